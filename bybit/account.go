@@ -1,12 +1,20 @@
 package bybit
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
 // 獲取錢包余額
 func (b *Client) GetSpotAccount() (Balances, error) {
+	return b.GetSpotAccountCtx(b.context())
+}
+
+// GetSpotAccountCtx 同 GetSpotAccount，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetSpotAccountCtx(ctx context.Context) (Balances, error) {
 	var ret AccountResult
 	params := map[string]interface{}{}
-	_, err := b.PublicRequest(http.MethodGet, "spot/v1/account", params, &ret)
+	_, err := b.PublicRequestCtx(ctx, http.MethodGet, "spot/v1/account", params, &ret)
 	if err != nil {
 		return nil, err
 	}
@@ -36,9 +44,14 @@ type BalanceVo struct {
 
 // 獲取錢包余額
 func (b *Client) GetFuturesAccount() (map[string]interface{}, error) {
+	return b.GetFuturesAccountCtx(b.context())
+}
+
+// GetFuturesAccountCtx 同 GetFuturesAccount，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetFuturesAccountCtx(ctx context.Context) (map[string]interface{}, error) {
 	var ret FuturesAccountResult
 	params := map[string]interface{}{}
-	_, err := b.PublicRequest(http.MethodGet, "v2/private/wallet/balance", params, &ret)
+	_, err := b.PublicRequestCtx(ctx, http.MethodGet, "v2/private/wallet/balance", params, &ret)
 	if err != nil {
 		return nil, err
 	}
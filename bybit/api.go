@@ -2,15 +2,16 @@ package bybit
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"go.uber.org/zap"
-	"io/ioutil"
 	"net/http"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -18,8 +19,11 @@ type Client struct {
 	baseURL          string // https://api-testnet.bybit.com/open-api/
 	apiKey           string
 	secretKey        string
-	serverTimeOffset int64 // 时间偏差(ms)
+	serverTimeOffset *int64 // 时间偏差(ms)，WithContext 的副本共享同一值
 	client           *http.Client
+	doer             Doer            // client 外包裹的中間件鏈
+	middlewares      []Middleware    // Use 追加的中間件，按追加順序由外到內
+	ctx              context.Context // WithContext 設置，默認 Background
 	debugMode        bool
 }
 
@@ -30,32 +34,48 @@ func New(httpClient *http.Client, baseURL string, apiKey string, secretKey strin
 		}
 	}
 	return &Client{
-		baseURL:   baseURL,
-		apiKey:    apiKey,
-		secretKey: secretKey,
-		client:    httpClient,
-		debugMode: debugMode,
+		baseURL:          baseURL,
+		apiKey:           apiKey,
+		secretKey:        secretKey,
+		serverTimeOffset: new(int64),
+		client:           httpClient,
+		doer:             httpClient,
+		debugMode:        debugMode,
 	}
 }
 
 // SetCorrectServerTime 校正服务器时间
 func (b *Client) SetCorrectServerTime() (err error) {
+	return b.SetCorrectServerTimeCtx(b.context())
+}
+
+// SetCorrectServerTimeCtx 同 SetCorrectServerTime，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) SetCorrectServerTimeCtx(ctx context.Context) (err error) {
 	var timeNow int64
-	timeNow, err = b.GetServerTime()
+	timeNow, err = b.GetServerTimeV5Ctx(ctx)
 	if err != nil {
 		return
 	}
-	b.serverTimeOffset = timeNow - time.Now().UnixNano()/1e6
+	atomic.StoreInt64(b.serverTimeOffset, timeNow-time.Now().UnixNano()/1e6)
 	return
 }
 
 // GetBalance Get Wallet Balance
 // coin: BTC,EOS,XRP,ETH,USDT
+//
+// Deprecated: v2 接口已下線，使用 GetWalletBalanceV5
 func (b *Client) GetWalletBalance(coin string) (result Balance, err error) {
+	return b.GetWalletBalanceCtx(b.context(), coin)
+}
+
+// GetWalletBalanceCtx 同 GetWalletBalance，ctx 用於取消請求或設置單次調用的超時
+//
+// Deprecated: v2 接口已下線，使用 GetWalletBalanceV5Ctx
+func (b *Client) GetWalletBalanceCtx(ctx context.Context, coin string) (result Balance, err error) {
 	var ret GetBalanceResult
 	params := map[string]interface{}{}
 	params["coin"] = coin
-	_, err = b.SignedRequest(http.MethodGet, "v2/private/wallet/balance", params, &ret) // v2/private/wallet/balance
+	_, err = b.SignedRequestCtx(ctx, http.MethodGet, "v2/private/wallet/balance", params, &ret) // v2/private/wallet/balance
 	if err != nil {
 		return
 	}
@@ -76,9 +96,14 @@ func (b *Client) GetWalletBalance(coin string) (result Balance, err error) {
 
 // GetLeverages 获取用户杠杆
 func (b *Client) GetLeverages() (result map[string]LeverageItem, err error) {
+	return b.GetLeveragesCtx(b.context())
+}
+
+// GetLeveragesCtx 同 GetLeverages，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetLeveragesCtx(ctx context.Context) (result map[string]LeverageItem, err error) {
 	var r GetLeverageResult
 	params := map[string]interface{}{}
-	_, err = b.SignedRequest(http.MethodGet, "user/leverage", params, &r)
+	_, err = b.SignedRequestCtx(ctx, http.MethodGet, "user/leverage", params, &r)
 	if err != nil {
 		return
 	}
@@ -88,12 +113,17 @@ func (b *Client) GetLeverages() (result map[string]LeverageItem, err error) {
 
 // SetLeverage 设置杠杆
 func (b *Client) SetLeverage(leverage int, symbol string) (err error) {
+	return b.SetLeverageCtx(b.context(), leverage, symbol)
+}
+
+// SetLeverageCtx 同 SetLeverage，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) SetLeverageCtx(ctx context.Context, leverage int, symbol string) (err error) {
 	var r BaseResult
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
 	params["buy_leverage"] = leverage
 	params["sell_leverage"] = leverage
-	_, err = b.SignedRequest(http.MethodPost, "private/linear/position/set-leverage", params, &r)
+	_, err = b.SignedRequestCtx(ctx, http.MethodPost, "private/linear/position/set-leverage", params, &r)
 	if err != nil {
 		return
 	}
@@ -102,13 +132,18 @@ func (b *Client) SetLeverage(leverage int, symbol string) (err error) {
 
 // SetIsolated 设置杠杆
 func (b *Client) SetIsolated(isIsolated bool, leverage int, symbol string) (err error) {
+	return b.SetIsolatedCtx(b.context(), isIsolated, leverage, symbol)
+}
+
+// SetIsolatedCtx 同 SetIsolated，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) SetIsolatedCtx(ctx context.Context, isIsolated bool, leverage int, symbol string) (err error) {
 	var r BaseResult
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
 	params["is_isolated"] = isIsolated
 	params["buy_leverage"] = leverage
 	params["sell_leverage"] = leverage
-	_, err = b.SignedRequest(http.MethodPost, "private/linear/position/set-leverage", params, &r)
+	_, err = b.SignedRequestCtx(ctx, http.MethodPost, "private/linear/position/set-leverage", params, &r)
 	if err != nil {
 		return
 	}
@@ -117,11 +152,16 @@ func (b *Client) SetIsolated(isIsolated bool, leverage int, symbol string) (err
 
 // GetPositions 获取我的仓位
 func (b *Client) GetPositions() (result []Position, err error) {
+	return b.GetPositionsCtx(b.context())
+}
+
+// GetPositionsCtx 同 GetPositions，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetPositionsCtx(ctx context.Context) (result []Position, err error) {
 	var r PositionListResult
 
 	params := map[string]interface{}{}
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodGet, "private/linear/position/list", params, &r)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodGet, "private/linear/position/list", params, &r)
 	if err != nil {
 		return
 	}
@@ -135,12 +175,17 @@ func (b *Client) GetPositions() (result []Position, err error) {
 
 // GetPosition 获取我的仓位
 func (b *Client) GetPosition(symbol string) (result []Position, err error) {
+	return b.GetPositionCtx(b.context(), symbol)
+}
+
+// GetPositionCtx 同 GetPosition，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetPositionCtx(ctx context.Context, symbol string) (result []Position, err error) {
 	var r GetPositionResult
 
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodGet, "private/linear/position/list", params, &r)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodGet, "private/linear/position/list", params, &r)
 	if err != nil {
 		return
 	}
@@ -154,13 +199,18 @@ func (b *Client) GetPosition(symbol string) (result []Position, err error) {
 
 // SetPositionMode 设置仓位模式
 func (b *Client) SetPositionMode(symbol, mode string) (err error) {
+	return b.SetPositionModeCtx(b.context(), symbol, mode)
+}
+
+// SetPositionModeCtx 同 SetPositionMode，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) SetPositionModeCtx(ctx context.Context, symbol, mode string) (err error) {
 	var r SetPositionModeResult
 
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
 	params["mode"] = mode
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodPost, "private/linear/position/switch-mode", params, &r)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodPost, "private/linear/position/switch-mode", params, &r)
 	if err != nil {
 		return
 	}
@@ -172,6 +222,10 @@ func (b *Client) SetPositionMode(symbol, mode string) (err error) {
 }
 
 func (b *Client) PublicRequest(method string, apiURL string, params map[string]interface{}, result interface{}) (resp []byte, err error) {
+	return b.PublicRequestCtx(b.context(), method, apiURL, params, result)
+}
+
+func (b *Client) PublicRequestCtx(ctx context.Context, method string, apiURL string, params map[string]interface{}, result interface{}) (resp []byte, err error) {
 	var keys []string
 	for k := range params {
 		keys = append(keys, k)
@@ -188,40 +242,42 @@ func (b *Client) PublicRequest(method string, apiURL string, params map[string]i
 	if param != "" {
 		fullURL += "?" + param
 	}
-	//if b.debugMode {
-	//	log.Printf("PublicRequest: %v", fullURL)
-	//}
 	var binBody = bytes.NewReader(make([]byte, 0))
 
 	// get a http request
 	var request *http.Request
-	request, err = http.NewRequest(method, fullURL, binBody)
+	request, err = http.NewRequestWithContext(ctx, method, fullURL, binBody)
 	if err != nil {
 		return
 	}
 	//request.Header.Add(utils.ExKind, "bybit")
-	var response *http.Response
-	response, err = b.client.Do(request)
-	if err != nil {
+	if resp, err = b.do(request); err != nil {
 		return
 	}
-	defer response.Body.Close()
 
-	resp, err = ioutil.ReadAll(response.Body)
-	if err != nil {
+	// 錯誤響應的 result 可能與結果類型不符，先檢查返回碼
+	if err = checkRetCode(resp); err != nil {
 		return
 	}
-
-	if b.debugMode {
-		zap.S().Info("PublicRequest: %v", string(resp))
-	}
-
 	err = json.Unmarshal(resp, result)
 	return
 }
 
+// SignedRequest 時間戳錯誤時校正服務器時間後重試一次
 func (b *Client) SignedRequest(method string, apiURL string, params map[string]interface{}, result interface{}) (resp []byte, err error) {
-	timestamp := time.Now().UnixNano()/1e6 + b.serverTimeOffset
+	return b.SignedRequestCtx(b.context(), method, apiURL, params, result)
+}
+
+func (b *Client) SignedRequestCtx(ctx context.Context, method string, apiURL string, params map[string]interface{}, result interface{}) (resp []byte, err error) {
+	resp, err = b.signedRequest(ctx, method, apiURL, params, result)
+	if IsTimestampError(err) && b.resyncServerTime(ctx) {
+		resp, err = b.signedRequest(ctx, method, apiURL, params, result)
+	}
+	return
+}
+
+func (b *Client) signedRequest(ctx context.Context, method string, apiURL string, params map[string]interface{}, result interface{}) (resp []byte, err error) {
+	timestamp := b.timestamp()
 
 	params["api_key"] = b.apiKey
 	params["timestamp"] = timestamp
@@ -243,14 +299,11 @@ func (b *Client) SignedRequest(method string, apiURL string, params map[string]i
 	param += "&sign=" + signature
 
 	fullURL := b.baseURL + apiURL + "?" + param
-	if b.debugMode {
-		zap.S().Info("SignedRequest: %v", fullURL)
-	}
 	var binBody = bytes.NewReader(make([]byte, 0))
 
 	// get a http request
 	var request *http.Request
-	request, err = http.NewRequest(method, fullURL, binBody)
+	request, err = http.NewRequestWithContext(ctx, method, fullURL, binBody)
 	if err != nil {
 		return
 	}
@@ -259,24 +312,45 @@ func (b *Client) SignedRequest(method string, apiURL string, params map[string]i
 	// 所有的签名接口都设置会不会有问题？
 	request.Header.Add("Referer", "AntBot")
 
-	var response *http.Response
-	response, err = b.client.Do(request)
-	if err != nil {
+	if resp, err = b.do(request); err != nil {
 		return
 	}
-	defer response.Body.Close()
 
-	resp, err = ioutil.ReadAll(response.Body)
-	if err != nil {
+	// 錯誤響應的 result 可能與結果類型不符，先檢查返回碼
+	if err = checkRetCode(resp); err != nil {
 		return
 	}
+	err = json.Unmarshal(resp, result)
+	return
+}
 
-	if b.debugMode {
-		zap.S().Info("SignedRequest: %v", string(resp))
+// checkRetCode 舊接口返回 ret_code，V5 返回 retCode
+func checkRetCode(resp []byte) error {
+	var ret struct {
+		RetCode   int    `json:"ret_code"`
+		RetMsg    string `json:"ret_msg"`
+		RetCodeV5 int    `json:"retCode"`
+		RetMsgV5  string `json:"retMsg"`
+	}
+	if err := json.Unmarshal(resp, &ret); err != nil {
+		return err
+	}
+	if ret.RetCode != 0 {
+		return newAPIError(ret.RetCode, ret.RetMsg)
 	}
+	if ret.RetCodeV5 != 0 {
+		return newAPIError(ret.RetCodeV5, ret.RetMsgV5)
+	}
+	return nil
+}
 
-	err = json.Unmarshal(resp, result)
-	return
+// resyncServerTime 校正服務器時間，成功返回 true
+func (b *Client) resyncServerTime(ctx context.Context) bool {
+	if err := b.SetCorrectServerTimeCtx(ctx); err != nil {
+		zap.S().Errorf("bybit: resync server time: %v", err)
+		return false
+	}
+	return true
 }
 
 func (b *Client) getSigned(param string) string {
@@ -1,12 +1,24 @@
 package bybit
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 )
 
+// Deprecated: v2 接口已下線，使用 CreateOrderV5
 func (b *Client) CreateOrderV2(side string, orderType string, price float64,
+	qty int, timeInForce string, takeProfit float64, stopLoss float64, reduceOnly bool,
+	closeOnTrigger bool, orderLinkID string, symbol string) (result OrderV2, err error) {
+	return b.CreateOrderV2Ctx(b.context(), side, orderType, price, qty, timeInForce, takeProfit, stopLoss,
+		reduceOnly, closeOnTrigger, orderLinkID, symbol)
+}
+
+// CreateOrderV2Ctx 同 CreateOrderV2，ctx 用於取消請求或設置單次調用的超時
+//
+// Deprecated: v2 接口已下線，使用 CreateOrderV5Ctx
+func (b *Client) CreateOrderV2Ctx(ctx context.Context, side string, orderType string, price float64,
 	qty int, timeInForce string, takeProfit float64, stopLoss float64, reduceOnly bool,
 	closeOnTrigger bool, orderLinkID string, symbol string) (result OrderV2, err error) {
 	var cResult CreateOrderV2Result
@@ -35,7 +47,7 @@ func (b *Client) CreateOrderV2(side string, orderType string, price float64,
 		params["order_link_id"] = orderLinkID
 	}
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodPost, "v2/private/order/create", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodPost, "v2/private/order/create", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -67,6 +79,11 @@ func (b *Client) CreateOrderV2(side string, orderType string, price float64,
 // 									1-雙向持倉Buy
 // 									2-雙向持倉Sell
 func (b *Client) CreateLinearOrder(param *CreateOrderParam) (result Order, err error) {
+	return b.CreateLinearOrderCtx(b.context(), param)
+}
+
+// CreateLinearOrderCtx 同 CreateLinearOrder，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) CreateLinearOrderCtx(ctx context.Context, param *CreateOrderParam) (result Order, err error) {
 	var cResult CreateOrderResult
 	params := map[string]interface{}{}
 	params["side"] = param.Side
@@ -96,7 +113,7 @@ func (b *Client) CreateLinearOrder(param *CreateOrderParam) (result Order, err e
 		params["sl_trigger_by"] = param.SlTriggerBy
 	}
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodPost, "private/linear/order/create", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodPost, "private/linear/order/create", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -118,6 +135,11 @@ func (b *Client) CreateLinearOrder(param *CreateOrderParam) (result Order, err e
 // limit				integer	每頁數量, 最大50. 默認每頁20條
 // order_status			string	指定訂單狀態查詢訂單列表。不傳該參數則默認查詢所有狀態訂單。該參數支持多狀態查詢，狀態之間用英文逗號分割。
 func (b *Client) GetLinearOrders(orderID, orderLinkID string, page int, limit int, order string, orderStatus string, symbol string) (result []Order, err error) {
+	return b.GetLinearOrdersCtx(b.context(), orderID, orderLinkID, page, limit, order, orderStatus, symbol)
+}
+
+// GetLinearOrdersCtx 同 GetLinearOrders，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetLinearOrdersCtx(ctx context.Context, orderID, orderLinkID string, page int, limit int, order string, orderStatus string, symbol string) (result []Order, err error) {
 	var cResult OrderListResult
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
@@ -136,7 +158,7 @@ func (b *Client) GetLinearOrders(orderID, orderLinkID string, page int, limit in
 		params["order_status"] = orderStatus
 	}
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodGet, "private/linear/order/list", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodGet, "private/linear/order/list", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -158,7 +180,16 @@ func (b *Client) GetLinearOrders(orderID, orderLinkID string, page int, limit in
 // timeInForce: 执行策略, 有效选项:GoodTillCancel,ImmediateOrCancel,FillOrKill,PostOnly
 // reduceOnly: 只减仓
 // symbol: 产品类型, 有效选项:BTCUSD,ETHUSD (BTCUSD ETHUSD)
+//
+// Deprecated: 舊版接口已下線，使用 CreateOrderV5
 func (b *Client) CreateOrder(side string, orderType string, price float64, qty int, timeInForce string, reduceOnly bool, symbol string) (result Order, err error) {
+	return b.CreateOrderCtx(b.context(), side, orderType, price, qty, timeInForce, reduceOnly, symbol)
+}
+
+// CreateOrderCtx 同 CreateOrder，ctx 用於取消請求或設置單次調用的超時
+//
+// Deprecated: 舊版接口已下線，使用 CreateOrderV5Ctx
+func (b *Client) CreateOrderCtx(ctx context.Context, side string, orderType string, price float64, qty int, timeInForce string, reduceOnly bool, symbol string) (result Order, err error) {
 	var cResult CreateOrderResult
 	params := map[string]interface{}{}
 	params["side"] = side
@@ -171,7 +202,7 @@ func (b *Client) CreateOrder(side string, orderType string, price float64, qty i
 		params["reduce_only"] = true
 	}
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodPost, "open-api/order/create", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodPost, "open-api/order/create", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -184,6 +215,11 @@ func (b *Client) CreateOrder(side string, orderType string, price float64, qty i
 }
 
 func (b *Client) ReplaceOrder(symbol string, orderID string, qty int, price float64) (result Order, err error) {
+	return b.ReplaceOrderCtx(b.context(), symbol, orderID, qty, price)
+}
+
+// ReplaceOrderCtx 同 ReplaceOrder，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) ReplaceOrderCtx(ctx context.Context, symbol string, orderID string, qty int, price float64) (result Order, err error) {
 	var cResult ReplaceOrderResult
 	params := map[string]interface{}{}
 	params["order_id"] = orderID
@@ -195,7 +231,7 @@ func (b *Client) ReplaceOrder(symbol string, orderID string, qty int, price floa
 		params["p_r_price"] = price
 	}
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodPost, "open-api/order/replace", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodPost, "open-api/order/replace", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -221,6 +257,12 @@ func (b *Client) ReplaceOrder(symbol string, orderID string, qty int, price floa
 // reduceOnly: 只减仓
 // symbol: 产品类型, 有效选项:BTCUSD,ETHUSD (BTCUSD ETHUSD)
 func (b *Client) CreateStopOrder(side string, orderType string, price float64, basePrice float64, stopPx float64,
+	qty int, triggerBy string, timeInForce string, reduceOnly bool, symbol string) (result Order, err error) {
+	return b.CreateStopOrderCtx(b.context(), side, orderType, price, basePrice, stopPx, qty, triggerBy, timeInForce, reduceOnly, symbol)
+}
+
+// CreateStopOrderCtx 同 CreateStopOrder，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) CreateStopOrderCtx(ctx context.Context, side string, orderType string, price float64, basePrice float64, stopPx float64,
 	qty int, triggerBy string, timeInForce string, reduceOnly bool, symbol string) (result Order, err error) {
 	var cResult CreateOrderResult
 	params := map[string]interface{}{}
@@ -241,7 +283,7 @@ func (b *Client) CreateStopOrder(side string, orderType string, price float64, b
 		params["trigger_by"] = triggerBy
 	}
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodPost, "open-api/stop-order/create", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodPost, "open-api/stop-order/create", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -263,7 +305,13 @@ func (b *Client) CreateStopOrder(side string, orderType string, price float64, b
 // limit: 一页数量，一页默认展示20条数据
 func (b *Client) GetOrders(sort string, order string, page int,
 	limit int, orderStatus string, symbol string) (result []Order, err error) {
-	return b.getOrders("", "", sort, order, page, limit, orderStatus, symbol)
+	return b.GetOrdersCtx(b.context(), sort, order, page, limit, orderStatus, symbol)
+}
+
+// GetOrdersCtx 同 GetOrders，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetOrdersCtx(ctx context.Context, sort string, order string, page int,
+	limit int, orderStatus string, symbol string) (result []Order, err error) {
+	return b.getOrders(ctx, "", "", sort, order, page, limit, orderStatus, symbol)
 }
 
 // getOrders 查询活动委托
@@ -274,7 +322,7 @@ func (b *Client) GetOrders(sort string, order string, page int,
 // order: 升序降序， 默认降序 (desc asc)
 // page: 页码，默认取第一页数据
 // limit: 一页数量，一页默认展示20条数据
-func (b *Client) getOrders(orderID string, orderLinkID string, sort string, order string, page int,
+func (b *Client) getOrders(ctx context.Context, orderID string, orderLinkID string, sort string, order string, page int,
 	limit int, orderStatus string, symbol string) (result []Order, err error) {
 	var cResult OrderListResult
 
@@ -302,7 +350,7 @@ func (b *Client) getOrders(orderID string, orderLinkID string, sort string, orde
 		params["order_status"] = orderStatus
 	}
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodGet, "open-api/order/list", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodGet, "open-api/order/list", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -324,6 +372,12 @@ func (b *Client) getOrders(orderID string, orderLinkID string, sort string, orde
 // limit: 一页数量，默认一页展示20条数据;最大支持50条每页
 func (b *Client) GetStopOrders(orderID string, orderLinkID string, stopOrderStatus string, order string,
 	page int, limit int, symbol string) (result GetStopOrdersResult, err error) {
+	return b.GetStopOrdersCtx(b.context(), orderID, orderLinkID, stopOrderStatus, order, page, limit, symbol)
+}
+
+// GetStopOrdersCtx 同 GetStopOrders，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetStopOrdersCtx(ctx context.Context, orderID string, orderLinkID string, stopOrderStatus string, order string,
+	page int, limit int, symbol string) (result GetStopOrdersResult, err error) {
 
 	if limit == 0 {
 		limit = 20
@@ -346,7 +400,7 @@ func (b *Client) GetStopOrders(orderID string, orderLinkID string, stopOrderStat
 	params["page"] = page
 	params["limit"] = limit
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodGet, "open-api/stop-order/list", params, &result)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodGet, "open-api/stop-order/list", params, &result)
 	if err != nil {
 		return
 	}
@@ -360,6 +414,11 @@ func (b *Client) GetStopOrders(orderID string, orderLinkID string, stopOrderStat
 
 // GetOrderByID
 func (b *Client) GetOrderByID(orderID string, orderLinkID string, symbol string) (result OrderV2, err error) {
+	return b.GetOrderByIDCtx(b.context(), orderID, orderLinkID, symbol)
+}
+
+// GetOrderByIDCtx 同 GetOrderByID，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetOrderByIDCtx(ctx context.Context, orderID string, orderLinkID string, symbol string) (result OrderV2, err error) {
 	var cResult QueryOrderResult
 
 	params := map[string]interface{}{}
@@ -371,7 +430,7 @@ func (b *Client) GetOrderByID(orderID string, orderLinkID string, symbol string)
 		params["order_link_id"] = orderLinkID
 	}
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodGet, "v2/private/order", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodGet, "v2/private/order", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -386,8 +445,13 @@ func (b *Client) GetOrderByID(orderID string, orderLinkID string, symbol string)
 
 // GetOrderByOrderLinkID ...
 func (b *Client) GetOrderByOrderLinkID(orderLinkID string, symbol string) (result Order, err error) {
+	return b.GetOrderByOrderLinkIDCtx(b.context(), orderLinkID, symbol)
+}
+
+// GetOrderByOrderLinkIDCtx 同 GetOrderByOrderLinkID，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetOrderByOrderLinkIDCtx(ctx context.Context, orderLinkID string, symbol string) (result Order, err error) {
 	var orders []Order
-	orders, err = b.getOrders("", orderLinkID, "", "", 0, 20, "", symbol)
+	orders, err = b.getOrders(ctx, "", orderLinkID, "", "", 0, 20, "", symbol)
 	if err != nil {
 		return
 	}
@@ -403,12 +467,17 @@ func (b *Client) GetOrderByOrderLinkID(orderLinkID string, symbol string) (resul
 // orderID: 活动委托单ID, 数据来自创建活动委托单返回的订单唯一ID
 // symbol:
 func (b *Client) CancelOrder(orderID string, symbol string) (result Order, err error) {
+	return b.CancelOrderCtx(b.context(), orderID, symbol)
+}
+
+// CancelOrderCtx 同 CancelOrder，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) CancelOrderCtx(ctx context.Context, orderID string, symbol string) (result Order, err error) {
 	var cResult CancelOrderResult
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
 	params["order_id"] = orderID
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodPost, "open-api/order/cancel", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodPost, "open-api/order/cancel", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -425,6 +494,11 @@ func (b *Client) CancelOrder(orderID string, symbol string) (result Order, err e
 // orderID: 活动委托单ID, 数据来自创建活动委托单返回的订单唯一ID
 // symbol:
 func (b *Client) CancelOrderV2(orderID string, orderLinkID string, symbol string) (result OrderV2, err error) {
+	return b.CancelOrderV2Ctx(b.context(), orderID, orderLinkID, symbol)
+}
+
+// CancelOrderV2Ctx 同 CancelOrderV2，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) CancelOrderV2Ctx(ctx context.Context, orderID string, orderLinkID string, symbol string) (result OrderV2, err error) {
 	var cResult CancelOrderV2Result
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
@@ -435,7 +509,7 @@ func (b *Client) CancelOrderV2(orderID string, orderLinkID string, symbol string
 		params["order_link_id"] = orderLinkID
 	}
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodPost, "v2/private/order/cancel", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodPost, "v2/private/order/cancel", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -450,11 +524,16 @@ func (b *Client) CancelOrderV2(orderID string, orderLinkID string, symbol string
 
 // CancelAllOrder Cancel All Active Orders
 func (b *Client) CancelAllOrder(symbol string) (result []OrderV2, err error) {
+	return b.CancelAllOrderCtx(b.context(), symbol)
+}
+
+// CancelAllOrderCtx 同 CancelAllOrder，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) CancelAllOrderCtx(ctx context.Context, symbol string) (result []OrderV2, err error) {
 	var cResult CancelAllOrderV2Result
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodPost, "v2/private/order/cancelAll", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodPost, "v2/private/order/cancelAll", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -471,12 +550,17 @@ func (b *Client) CancelAllOrder(symbol string) (result []OrderV2, err error) {
 // orderID: 活动条件委托单ID, 数据来自创建活动委托单返回的订单唯一ID
 // symbol:
 func (b *Client) CancelStopOrder(orderID string, symbol string) (result Order, err error) {
+	return b.CancelStopOrderCtx(b.context(), orderID, symbol)
+}
+
+// CancelStopOrderCtx 同 CancelStopOrder，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) CancelStopOrderCtx(ctx context.Context, orderID string, symbol string) (result Order, err error) {
 	var cResult CancelOrderResult
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
 	params["stop_order_id"] = orderID
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodPost, "open-api/stop-order/cancel", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodPost, "open-api/stop-order/cancel", params, &cResult)
 	if err != nil {
 		return
 	}
@@ -492,11 +576,16 @@ func (b *Client) CancelStopOrder(orderID string, symbol string) (result Order, e
 // CancelAllStopOrders 撤消全部条件委托单
 // symbol:
 func (b *Client) CancelAllStopOrders(symbol string) (result []StopOrderV2, err error) {
+	return b.CancelAllStopOrdersCtx(b.context(), symbol)
+}
+
+// CancelAllStopOrdersCtx 同 CancelAllStopOrders，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) CancelAllStopOrdersCtx(ctx context.Context, symbol string) (result []StopOrderV2, err error) {
 	var cResult CancelStopOrdersV2Result
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
 	var resp []byte
-	resp, err = b.SignedRequest(http.MethodPost, "v2/private/stop-order/cancelAll", params, &cResult)
+	resp, err = b.SignedRequestCtx(ctx, http.MethodPost, "v2/private/stop-order/cancelAll", params, &cResult)
 	if err != nil {
 		return
 	}
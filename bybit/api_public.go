@@ -1,6 +1,7 @@
 package bybit
 
 import (
+	"context"
 	"net/http"
 	"sort"
 	"strconv"
@@ -8,10 +9,19 @@ import (
 )
 
 // GetServerTime Get server time.
+//
+// Deprecated: v2 接口已下線，使用 GetServerTimeV5
 func (b *Client) GetServerTime() (timeNow int64, err error) {
+	return b.GetServerTimeCtx(b.context())
+}
+
+// GetServerTimeCtx 同 GetServerTime，ctx 用於取消請求或設置單次調用的超時
+//
+// Deprecated: v2 接口已下線，使用 GetServerTimeV5Ctx
+func (b *Client) GetServerTimeCtx(ctx context.Context) (timeNow int64, err error) {
 	params := map[string]interface{}{}
 	var ret BaseResult
-	_, err = b.PublicRequest(http.MethodGet, "v2/public/time", params, &ret)
+	_, err = b.PublicRequestCtx(ctx, http.MethodGet, "v2/public/time", params, &ret)
 	if err != nil {
 		return
 	}
@@ -27,10 +37,15 @@ func (b *Client) GetServerTime() (timeNow int64, err error) {
 // GetOrderBook Get the orderbook
 // 正反向合约通用
 func (b *Client) GetOrderBook(symbol string) (result OrderBook, err error) {
+	return b.GetOrderBookCtx(b.context(), symbol)
+}
+
+// GetOrderBookCtx 同 GetOrderBook，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetOrderBookCtx(ctx context.Context, symbol string) (result OrderBook, err error) {
 	var ret GetOrderBookResult
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
-	_, err = b.PublicRequest(http.MethodGet, "v2/public/orderBook/L2", params, &ret)
+	_, err = b.PublicRequestCtx(ctx, http.MethodGet, "v2/public/orderBook/L2", params, &ret)
 	if err != nil {
 		return
 	}
@@ -72,6 +87,11 @@ func (b *Client) GetOrderBook(symbol string) (result OrderBook, err error) {
 // from: From timestamp in seconds
 // limit: Limit for data size per page, max size is 200. Default as showing 200 pieces of data per page
 func (b *Client) GetKLine(symbol string, interval string, from int64, limit int) (result []OHLC, err error) {
+	return b.GetKLineCtx(b.context(), symbol, interval, from, limit)
+}
+
+// GetKLineCtx 同 GetKLine，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetKLineCtx(ctx context.Context, symbol string, interval string, from int64, limit int) (result []OHLC, err error) {
 	var ret GetKlineResult
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
@@ -80,7 +100,7 @@ func (b *Client) GetKLine(symbol string, interval string, from int64, limit int)
 	if limit > 0 {
 		params["limit"] = limit
 	}
-	_, err = b.PublicRequest(http.MethodGet, "v2/public/kline/list", params, &ret)
+	_, err = b.PublicRequestCtx(ctx, http.MethodGet, "v2/public/kline/list", params, &ret)
 	if err != nil {
 		return
 	}
@@ -89,10 +109,15 @@ func (b *Client) GetKLine(symbol string, interval string, from int64, limit int)
 }
 
 func (b *Client) GetTickers() (result []Ticker, err error) {
+	return b.GetTickersCtx(b.context())
+}
+
+// GetTickersCtx 同 GetTickers，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetTickersCtx(ctx context.Context) (result []Ticker, err error) {
 	// https://api-testnet.bybit.com/v2/public/tickers
 	var ret GetTickersResult
 	params := map[string]interface{}{}
-	_, err = b.PublicRequest(http.MethodGet, "v2/public/tickers", params, &ret)
+	_, err = b.PublicRequestCtx(ctx, http.MethodGet, "v2/public/tickers", params, &ret)
 	if err != nil {
 		return
 	}
@@ -103,6 +128,11 @@ func (b *Client) GetTickers() (result []Ticker, err error) {
 // from: From ID. Default: return latest data
 // limit: Number of results. Default 500; max 1000
 func (b *Client) GetTradingRecords(symbol string, from int64, limit int) (result []TradingRecord, err error) {
+	return b.GetTradingRecordsCtx(b.context(), symbol, from, limit)
+}
+
+// GetTradingRecordsCtx 同 GetTradingRecords，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetTradingRecordsCtx(ctx context.Context, symbol string, from int64, limit int) (result []TradingRecord, err error) {
 	var ret GetTradingRecordsResult
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
@@ -112,7 +142,7 @@ func (b *Client) GetTradingRecords(symbol string, from int64, limit int) (result
 	if limit > 0 {
 		params["limit"] = limit
 	}
-	_, err = b.PublicRequest(http.MethodGet, "v2/public/trading-records", params, &ret)
+	_, err = b.PublicRequestCtx(ctx, http.MethodGet, "v2/public/trading-records", params, &ret)
 	if err != nil {
 		return
 	}
@@ -121,9 +151,14 @@ func (b *Client) GetTradingRecords(symbol string, from int64, limit int) (result
 }
 
 func (b *Client) GetSymbols() (result []SymbolInfo, err error) {
+	return b.GetSymbolsCtx(b.context())
+}
+
+// GetSymbolsCtx 同 GetSymbols，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetSymbolsCtx(ctx context.Context) (result []SymbolInfo, err error) {
 	var ret GetSymbolsResult
 	params := map[string]interface{}{}
-	_, err = b.PublicRequest(http.MethodGet, "v2/public/symbols", params, &ret)
+	_, err = b.PublicRequestCtx(ctx, http.MethodGet, "v2/public/symbols", params, &ret)
 	if err != nil {
 		return
 	}
@@ -133,9 +168,14 @@ func (b *Client) GetSymbols() (result []SymbolInfo, err error) {
 
 // 现货交易对信息
 func (b *Client) GetSpotSymbols() ([]Symbol, error) {
+	return b.GetSpotSymbolsCtx(b.context())
+}
+
+// GetSpotSymbolsCtx 同 GetSpotSymbols，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetSpotSymbolsCtx(ctx context.Context) ([]Symbol, error) {
 	var ret SymbolsResult
 	params := map[string]interface{}{}
-	_, err := b.PublicRequest(http.MethodGet, "spot/v1/symbols", params, &ret)
+	_, err := b.PublicRequestCtx(ctx, http.MethodGet, "spot/v1/symbols", params, &ret)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package bybit
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -10,6 +11,11 @@ import (
 // from: From timestamp in seconds
 // limit: Limit for data size per page, max size is 200. Default as showing 200 pieces of data per page
 func (b *Client) GetKLine2(symbol string, interval string, from int64, limit int) (result []OHLC2, err error) {
+	return b.GetKLine2Ctx(b.context(), symbol, interval, from, limit)
+}
+
+// GetKLine2Ctx 同 GetKLine2，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetKLine2Ctx(ctx context.Context, symbol string, interval string, from int64, limit int) (result []OHLC2, err error) {
 	var ret GetKlineResult2
 	params := map[string]interface{}{}
 	params["symbol"] = symbol
@@ -18,7 +24,7 @@ func (b *Client) GetKLine2(symbol string, interval string, from int64, limit int
 	if limit > 0 {
 		params["limit"] = limit
 	}
-	_, err = b.PublicRequest(http.MethodGet, "public/linear/kline", params, &ret)
+	_, err = b.PublicRequestCtx(ctx, http.MethodGet, "public/linear/kline", params, &ret)
 	if err != nil {
 		return
 	}
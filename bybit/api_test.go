@@ -0,0 +1,61 @@
+package bybit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_SignedRequest_TimestampRetry(t *testing.T) {
+	var calls, resyncs int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/market/time":
+			resyncs++
+			fmt.Fprintf(w, `{"retCode":0,"retMsg":"OK","result":{"timeSecond":"%d","timeNano":"%d"}}`, time.Now().Unix(), time.Now().UnixNano())
+		case "/private/linear/position/list":
+			calls++
+			if calls == 1 {
+				// 錯誤響應的 result 是對象，無法解碼到 []Position
+				fmt.Fprint(w, `{"ret_code":10002,"ret_msg":"invalid request, please check your timestamp","result":{}}`)
+				return
+			}
+			fmt.Fprint(w, `{"ret_code":0,"ret_msg":"OK","result":[{"symbol":"BTCUSDT","side":"Buy","size":1}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	b := New(server.Client(), server.URL+"/", "key", "secret", false)
+	positions, err := b.GetPosition("BTCUSDT")
+	if err != nil {
+		t.Fatalf("GetPosition() error = %v", err)
+	}
+	if calls != 2 || resyncs != 1 {
+		t.Errorf("calls = %d, resyncs = %d, want 2 and 1", calls, resyncs)
+	}
+	if len(positions) != 1 {
+		t.Errorf("GetPosition() = %+v, want 1 position", positions)
+	}
+}
+
+func TestClient_PublicRequest_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ret_code":10001,"ret_msg":"params error","result":{}}`)
+	}))
+	defer server.Close()
+
+	b := New(server.Client(), server.URL+"/", "", "", false)
+	var result struct {
+		Result []int `json:"result"`
+	}
+	_, err := b.PublicRequest(http.MethodGet, "v2/public/symbols", nil, &result)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrCode10001 {
+		t.Errorf("PublicRequest() error = %v, want *APIError with code %d", err, ErrCode10001)
+	}
+}
@@ -0,0 +1,103 @@
+package bybit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// GetServerTimeV5 服務器時間(ms)
+// GET /v5/market/time
+func (b *Client) GetServerTimeV5() (timeNow int64, err error) {
+	return b.GetServerTimeV5Ctx(b.context())
+}
+
+// GetServerTimeV5Ctx 同 GetServerTimeV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetServerTimeV5Ctx(ctx context.Context) (timeNow int64, err error) {
+	var ret ServerTimeV5
+	_, err = b.PublicRequestV5Ctx(ctx, http.MethodGet, "v5/market/time", nil, &ret)
+	if err != nil {
+		return
+	}
+	var nano int64
+	nano, err = strconv.ParseInt(ret.TimeNano, 10, 64)
+	if err != nil {
+		return
+	}
+	timeNow = nano / 1e6
+	return
+}
+
+// GetInstrumentsV5 交易對信息
+// GET /v5/market/instruments-info
+// category	*	spot / linear / inverse / option
+// status			Trading / PreLaunch / Delivering / Closed
+// limit			每頁數量 [1, 1000]，cursor 為上一頁的 NextPageCursor
+func (b *Client) GetInstrumentsV5(category, symbol, baseCoin, status string, limit int, cursor string) (result ListV5[InstrumentV5], err error) {
+	return b.GetInstrumentsV5Ctx(b.context(), category, symbol, baseCoin, status, limit, cursor)
+}
+
+// GetInstrumentsV5Ctx 同 GetInstrumentsV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetInstrumentsV5Ctx(ctx context.Context, category, symbol, baseCoin, status string, limit int, cursor string) (result ListV5[InstrumentV5], err error) {
+	params := map[string]interface{}{"category": category}
+	setParam(params, "symbol", symbol)
+	setParam(params, "baseCoin", baseCoin)
+	setParam(params, "status", status)
+	setParam(params, "limit", limit)
+	setParam(params, "cursor", cursor)
+	_, err = b.PublicRequestV5Ctx(ctx, http.MethodGet, "v5/market/instruments-info", params, &result)
+	return
+}
+
+// GetTickersV5 行情
+// GET /v5/market/tickers
+// symbol 為空返回該 category 全部交易對
+func (b *Client) GetTickersV5(category, symbol, baseCoin string) (result []TickerV5, err error) {
+	return b.GetTickersV5Ctx(b.context(), category, symbol, baseCoin)
+}
+
+// GetTickersV5Ctx 同 GetTickersV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetTickersV5Ctx(ctx context.Context, category, symbol, baseCoin string) (result []TickerV5, err error) {
+	var ret ListV5[TickerV5]
+	params := map[string]interface{}{"category": category}
+	setParam(params, "symbol", symbol)
+	setParam(params, "baseCoin", baseCoin)
+	_, err = b.PublicRequestV5Ctx(ctx, http.MethodGet, "v5/market/tickers", params, &ret)
+	result = ret.List
+	return
+}
+
+// GetOrderBookV5 深度
+// GET /v5/market/orderbook
+// limit: spot [1,200] / linear,inverse [1,500] / option [1,25]
+func (b *Client) GetOrderBookV5(category, symbol string, limit int) (result OrderBookV5, err error) {
+	return b.GetOrderBookV5Ctx(b.context(), category, symbol, limit)
+}
+
+// GetOrderBookV5Ctx 同 GetOrderBookV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetOrderBookV5Ctx(ctx context.Context, category, symbol string, limit int) (result OrderBookV5, err error) {
+	params := map[string]interface{}{"category": category, "symbol": symbol}
+	setParam(params, "limit", limit)
+	_, err = b.PublicRequestV5Ctx(ctx, http.MethodGet, "v5/market/orderbook", params, &result)
+	return
+}
+
+// GetKLineV5 K線，按時間倒序
+// GET /v5/market/kline
+// interval: 1 3 5 15 30 60 120 240 360 720 D W M
+// start/end: 毫秒時間戳，0 表示不限；limit 最大 1000
+func (b *Client) GetKLineV5(category, symbol, interval string, start, end int64, limit int) (result []KlineV5, err error) {
+	return b.GetKLineV5Ctx(b.context(), category, symbol, interval, start, end, limit)
+}
+
+// GetKLineV5Ctx 同 GetKLineV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetKLineV5Ctx(ctx context.Context, category, symbol, interval string, start, end int64, limit int) (result []KlineV5, err error) {
+	var ret KlineListV5
+	params := map[string]interface{}{"category": category, "symbol": symbol, "interval": interval}
+	setParam(params, "start", start)
+	setParam(params, "end", end)
+	setParam(params, "limit", limit)
+	_, err = b.PublicRequestV5Ctx(ctx, http.MethodGet, "v5/market/kline", params, &ret)
+	result = ret.List
+	return
+}
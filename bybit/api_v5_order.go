@@ -0,0 +1,83 @@
+package bybit
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateOrderV5 下單
+// POST /v5/order/create
+func (b *Client) CreateOrderV5(param *CreateOrderParamV5) (result OrderResultV5, err error) {
+	return b.CreateOrderV5Ctx(b.context(), param)
+}
+
+// CreateOrderV5Ctx 同 CreateOrderV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) CreateOrderV5Ctx(ctx context.Context, param *CreateOrderParamV5) (result OrderResultV5, err error) {
+	_, err = b.SignedRequestV5Ctx(ctx, http.MethodPost, "v5/order/create", param.params(), &result)
+	return
+}
+
+// AmendOrderV5 改單，僅支持未成交或部分成交的訂單
+// POST /v5/order/amend
+func (b *Client) AmendOrderV5(param *AmendOrderParamV5) (result OrderResultV5, err error) {
+	return b.AmendOrderV5Ctx(b.context(), param)
+}
+
+// AmendOrderV5Ctx 同 AmendOrderV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) AmendOrderV5Ctx(ctx context.Context, param *AmendOrderParamV5) (result OrderResultV5, err error) {
+	_, err = b.SignedRequestV5Ctx(ctx, http.MethodPost, "v5/order/amend", param.params(), &result)
+	return
+}
+
+// CancelOrderV5 撤單
+// POST /v5/order/cancel
+func (b *Client) CancelOrderV5(param *CancelOrderParamV5) (result OrderResultV5, err error) {
+	return b.CancelOrderV5Ctx(b.context(), param)
+}
+
+// CancelOrderV5Ctx 同 CancelOrderV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) CancelOrderV5Ctx(ctx context.Context, param *CancelOrderParamV5) (result OrderResultV5, err error) {
+	_, err = b.SignedRequestV5Ctx(ctx, http.MethodPost, "v5/order/cancel", param.params(), &result)
+	return
+}
+
+// CancelAllOrdersV5 全部撤單
+// POST /v5/order/cancel-all
+// linear/inverse 需指定 symbol、baseCoin 或 settleCoin 之一
+func (b *Client) CancelAllOrdersV5(category, symbol, baseCoin, settleCoin string) (result CancelAllResultV5, err error) {
+	return b.CancelAllOrdersV5Ctx(b.context(), category, symbol, baseCoin, settleCoin)
+}
+
+// CancelAllOrdersV5Ctx 同 CancelAllOrdersV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) CancelAllOrdersV5Ctx(ctx context.Context, category, symbol, baseCoin, settleCoin string) (result CancelAllResultV5, err error) {
+	params := map[string]interface{}{"category": category}
+	setParam(params, "symbol", symbol)
+	setParam(params, "baseCoin", baseCoin)
+	setParam(params, "settleCoin", settleCoin)
+	_, err = b.SignedRequestV5Ctx(ctx, http.MethodPost, "v5/order/cancel-all", params, &result)
+	return
+}
+
+// GetOpenOrdersV5 查詢實時委託（未成交、部分成交及最近結束的訂單）
+// GET /v5/order/realtime
+func (b *Client) GetOpenOrdersV5(param *QueryOrderParamV5) (result ListV5[OrderV5], err error) {
+	return b.GetOpenOrdersV5Ctx(b.context(), param)
+}
+
+// GetOpenOrdersV5Ctx 同 GetOpenOrdersV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetOpenOrdersV5Ctx(ctx context.Context, param *QueryOrderParamV5) (result ListV5[OrderV5], err error) {
+	_, err = b.SignedRequestV5Ctx(ctx, http.MethodGet, "v5/order/realtime", param.params(), &result)
+	return
+}
+
+// GetOrderHistoryV5 查詢歷史訂單，默認最近 7 天
+// GET /v5/order/history
+func (b *Client) GetOrderHistoryV5(param *QueryOrderParamV5) (result ListV5[OrderV5], err error) {
+	return b.GetOrderHistoryV5Ctx(b.context(), param)
+}
+
+// GetOrderHistoryV5Ctx 同 GetOrderHistoryV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetOrderHistoryV5Ctx(ctx context.Context, param *QueryOrderParamV5) (result ListV5[OrderV5], err error) {
+	_, err = b.SignedRequestV5Ctx(ctx, http.MethodGet, "v5/order/history", param.params(), &result)
+	return
+}
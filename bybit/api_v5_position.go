@@ -0,0 +1,75 @@
+package bybit
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetPositionsV5 查詢持倉
+// GET /v5/position/list
+// linear 需指定 symbol 或 settleCoin；limit [1,200]，cursor 為上一頁的 NextPageCursor
+func (b *Client) GetPositionsV5(category, symbol, settleCoin string, limit int, cursor string) (result ListV5[PositionV5], err error) {
+	return b.GetPositionsV5Ctx(b.context(), category, symbol, settleCoin, limit, cursor)
+}
+
+// GetPositionsV5Ctx 同 GetPositionsV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetPositionsV5Ctx(ctx context.Context, category, symbol, settleCoin string, limit int, cursor string) (result ListV5[PositionV5], err error) {
+	params := map[string]interface{}{"category": category}
+	setParam(params, "symbol", symbol)
+	setParam(params, "settleCoin", settleCoin)
+	setParam(params, "limit", limit)
+	setParam(params, "cursor", cursor)
+	_, err = b.SignedRequestV5Ctx(ctx, http.MethodGet, "v5/position/list", params, &result)
+	return
+}
+
+// SetLeverageV5 設置槓桿，統一賬戶全倉模式下 buy/sell 必須相同
+// POST /v5/position/set-leverage
+func (b *Client) SetLeverageV5(category, symbol, buyLeverage, sellLeverage string) (err error) {
+	return b.SetLeverageV5Ctx(b.context(), category, symbol, buyLeverage, sellLeverage)
+}
+
+// SetLeverageV5Ctx 同 SetLeverageV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) SetLeverageV5Ctx(ctx context.Context, category, symbol, buyLeverage, sellLeverage string) (err error) {
+	params := map[string]interface{}{
+		"category":     category,
+		"symbol":       symbol,
+		"buyLeverage":  buyLeverage,
+		"sellLeverage": sellLeverage,
+	}
+	_, err = b.SignedRequestV5Ctx(ctx, http.MethodPost, "v5/position/set-leverage", params, nil)
+	return
+}
+
+// SwitchPositionModeV5 切換持倉模式
+// POST /v5/position/switch-mode
+// mode: 0 單向持倉 3 雙向持倉；symbol 與 coin 二選一
+func (b *Client) SwitchPositionModeV5(category, symbol, coin string, mode int) (err error) {
+	return b.SwitchPositionModeV5Ctx(b.context(), category, symbol, coin, mode)
+}
+
+// SwitchPositionModeV5Ctx 同 SwitchPositionModeV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) SwitchPositionModeV5Ctx(ctx context.Context, category, symbol, coin string, mode int) (err error) {
+	params := map[string]interface{}{"category": category, "mode": mode}
+	setParam(params, "symbol", symbol)
+	setParam(params, "coin", coin)
+	_, err = b.SignedRequestV5Ctx(ctx, http.MethodPost, "v5/position/switch-mode", params, nil)
+	return
+}
+
+// GetWalletBalanceV5 錢包余額
+// GET /v5/account/wallet-balance
+// accountType: UNIFIED / CONTRACT / SPOT；coin 多個以逗號分隔，空表示全部
+func (b *Client) GetWalletBalanceV5(accountType, coin string) (result []WalletBalanceV5, err error) {
+	return b.GetWalletBalanceV5Ctx(b.context(), accountType, coin)
+}
+
+// GetWalletBalanceV5Ctx 同 GetWalletBalanceV5，ctx 用於取消請求或設置單次調用的超時
+func (b *Client) GetWalletBalanceV5Ctx(ctx context.Context, accountType, coin string) (result []WalletBalanceV5, err error) {
+	var ret ListV5[WalletBalanceV5]
+	params := map[string]interface{}{"accountType": accountType}
+	setParam(params, "coin", coin)
+	_, err = b.SignedRequestV5Ctx(ctx, http.MethodGet, "v5/account/wallet-balance", params, &ret)
+	result = ret.List
+	return
+}
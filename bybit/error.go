@@ -1,5 +1,10 @@
 package bybit
 
+import (
+	"errors"
+	"fmt"
+)
+
 const (
 	ErrCode10000 = 10000 // 處理請求時發生未知錯誤
 	ErrCode10001 = 10001 // 參數錯誤
@@ -207,3 +212,82 @@ const (
 	ErrCode37013 = 37013 // Stop loss price needs to be less than base price.
 	ErrCode38101 = 38101 // Replacement of order will result in the breach of user's limit according to open interest.
 )
+
+// ErrorCategory 錯誤分類，便於調用方按類處理（重試、重簽、放棄等）
+type ErrorCategory string
+
+const (
+	ErrCategoryUnknown       ErrorCategory = ""
+	ErrCategoryAuth          ErrorCategory = "auth"
+	ErrCategoryRateLimit     ErrorCategory = "rate-limit"
+	ErrCategoryParameter     ErrorCategory = "parameter"
+	ErrCategoryOrderRejected ErrorCategory = "order-rejected"
+	ErrCategoryTimestamp     ErrorCategory = "timestamp"
+)
+
+// APIError ret_code / retCode 非 0 時返回
+type APIError struct {
+	Code     int
+	Message  string
+	Category ErrorCategory
+}
+
+func newAPIError(code int, msg string) *APIError {
+	return &APIError{Code: code, Message: msg, Category: errorCategory(code)}
+}
+
+func (e *APIError) Error() string {
+	if e.Category == ErrCategoryUnknown {
+		return fmt.Sprintf("bybit: code=%d msg=%s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("bybit: code=%d msg=%s (%s)", e.Code, e.Message, e.Category)
+}
+
+// errorCategory 按錯誤碼歸類，V5 的 110xxx/170xxx 為合約/現貨下單錯誤
+func errorCategory(code int) ErrorCategory {
+	switch code {
+	case ErrCode10002, ErrCode10021:
+		return ErrCategoryTimestamp
+	case ErrCode10003, ErrCode10004, ErrCode10005, ErrCode10007, ErrCode10010, ErrCode10022,
+		ErrCode20014, ErrCode20015, ErrCode31003, ErrCode33004:
+		return ErrCategoryAuth
+	case ErrCode10006, ErrCode10018, ErrCode30035:
+		return ErrCategoryRateLimit
+	case ErrCode10001, ErrCode20003, ErrCode20004, ErrCode20005, ErrCode20006, ErrCode20007,
+		ErrCode20008, ErrCode20009, ErrCode20012, ErrCode20017, ErrCode20018, ErrCode20019,
+		ErrCode20020, ErrCode20021, ErrCode20022, ErrCode20023, ErrCode20031, ErrCode20070,
+		ErrCode20071, ErrCode20084:
+		return ErrCategoryParameter
+	case ErrCode20001, ErrCode20010, ErrCode20011, ErrCode20013:
+		return ErrCategoryOrderRejected
+	}
+	switch {
+	case code >= 11000 && code < 12000:
+		return ErrCategoryParameter
+	case code >= 30000 && code < 40000,
+		code >= 110000 && code < 120000,
+		code >= 170000 && code < 180000:
+		return ErrCategoryOrderRejected
+	}
+	return ErrCategoryUnknown
+}
+
+func isCategory(err error, c ErrorCategory) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.Category == c
+}
+
+// IsRateLimited 請求頻率超限
+func IsRateLimited(err error) bool { return isCategory(err, ErrCategoryRateLimit) }
+
+// IsTimestampError 時間戳過期或超出 recvWindow，需校正服務器時間
+func IsTimestampError(err error) bool { return isCategory(err, ErrCategoryTimestamp) }
+
+// IsAuthError apikey 無效、簽名錯誤或權限不足
+func IsAuthError(err error) bool { return isCategory(err, ErrCategoryAuth) }
+
+// IsParameterError 請求參數錯誤
+func IsParameterError(err error) bool { return isCategory(err, ErrCategoryParameter) }
+
+// IsOrderRejected 下單/撤單/改單被拒絕
+func IsOrderRejected(err error) bool { return isCategory(err, ErrCategoryOrderRejected) }
@@ -0,0 +1,430 @@
+package bybit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	PublicWsURLV5Spot    = "wss://stream.bybit.com/v5/public/spot"
+	PublicWsURLV5Linear  = "wss://stream.bybit.com/v5/public/linear"
+	PublicWsURLV5Inverse = "wss://stream.bybit.com/v5/public/inverse"
+)
+
+var errBookNotReadyV5 = errors.New("bybit: order book not ready")
+
+type bookGapErrorV5 struct{ expect, got int64 }
+
+func (e *bookGapErrorV5) Error() string {
+	return fmt.Sprintf("bybit: book update id broken, expect u=%d got=%d", e.expect, e.got)
+}
+
+// PublicWsURLV5 按品類返回公共頻道地址
+func PublicWsURLV5(category string) (string, error) {
+	switch category {
+	case CategorySpot:
+		return PublicWsURLV5Spot, nil
+	case CategoryLinear:
+		return PublicWsURLV5Linear, nil
+	case CategoryInverse:
+		return PublicWsURLV5Inverse, nil
+	}
+	return "", fmt.Errorf("bybit: unsupported category %q", category)
+}
+
+type BookLevel struct {
+	Price float64
+	Size  float64
+}
+
+/* ============================== 單個交易對 ============================== */
+
+// LocalOrderBookV5 本地維護的單個交易對深度，所有方法並發安全
+// UpdateId 對應推送中的 u，Seq 為撮合序號（不同檔位深度間可比較新舊）
+type LocalOrderBookV5 struct {
+	mu       sync.RWMutex
+	symbol   string
+	bids     []BookLevel // 價格降序
+	asks     []BookLevel // 價格升序
+	updateId int64
+	seq      int64
+	ts       int64
+	valid    bool
+}
+
+func (b *LocalOrderBookV5) Symbol() string { return b.symbol }
+
+// IsValid 已收到快照
+func (b *LocalOrderBookV5) IsValid() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.valid
+}
+
+func (b *LocalOrderBookV5) UpdateId() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.updateId
+}
+
+func (b *LocalOrderBookV5) Seq() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.seq
+}
+
+// Ts 最近一次推送的系統時間(ms)
+func (b *LocalOrderBookV5) Ts() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.ts
+}
+
+func (b *LocalOrderBookV5) BestBid() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid || len(b.bids) == 0 {
+		return BookLevel{}, false
+	}
+	return b.bids[0], true
+}
+
+func (b *LocalOrderBookV5) BestAsk() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid || len(b.asks) == 0 {
+		return BookLevel{}, false
+	}
+	return b.asks[0], true
+}
+
+// Depth 返回前 n 檔的拷貝，n <= 0 返回全部
+func (b *LocalOrderBookV5) Depth(n int) (bids, asks []BookLevel) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid {
+		return nil, nil
+	}
+	return topLevels(b.bids, n), topLevels(b.asks, n)
+}
+
+func topLevels(src []BookLevel, n int) []BookLevel {
+	if n <= 0 || n > len(src) {
+		n = len(src)
+	}
+	dst := make([]BookLevel, n)
+	copy(dst, src[:n])
+	return dst
+}
+
+func (b *LocalOrderBookV5) invalidate() {
+	b.mu.Lock()
+	b.valid = false
+	b.mu.Unlock()
+}
+
+// snapshot 全量覆蓋本地深度
+func (b *LocalOrderBookV5) snapshot(d *OrderBookV5, ts int64) error {
+	nb, err := parseLevels(d.B)
+	if err != nil {
+		return err
+	}
+	na, err := parseLevels(d.A)
+	if err != nil {
+		return err
+	}
+	sort.Slice(nb, func(i, j int) bool { return nb[i].Price > nb[j].Price })
+	sort.Slice(na, func(i, j int) bool { return na[i].Price < na[j].Price })
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bids, b.asks = nb, na
+	b.updateId, b.seq, b.ts = d.U, d.Seq, ts
+	b.valid = true
+	return nil
+}
+
+// update 應用增量；u 不大於當前 updateId 或 seq 回退的視為過期推送，忽略並返回 false；
+// u 跳號時作廢本地深度並返回 bookGapErrorV5
+func (b *LocalOrderBookV5) update(d *OrderBookV5, ts int64) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.valid {
+		return false, errBookNotReadyV5
+	}
+	if d.U <= b.updateId || (d.Seq != 0 && d.Seq < b.seq) {
+		return false, nil
+	}
+	if d.U != b.updateId+1 {
+		b.valid = false
+		return false, &bookGapErrorV5{expect: b.updateId + 1, got: d.U}
+	}
+	for _, a := range d.B {
+		l, err := parseLevel(a)
+		if err != nil {
+			return false, err
+		}
+		b.bids = applyLevel(b.bids, l, true)
+	}
+	for _, a := range d.A {
+		l, err := parseLevel(a)
+		if err != nil {
+			return false, err
+		}
+		b.asks = applyLevel(b.asks, l, false)
+	}
+	b.updateId, b.ts = d.U, ts
+	if d.Seq != 0 {
+		b.seq = d.Seq
+	}
+	return true, nil
+}
+
+// applyLevel 插入/替換/刪除(size=0) 一檔，desc 表示價格降序
+func applyLevel(levels []BookLevel, l BookLevel, desc bool) []BookLevel {
+	i := sort.Search(len(levels), func(i int) bool {
+		if desc {
+			return levels[i].Price <= l.Price
+		}
+		return levels[i].Price >= l.Price
+	})
+	found := i < len(levels) && levels[i].Price == l.Price
+	switch {
+	case l.Size == 0:
+		if found {
+			levels = append(levels[:i], levels[i+1:]...)
+		}
+	case found:
+		levels[i] = l
+	default:
+		levels = append(levels, BookLevel{})
+		copy(levels[i+1:], levels[i:])
+		levels[i] = l
+	}
+	return levels
+}
+
+func parseLevel(a []string) (l BookLevel, err error) {
+	if len(a) < 2 {
+		return l, fmt.Errorf("bybit: invalid book level %v", a)
+	}
+	if l.Price, err = strconv.ParseFloat(a[0], 64); err != nil {
+		return
+	}
+	l.Size, err = strconv.ParseFloat(a[1], 64)
+	return
+}
+
+func parseLevels(src [][]string) ([]BookLevel, error) {
+	levels := make([]BookLevel, 0, len(src))
+	for _, a := range src {
+		l, err := parseLevel(a)
+		if err != nil {
+			return nil, err
+		}
+		if l.Size != 0 {
+			levels = append(levels, l)
+		}
+	}
+	return levels, nil
+}
+
+/* ============================== 管理器 ============================== */
+
+// OrderBookManagerV5 維護 V5 orderbook.{depth}.{symbol} 深度：
+// type=snapshot 時重置本地深度（服務重啟時也會重發 u=1 的快照），type=delta 按 u/seq 增量應用；
+// u 跳號或增量無法解析時重新訂閱以獲取新快照
+type OrderBookManagerV5 struct {
+	*WsClient
+	category string
+	depth    int
+	mu       sync.RWMutex
+	books    map[string]*LocalOrderBookV5
+	onChange func(book *LocalOrderBookV5)
+}
+
+// NewOrderBookManagerV5 category: spot / linear / inverse
+// depth: spot 1/50/200，linear/inverse 1/50/200/500；symbols 如 BTCUSDT
+// onChange 在每次快照/增量成功應用後回調，opts 可覆蓋 URL（如測試網）
+func NewOrderBookManagerV5(parent context.Context, category string, depth int, onChange func(*LocalOrderBookV5), symbols []string, opts ...func(*Options)) (*OrderBookManagerV5, error) {
+	wsURL, err := PublicWsURLV5(category)
+	if err != nil {
+		return nil, err
+	}
+	m := &OrderBookManagerV5{
+		category: category,
+		depth:    depth,
+		books:    make(map[string]*LocalOrderBookV5),
+		onChange: onChange,
+	}
+	for _, s := range symbols {
+		m.books[s] = &LocalOrderBookV5{symbol: s}
+	}
+	options := []func(*Options){
+		SetURL(wsURL),
+		SetSubscriber(m.resubscribeAll, nil),
+		SetPingInterval(wsPingIntervalV5),
+		SetWorkers(1), // 增量必須按順序應用
+	}
+	m.WsClient, err = NewWsClient(parent, m.handle, append(options, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Subscribe 追加交易對，重連後自動重新訂閱
+func (m *OrderBookManagerV5) Subscribe(symbols ...string) error {
+	var topics []string
+	m.mu.Lock()
+	for _, s := range symbols {
+		if _, ok := m.books[s]; !ok {
+			m.books[s] = &LocalOrderBookV5{symbol: s}
+			topics = append(topics, m.topic(s))
+		}
+	}
+	m.mu.Unlock()
+	if len(topics) == 0 {
+		return nil
+	}
+	return m.sendOp("subscribe", topics)
+}
+
+// Unsubscribe 移除交易對並取消訂閱
+func (m *OrderBookManagerV5) Unsubscribe(symbols ...string) error {
+	var topics []string
+	m.mu.Lock()
+	for _, s := range symbols {
+		if _, ok := m.books[s]; ok {
+			delete(m.books, s)
+			topics = append(topics, m.topic(s))
+		}
+	}
+	m.mu.Unlock()
+	if len(topics) == 0 {
+		return nil
+	}
+	return m.sendOp("unsubscribe", topics)
+}
+
+// Book 返回交易對的本地深度，未訂閱返回 nil
+func (m *OrderBookManagerV5) Book(symbol string) *LocalOrderBookV5 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.books[symbol]
+}
+
+func (m *OrderBookManagerV5) BestBid(symbol string) (BookLevel, bool) {
+	if b := m.Book(symbol); b != nil {
+		return b.BestBid()
+	}
+	return BookLevel{}, false
+}
+
+func (m *OrderBookManagerV5) BestAsk(symbol string) (BookLevel, bool) {
+	if b := m.Book(symbol); b != nil {
+		return b.BestAsk()
+	}
+	return BookLevel{}, false
+}
+
+func (m *OrderBookManagerV5) Depth(symbol string, n int) (bids, asks []BookLevel) {
+	if b := m.Book(symbol); b != nil {
+		return b.Depth(n)
+	}
+	return nil, nil
+}
+
+func (m *OrderBookManagerV5) topic(symbol string) string {
+	return "orderbook." + strconv.Itoa(m.depth) + "." + symbol
+}
+
+func (m *OrderBookManagerV5) sendOp(op string, topics []string) error {
+	bs, _ := json.Marshal(map[string]interface{}{"op": op, "args": topics})
+	return m.safeWrite(websocket.TextMessage, bs)
+}
+
+// resubscribeAll 作為 WsClient 的 subscriber，每次撥號後作廢全部深度並重新訂閱
+func (m *OrderBookManagerV5) resubscribeAll(conn *websocket.Conn, _ ...string) error {
+	m.mu.RLock()
+	topics := make([]string, 0, len(m.books))
+	for s, b := range m.books {
+		b.invalidate()
+		topics = append(topics, m.topic(s))
+	}
+	m.mu.RUnlock()
+	if len(topics) == 0 {
+		return nil
+	}
+	bs, _ := json.Marshal(map[string]interface{}{"op": "subscribe", "args": topics})
+	return conn.WriteMessage(websocket.TextMessage, bs)
+}
+
+// resync 重新訂閱單個交易對，服務端會先推送快照
+func (m *OrderBookManagerV5) resync(book *LocalOrderBookV5) error {
+	book.invalidate()
+	topic := []string{m.topic(book.symbol)}
+	if err := m.sendOp("unsubscribe", topic); err != nil {
+		return err
+	}
+	return m.sendOp("subscribe", topic)
+}
+
+func (m *OrderBookManagerV5) handle(bs []byte) error {
+	var msg wsMessageV5
+	if err := json.Unmarshal(bs, &msg); err != nil {
+		return err
+	}
+	if msg.Op != "" {
+		if msg.Success != nil && !*msg.Success {
+			zap.S().Errorf("[bybit][book] %s failed: %s", msg.Op, msg.RetMsg)
+		}
+		return nil
+	}
+	if !strings.HasPrefix(msg.Topic, "orderbook.") {
+		return nil
+	}
+
+	var d OrderBookV5
+	if err := json.Unmarshal(msg.Data, &d); err != nil {
+		return err
+	}
+	book := m.Book(d.S)
+	if book == nil {
+		return nil
+	}
+
+	switch msg.Type {
+	case "snapshot":
+		if err := book.snapshot(&d, msg.Ts); err != nil {
+			return err
+		}
+	case "delta":
+		applied, err := book.update(&d, msg.Ts)
+		if err == errBookNotReadyV5 { // 訂閱後服務端先推快照，此前的增量屬於舊訂閱
+			return nil
+		}
+		if err != nil {
+			zap.S().Warnf("[bybit][book][%s] %s, resubscribe", d.S, err)
+			return m.resync(book)
+		}
+		if !applied {
+			zap.S().Debugf("[bybit][book][%s] stale delta u=%d seq=%d", d.S, d.U, d.Seq)
+			return nil
+		}
+	default:
+		return nil
+	}
+	if m.onChange != nil {
+		m.onChange(book)
+	}
+	return nil
+}
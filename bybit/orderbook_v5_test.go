@@ -0,0 +1,248 @@
+package bybit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func bookV5(u, seq int64, b ...[]string) *OrderBookV5 {
+	return &OrderBookV5{S: "BTCUSDT", B: b, U: u, Seq: seq}
+}
+
+func TestLocalOrderBookV5(t *testing.T) {
+	snapshot := bookV5(100, 1000, []string{"30000", "1"}, []string{"29999", "2"})
+	type step struct {
+		snapshot bool
+		d        *OrderBookV5
+	}
+	tests := []struct {
+		name        string
+		steps       []step
+		wantErr     error
+		wantValid   bool
+		wantU       int64
+		wantSeq     int64
+		wantBids    []float64
+		wantApplied bool
+	}{
+		{
+			name:      "snapshot",
+			steps:     []step{{true, snapshot}},
+			wantValid: true,
+			wantU:     100,
+			wantSeq:   1000,
+			wantBids:  []float64{30000, 29999},
+		},
+		{
+			name:        "delta",
+			steps:       []step{{true, snapshot}, {false, bookV5(101, 1001, []string{"30000", "0"}, []string{"30000.5", "3"})}},
+			wantValid:   true,
+			wantU:       101,
+			wantSeq:     1001,
+			wantBids:    []float64{30000.5, 29999},
+			wantApplied: true,
+		},
+		{
+			name:    "delta before snapshot",
+			steps:   []step{{false, bookV5(101, 1001, []string{"30000.5", "3"})}},
+			wantErr: errBookNotReadyV5,
+		},
+		{
+			name:      "stale u",
+			steps:     []step{{true, snapshot}, {false, bookV5(100, 1001, []string{"30000.5", "3"})}},
+			wantValid: true,
+			wantU:     100,
+			wantSeq:   1000,
+			wantBids:  []float64{30000, 29999},
+		},
+		{
+			name:      "seq going back",
+			steps:     []step{{true, snapshot}, {false, bookV5(101, 999, []string{"30000.5", "3"})}},
+			wantValid: true,
+			wantU:     100,
+			wantSeq:   1000,
+			wantBids:  []float64{30000, 29999},
+		},
+		{
+			name:    "u gap",
+			steps:   []step{{true, snapshot}, {false, bookV5(103, 1003, []string{"30000.5", "3"})}},
+			wantErr: &bookGapErrorV5{expect: 101, got: 103},
+			wantU:   100,
+			wantSeq: 1000,
+		},
+		{
+			// 服務重啟後推送 u=1 的快照，本地深度整體重置
+			name: "u=1 snapshot resets",
+			steps: []step{
+				{true, snapshot},
+				{true, bookV5(1, 5, []string{"31000", "1"})},
+				{false, bookV5(2, 6, []string{"30999", "1"})},
+			},
+			wantValid:   true,
+			wantU:       2,
+			wantSeq:     6,
+			wantBids:    []float64{31000, 30999},
+			wantApplied: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &LocalOrderBookV5{symbol: "BTCUSDT"}
+			var (
+				applied bool
+				err     error
+			)
+			for _, s := range tt.steps {
+				if s.snapshot {
+					if err := b.snapshot(s.d, 1); err != nil {
+						t.Fatalf("snapshot() error = %v", err)
+					}
+					continue
+				}
+				if applied, err = b.update(s.d, 1); err != nil {
+					break
+				}
+			}
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("update() error = %v, want %v", err, tt.wantErr)
+			}
+			if applied != tt.wantApplied {
+				t.Errorf("update() applied = %v, want %v", applied, tt.wantApplied)
+			}
+			if b.IsValid() != tt.wantValid {
+				t.Errorf("IsValid() = %v, want %v", b.IsValid(), tt.wantValid)
+			}
+			if b.UpdateId() != tt.wantU || b.Seq() != tt.wantSeq {
+				t.Errorf("u/seq = %d/%d, want %d/%d", b.UpdateId(), b.Seq(), tt.wantU, tt.wantSeq)
+			}
+			bids, _ := b.Depth(0)
+			var px []float64
+			for _, l := range bids {
+				px = append(px, l.Price)
+			}
+			if !reflect.DeepEqual(px, tt.wantBids) {
+				t.Errorf("bids = %v, want %v", px, tt.wantBids)
+			}
+		})
+	}
+}
+
+// fakeBybitV5 記錄收到的訂閱報文，並可向當前連接推送消息
+type fakeBybitV5 struct {
+	*httptest.Server
+	mu   sync.Mutex
+	conn *websocket.Conn
+	ops  []string
+}
+
+func newFakeBybitV5(t *testing.T) *fakeBybitV5 {
+	f := &fakeBybitV5{}
+	upgrader := websocket.Upgrader{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		f.mu.Lock()
+		f.conn = c
+		f.mu.Unlock()
+		for {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				Op   string   `json:"op"`
+				Args []string `json:"args"`
+			}
+			if json.Unmarshal(data, &req) != nil || req.Op == "ping" {
+				continue
+			}
+			f.mu.Lock()
+			f.ops = append(f.ops, req.Op+" "+strings.Join(req.Args, ","))
+			f.mu.Unlock()
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeBybitV5) push(t *testing.T, msg string) {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *fakeBybitV5) received() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.ops...)
+}
+
+func waitFor(timeout time.Duration, cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestOrderBookManagerV5_gapResubscribes(t *testing.T) {
+	server := newFakeBybitV5(t)
+	changed := make(chan int64, 8)
+	m, err := NewOrderBookManagerV5(context.Background(), CategoryLinear, 50, func(b *LocalOrderBookV5) {
+		changed <- b.UpdateId()
+	}, nil, SetURL("ws"+strings.TrimPrefix(server.URL, "http")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	if err := m.Subscribe("BTCUSDT"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"subscribe orderbook.50.BTCUSDT"}
+	if !waitFor(time.Second, func() bool { return reflect.DeepEqual(server.received(), want) }) {
+		t.Fatalf("received %v, want %v", server.received(), want)
+	}
+
+	server.push(t, `{"topic":"orderbook.50.BTCUSDT","type":"snapshot","ts":1,"data":{"s":"BTCUSDT","b":[["30000","1"]],"a":[["30001","1"]],"u":100,"seq":1000}}`)
+	server.push(t, `{"topic":"orderbook.50.BTCUSDT","type":"delta","ts":2,"data":{"s":"BTCUSDT","b":[["30000.5","2"]],"a":[],"u":101,"seq":1001}}`)
+	for _, u := range []int64{100, 101} {
+		select {
+		case got := <-changed:
+			if got != u {
+				t.Fatalf("onChange u = %d, want %d", got, u)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("onChange u=%d not called", u)
+		}
+	}
+	if bid, ok := m.BestBid("BTCUSDT"); !ok || bid.Price != 30000.5 {
+		t.Errorf("BestBid() = %v, %v, want 30000.5", bid, ok)
+	}
+
+	server.push(t, `{"topic":"orderbook.50.BTCUSDT","type":"delta","ts":3,"data":{"s":"BTCUSDT","b":[["30000.6","2"]],"a":[],"u":105,"seq":1005}}`)
+	want = append(want, "unsubscribe orderbook.50.BTCUSDT", "subscribe orderbook.50.BTCUSDT")
+	if !waitFor(time.Second, func() bool { return reflect.DeepEqual(server.received(), want) }) {
+		t.Fatalf("received %v, want %v", server.received(), want)
+	}
+	if m.Book("BTCUSDT").IsValid() {
+		t.Error("book still valid after u gap")
+	}
+}
@@ -0,0 +1,119 @@
+package bybit
+
+// V5 產品類型
+const (
+	CategorySpot    = "spot"
+	CategoryLinear  = "linear"
+	CategoryInverse = "inverse"
+	CategoryOption  = "option"
+)
+
+// V5 賬戶類型
+const (
+	AccountTypeUnified  = "UNIFIED"
+	AccountTypeContract = "CONTRACT"
+	AccountTypeSpot     = "SPOT"
+)
+
+// V5 執行策略，PostOnly 沿用 TimeInForcePostOnly
+const (
+	TimeInForceGTC = "GTC"
+	TimeInForceIOC = "IOC"
+	TimeInForceFOK = "FOK"
+)
+
+// CreateOrderParamV5 數量與價格均為字符串，避免浮點精度問題
+// POST /v5/order/create
+type CreateOrderParamV5 struct {
+	Category         string `json:"category"`
+	Symbol           string `json:"symbol"`
+	Side             string `json:"side"`      // Buy / Sell
+	OrderType        string `json:"orderType"` // Market / Limit
+	Qty              string `json:"qty"`
+	Price            string `json:"price,omitempty"`
+	IsLeverage       int    `json:"isLeverage,omitempty"` // 統一賬戶現貨槓桿：1
+	MarketUnit       string `json:"marketUnit,omitempty"` // 現貨市價單 qty 單位：baseCoin / quoteCoin
+	TriggerDirection int    `json:"triggerDirection,omitempty"`
+	TriggerPrice     string `json:"triggerPrice,omitempty"`
+	TriggerBy        string `json:"triggerBy,omitempty"`
+	TimeInForce      string `json:"timeInForce,omitempty"`
+	PositionIdx      int    `json:"positionIdx,omitempty"`
+	OrderLinkId      string `json:"orderLinkId,omitempty"`
+	TakeProfit       string `json:"takeProfit,omitempty"`
+	StopLoss         string `json:"stopLoss,omitempty"`
+	TpTriggerBy      string `json:"tpTriggerBy,omitempty"`
+	SlTriggerBy      string `json:"slTriggerBy,omitempty"`
+	ReduceOnly       bool   `json:"reduceOnly,omitempty"`
+	CloseOnTrigger   bool   `json:"closeOnTrigger,omitempty"`
+}
+
+// AmendOrderParamV5 OrderId 與 OrderLinkId 二選一
+// POST /v5/order/amend
+type AmendOrderParamV5 struct {
+	Category     string `json:"category"`
+	Symbol       string `json:"symbol"`
+	OrderId      string `json:"orderId,omitempty"`
+	OrderLinkId  string `json:"orderLinkId,omitempty"`
+	Qty          string `json:"qty,omitempty"`
+	Price        string `json:"price,omitempty"`
+	TriggerPrice string `json:"triggerPrice,omitempty"`
+	TakeProfit   string `json:"takeProfit,omitempty"`
+	StopLoss     string `json:"stopLoss,omitempty"`
+}
+
+// CancelOrderParamV5 OrderId 與 OrderLinkId 二選一
+// POST /v5/order/cancel
+type CancelOrderParamV5 struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	OrderId     string `json:"orderId,omitempty"`
+	OrderLinkId string `json:"orderLinkId,omitempty"`
+	OrderFilter string `json:"orderFilter,omitempty"` // 現貨：Order / tpslOrder / StopOrder
+}
+
+// QueryOrderParamV5 GET /v5/order/realtime 與 /v5/order/history
+// Cursor 取上一頁返回的 NextPageCursor
+type QueryOrderParamV5 struct {
+	Category    string
+	Symbol      string
+	BaseCoin    string
+	SettleCoin  string
+	OrderId     string
+	OrderLinkId string
+	OrderStatus string
+	OrderFilter string
+	OpenOnly    int
+	StartTime   int64
+	EndTime     int64
+	Limit       int
+	Cursor      string
+}
+
+func (p *QueryOrderParamV5) params() map[string]interface{} {
+	params := map[string]interface{}{"category": p.Category}
+	setParam(params, "symbol", p.Symbol)
+	setParam(params, "baseCoin", p.BaseCoin)
+	setParam(params, "settleCoin", p.SettleCoin)
+	setParam(params, "orderId", p.OrderId)
+	setParam(params, "orderLinkId", p.OrderLinkId)
+	setParam(params, "orderStatus", p.OrderStatus)
+	setParam(params, "orderFilter", p.OrderFilter)
+	setParam(params, "openOnly", p.OpenOnly)
+	setParam(params, "startTime", p.StartTime)
+	setParam(params, "endTime", p.EndTime)
+	setParam(params, "limit", p.Limit)
+	setParam(params, "cursor", p.Cursor)
+	return params
+}
+
+func (p *CreateOrderParamV5) params() map[string]interface{} { return structParams(p) }
+func (p *AmendOrderParamV5) params() map[string]interface{}  { return structParams(p) }
+func (p *CancelOrderParamV5) params() map[string]interface{} { return structParams(p) }
+
+// structParams 按 json tag 轉為參數表，omitempty 的零值不會出現
+func structParams(v interface{}) map[string]interface{} {
+	params := map[string]interface{}{}
+	bs, _ := json.Marshal(v)
+	_ = json.Unmarshal(bs, &params)
+	return params
+}
@@ -0,0 +1,268 @@
+package bybit
+
+import (
+	"errors"
+)
+
+// V5 接口的數值均為字符串，按需使用 strconv 或 decimal 解析
+
+type ServerTimeV5 struct {
+	TimeSecond string `json:"timeSecond"`
+	TimeNano   string `json:"timeNano"`
+}
+
+type InstrumentV5 struct {
+	Symbol          string `json:"symbol"`
+	ContractType    string `json:"contractType"`
+	Status          string `json:"status"`
+	BaseCoin        string `json:"baseCoin"`
+	QuoteCoin       string `json:"quoteCoin"`
+	SettleCoin      string `json:"settleCoin"`
+	LaunchTime      string `json:"launchTime"`
+	DeliveryTime    string `json:"deliveryTime"`
+	PriceScale      string `json:"priceScale"`
+	FundingInterval int    `json:"fundingInterval"`
+	LeverageFilter  struct {
+		MinLeverage  string `json:"minLeverage"`
+		MaxLeverage  string `json:"maxLeverage"`
+		LeverageStep string `json:"leverageStep"`
+	} `json:"leverageFilter"`
+	PriceFilter struct {
+		MinPrice string `json:"minPrice"`
+		MaxPrice string `json:"maxPrice"`
+		TickSize string `json:"tickSize"`
+	} `json:"priceFilter"`
+	LotSizeFilter struct {
+		MinOrderQty      string `json:"minOrderQty"`
+		MaxOrderQty      string `json:"maxOrderQty"`
+		QtyStep          string `json:"qtyStep"`
+		BasePrecision    string `json:"basePrecision"`  // 現貨
+		QuotePrecision   string `json:"quotePrecision"` // 現貨
+		MinOrderAmt      string `json:"minOrderAmt"`
+		MaxOrderAmt      string `json:"maxOrderAmt"`
+		MinNotionalValue string `json:"minNotionalValue"`
+	} `json:"lotSizeFilter"`
+}
+
+type TickerV5 struct {
+	Symbol                 string `json:"symbol"`
+	LastPrice              string `json:"lastPrice"`
+	IndexPrice             string `json:"indexPrice"`
+	MarkPrice              string `json:"markPrice"`
+	PrevPrice24h           string `json:"prevPrice24h"`
+	Price24hPcnt           string `json:"price24hPcnt"`
+	HighPrice24h           string `json:"highPrice24h"`
+	LowPrice24h            string `json:"lowPrice24h"`
+	Volume24h              string `json:"volume24h"`
+	Turnover24h            string `json:"turnover24h"`
+	OpenInterest           string `json:"openInterest"`
+	OpenInterestValue      string `json:"openInterestValue"`
+	FundingRate            string `json:"fundingRate"`
+	NextFundingTime        string `json:"nextFundingTime"`
+	Bid1Price              string `json:"bid1Price"`
+	Bid1Size               string `json:"bid1Size"`
+	Ask1Price              string `json:"ask1Price"`
+	Ask1Size               string `json:"ask1Size"`
+	UsdIndexPrice          string `json:"usdIndexPrice"`
+	DeliveryTime           string `json:"deliveryTime"`
+	BasisRate              string `json:"basisRate"`
+	PredictedDeliveryPrice string `json:"predictedDeliveryPrice"`
+}
+
+// OrderBookV5 B/A 每檔為 [price, size]，U 為更新 id，Seq 為撮合序號
+type OrderBookV5 struct {
+	S   string     `json:"s"`
+	B   [][]string `json:"b"`
+	A   [][]string `json:"a"`
+	Ts  int64      `json:"ts"`
+	U   int64      `json:"u"`
+	Seq int64      `json:"seq"`
+	Cts int64      `json:"cts"`
+}
+
+// KlineV5 由 [startTime, open, high, low, close, volume, turnover] 數組解析
+type KlineV5 struct {
+	StartTime string
+	Open      string
+	High      string
+	Low       string
+	Close     string
+	Volume    string
+	Turnover  string
+}
+
+func (k *KlineV5) UnmarshalJSON(b []byte) error {
+	var a []string
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	if len(a) < 7 {
+		return errors.New("bybit: invalid kline")
+	}
+	k.StartTime, k.Open, k.High, k.Low, k.Close, k.Volume, k.Turnover = a[0], a[1], a[2], a[3], a[4], a[5], a[6]
+	return nil
+}
+
+type KlineListV5 struct {
+	Category string    `json:"category"`
+	Symbol   string    `json:"symbol"`
+	List     []KlineV5 `json:"list"`
+}
+
+type OrderResultV5 struct {
+	OrderId     string `json:"orderId"`
+	OrderLinkId string `json:"orderLinkId"`
+}
+
+type CancelAllResultV5 struct {
+	List    []OrderResultV5 `json:"list"`
+	Success string          `json:"success"`
+}
+
+type OrderV5 struct {
+	OrderId            string `json:"orderId"`
+	OrderLinkId        string `json:"orderLinkId"`
+	Symbol             string `json:"symbol"`
+	Price              string `json:"price"`
+	Qty                string `json:"qty"`
+	Side               string `json:"side"`
+	IsLeverage         string `json:"isLeverage"`
+	PositionIdx        int    `json:"positionIdx"`
+	OrderStatus        string `json:"orderStatus"`
+	CancelType         string `json:"cancelType"`
+	RejectReason       string `json:"rejectReason"`
+	AvgPrice           string `json:"avgPrice"`
+	LeavesQty          string `json:"leavesQty"`
+	LeavesValue        string `json:"leavesValue"`
+	CumExecQty         string `json:"cumExecQty"`
+	CumExecValue       string `json:"cumExecValue"`
+	CumExecFee         string `json:"cumExecFee"`
+	TimeInForce        string `json:"timeInForce"`
+	OrderType          string `json:"orderType"`
+	StopOrderType      string `json:"stopOrderType"`
+	TriggerPrice       string `json:"triggerPrice"`
+	TakeProfit         string `json:"takeProfit"`
+	StopLoss           string `json:"stopLoss"`
+	TriggerBy          string `json:"triggerBy"`
+	TriggerDirection   int    `json:"triggerDirection"`
+	ReduceOnly         bool   `json:"reduceOnly"`
+	CloseOnTrigger     bool   `json:"closeOnTrigger"`
+	SmpType            string `json:"smpType"`
+	CreatedTime        string `json:"createdTime"`
+	UpdatedTime        string `json:"updatedTime"`
+	PlaceType          string `json:"placeType"`
+	MarketUnit         string `json:"marketUnit"`
+	OcoTriggerBy       string `json:"ocoTriggerBy"`
+	SlTriggerBy        string `json:"slTriggerBy"`
+	TpTriggerBy        string `json:"tpTriggerBy"`
+	LastPriceOnCreated string `json:"lastPriceOnCreated"`
+}
+
+type PositionV5 struct {
+	PositionIdx      int    `json:"positionIdx"`
+	RiskId           int    `json:"riskId"`
+	Symbol           string `json:"symbol"`
+	Side             string `json:"side"` // Buy / Sell / 空字符串表示無倉位
+	Size             string `json:"size"`
+	AvgPrice         string `json:"avgPrice"`
+	PositionValue    string `json:"positionValue"`
+	TradeMode        int    `json:"tradeMode"` // 0 全倉 1 逐倉
+	AutoAddMargin    int    `json:"autoAddMargin"`
+	PositionStatus   string `json:"positionStatus"`
+	Leverage         string `json:"leverage"`
+	MarkPrice        string `json:"markPrice"`
+	LiqPrice         string `json:"liqPrice"`
+	BustPrice        string `json:"bustPrice"`
+	PositionIM       string `json:"positionIM"`
+	PositionMM       string `json:"positionMM"`
+	PositionBalance  string `json:"positionBalance"`
+	TakeProfit       string `json:"takeProfit"`
+	StopLoss         string `json:"stopLoss"`
+	TrailingStop     string `json:"trailingStop"`
+	UnrealisedPnl    string `json:"unrealisedPnl"`
+	CurRealisedPnl   string `json:"curRealisedPnl"`
+	CumRealisedPnl   string `json:"cumRealisedPnl"`
+	AdlRankIndicator int    `json:"adlRankIndicator"`
+	IsReduceOnly     bool   `json:"isReduceOnly"`
+	CreatedTime      string `json:"createdTime"`
+	UpdatedTime      string `json:"updatedTime"`
+	Seq              int64  `json:"seq"`
+}
+
+type CoinBalanceV5 struct {
+	Coin                string `json:"coin"`
+	Equity              string `json:"equity"`
+	UsdValue            string `json:"usdValue"`
+	WalletBalance       string `json:"walletBalance"`
+	Free                string `json:"free"` // 經典賬戶現貨
+	Locked              string `json:"locked"`
+	AvailableToWithdraw string `json:"availableToWithdraw"`
+	BorrowAmount        string `json:"borrowAmount"`
+	AccruedInterest     string `json:"accruedInterest"`
+	TotalOrderIM        string `json:"totalOrderIM"`
+	TotalPositionIM     string `json:"totalPositionIM"`
+	TotalPositionMM     string `json:"totalPositionMM"`
+	UnrealisedPnl       string `json:"unrealisedPnl"`
+	CumRealisedPnl      string `json:"cumRealisedPnl"`
+	MarginCollateral    bool   `json:"marginCollateral"`
+	CollateralSwitch    bool   `json:"collateralSwitch"`
+}
+
+type WalletBalanceV5 struct {
+	AccountType            string          `json:"accountType"`
+	AccountIMRate          string          `json:"accountIMRate"`
+	AccountMMRate          string          `json:"accountMMRate"`
+	TotalEquity            string          `json:"totalEquity"`
+	TotalWalletBalance     string          `json:"totalWalletBalance"`
+	TotalMarginBalance     string          `json:"totalMarginBalance"`
+	TotalAvailableBalance  string          `json:"totalAvailableBalance"`
+	TotalPerpUPL           string          `json:"totalPerpUPL"`
+	TotalInitialMargin     string          `json:"totalInitialMargin"`
+	TotalMaintenanceMargin string          `json:"totalMaintenanceMargin"`
+	Coin                   []CoinBalanceV5 `json:"coin"`
+}
+
+// OrderStreamV5 私有頻道 order 推送，比 REST 多返回 category
+type OrderStreamV5 struct {
+	Category string `json:"category"`
+	OrderV5
+}
+
+// ExecutionV5 私有頻道 execution 推送（成交明細）
+type ExecutionV5 struct {
+	Category        string `json:"category"`
+	Symbol          string `json:"symbol"`
+	IsLeverage      string `json:"isLeverage"`
+	OrderId         string `json:"orderId"`
+	OrderLinkId     string `json:"orderLinkId"`
+	Side            string `json:"side"`
+	OrderPrice      string `json:"orderPrice"`
+	OrderQty        string `json:"orderQty"`
+	LeavesQty       string `json:"leavesQty"`
+	CreateType      string `json:"createType"`
+	OrderType       string `json:"orderType"`
+	StopOrderType   string `json:"stopOrderType"`
+	ExecFee         string `json:"execFee"`
+	ExecId          string `json:"execId"`
+	ExecPrice       string `json:"execPrice"`
+	ExecQty         string `json:"execQty"`
+	ExecPnl         string `json:"execPnl"`
+	ExecType        string `json:"execType"` // Trade / AdlTrade / Funding / BustTrade / Settle
+	ExecValue       string `json:"execValue"`
+	ExecTime        string `json:"execTime"`
+	IsMaker         bool   `json:"isMaker"`
+	FeeRate         string `json:"feeRate"`
+	MarkPrice       string `json:"markPrice"`
+	IndexPrice      string `json:"indexPrice"`
+	UnderlyingPrice string `json:"underlyingPrice"`
+	ClosedSize      string `json:"closedSize"`
+	Seq             int64  `json:"seq"`
+}
+
+// PositionStreamV5 私有頻道 position 推送，均價字段為 entryPrice
+type PositionStreamV5 struct {
+	Category        string `json:"category"`
+	EntryPrice      string `json:"entryPrice"`
+	SessionAvgPrice string `json:"sessionAvgPrice"`
+	PositionV5
+}
@@ -0,0 +1,110 @@
+package bybit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Doer 發送 HTTP 請求，*http.Client 即實現了該接口
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc 函數形式的 Doer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+// Middleware 包裹 client.Do，用於日誌、監控、重試等
+type Middleware func(next Doer) Doer
+
+// Use 追加中間件，先傳入的在最外層（多次調用時先前追加的仍在外層）；應在發起請求前完成設置
+func (b *Client) Use(mw ...Middleware) {
+	b.middlewares = append(b.middlewares, mw...)
+	var doer Doer = b.client
+	for i := len(b.middlewares) - 1; i >= 0; i-- {
+		doer = b.middlewares[i](doer)
+	}
+	b.doer = doer
+}
+
+// WithContext 返回使用 ctx 發起請求的副本，副本上所有方法都使用該 ctx。
+// 單次調用優先使用對應的 …Ctx 方法：
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	ret, err := b.GetPositionsV5Ctx(ctx, CategoryLinear, "BTCUSDT", "", 0, "")
+//
+// 副本與原 Client 共享 http.Client 及服務器時間偏差；中間件列表各自獨立，
+// 之後在任一方調用 Use 不影響另一方
+func (b *Client) WithContext(ctx context.Context) *Client {
+	c := *b
+	c.ctx = ctx
+	c.middlewares = append([]Middleware(nil), b.middlewares...)
+	return &c
+}
+
+func (b *Client) context() context.Context {
+	if b.ctx != nil {
+		return b.ctx
+	}
+	return context.Background()
+}
+
+// timestamp 校正後的服務器時間(ms)
+func (b *Client) timestamp() int64 {
+	return time.Now().UnixNano()/1e6 + atomic.LoadInt64(b.serverTimeOffset)
+}
+
+// do 經中間件鏈發送請求並讀取響應，debugMode 下輸出脫敏後的結構化日誌
+func (b *Client) do(req *http.Request) (resp []byte, err error) {
+	start := time.Now()
+	status := 0
+	defer func() {
+		if b.debugMode {
+			logRequest(req, status, resp, time.Since(start), err)
+		}
+	}()
+
+	var response *http.Response
+	if response, err = b.doer.Do(req); err != nil {
+		return
+	}
+	defer response.Body.Close()
+	status = response.StatusCode
+	resp, err = io.ReadAll(response.Body)
+	return
+}
+
+func logRequest(req *http.Request, status int, resp []byte, elapsed time.Duration, err error) {
+	fields := []zap.Field{
+		zap.String("method", req.Method),
+		zap.String("url", redactURL(req.URL)),
+		zap.Int("status", status),
+		zap.Duration("elapsed", elapsed),
+		zap.ByteString("resp", resp),
+	}
+	if err != nil {
+		zap.L().Warn("bybit request", append(fields, zap.Error(err))...)
+		return
+	}
+	zap.L().Info("bybit request", fields...)
+}
+
+// redactURL 隱藏 query 中的簽名，V5 的簽名在 header 中不會輸出
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	if q.Get("sign") == "" {
+		return u.String()
+	}
+	q.Set("sign", "REDACTED")
+	r := *u
+	r.RawQuery = q.Encode()
+	return r.String()
+}
@@ -0,0 +1,142 @@
+package bybit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestClient_Use(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"retCode":0,"retMsg":"OK","result":{"timeSecond":"1","timeNano":"1000000000"}}`)
+	}))
+	defer server.Close()
+
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" before")
+				resp, err := next.Do(req)
+				order = append(order, name+" after")
+				return resp, err
+			})
+		}
+	}
+
+	b := New(server.Client(), server.URL+"/", "", "", false)
+	b.Use(mark("a"), mark("b"))
+	b.Use(mark("c"))
+	if _, err := b.GetServerTimeV5(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a before", "b before", "c before", "c after", "b after", "a after"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("middleware order = %v, want %v", order, want)
+	}
+}
+
+func TestClient_WithContextUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"retCode":0,"retMsg":"OK","result":{"timeSecond":"1","timeNano":"1000000000"}}`)
+	}))
+	defer server.Close()
+
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	b := New(server.Client(), server.URL+"/", "", "", false)
+	b.Use(mark("a"), mark("b"), mark("c"))
+	b.middlewares = b.middlewares[:1] // 保留多餘容量，模擬 append 共享底層數組的情況
+	b.Use()
+	c := b.WithContext(context.Background())
+	c.Use(mark("copy"))
+	b.Use(mark("orig"))
+	c.Use() // 重建鏈時不應看到原 Client 追加的中間件
+
+	for _, tt := range []struct {
+		name   string
+		client *Client
+		want   []string
+	}{
+		{"original", b, []string{"a", "orig"}},
+		{"copy", c, []string{"a", "copy"}},
+	} {
+		order = nil
+		if _, err := tt.client.GetServerTimeV5(); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(order, tt.want) {
+			t.Errorf("%s middleware = %v, want %v", tt.name, order, tt.want)
+		}
+	}
+}
+
+func TestClient_Ctx(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	b := New(server.Client(), server.URL+"/", "key", "secret", false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := b.GetPositionsV5Ctx(ctx, CategoryLinear, "BTCUSDT", "", 0, ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetPositionsV5Ctx() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClient_debugLogRedacted(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	defer zap.ReplaceGlobals(zap.New(core))()
+
+	var signs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signs = append(signs, r.URL.Query().Get("sign"), r.Header.Get("X-BAPI-SIGN"))
+		fmt.Fprint(w, `{"ret_code":0,"retCode":0,"result":{}}`)
+	}))
+	defer server.Close()
+
+	b := New(server.Client(), server.URL+"/", "key", "secret", true)
+	if _, err := b.SignedRequest(http.MethodGet, "private/linear/position/list", map[string]interface{}{"symbol": "BTCUSDT"}, &BaseResult{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.SignedRequestV5(http.MethodGet, "v5/position/list", map[string]interface{}{"category": CategoryLinear}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("got %d log entries, want 2", len(entries))
+	}
+	for _, e := range entries {
+		fields := fmt.Sprint(e.ContextMap())
+		for _, sign := range signs {
+			if sign != "" && strings.Contains(fields, sign) {
+				t.Errorf("log %q leaks signature %s", fields, sign)
+			}
+		}
+	}
+	if url := entries[0].ContextMap()["url"]; !strings.Contains(fmt.Sprint(url), "sign=REDACTED") {
+		t.Errorf("url = %v, want sign=REDACTED", url)
+	}
+}
@@ -18,6 +18,8 @@ type Options struct {
 	url           string
 	subscriber    func(*websocket.Conn, ...string) error // 首次连上后的订阅动作
 	subscribeArgs []string
+	pingInterval  time.Duration // 默认 30s，V5 建议 20s
+	workers       int           // 业务 worker 数，1 表示按收到顺序处理
 }
 
 func SetURL(url string) func(*Options) {
@@ -31,6 +33,14 @@ func SetSubscriber(fn func(*websocket.Conn, ...string) error, args []string) fun
 	}
 }
 
+func SetPingInterval(d time.Duration) func(*Options) {
+	return func(o *Options) { o.pingInterval = d }
+}
+
+func SetWorkers(n int) func(*Options) {
+	return func(o *Options) { o.workers = n }
+}
+
 /* =============== 客户端对象 =============== */
 
 const handleQueueSize = 4096 // ★统一缓冲区大小★
@@ -69,6 +79,12 @@ func NewWsClient(parent context.Context, handle func([]byte) error, opts ...func
 	if c.url == "" {
 		c.url = "wss://stream.bytick.com/realtime_public"
 	}
+	if c.pingInterval <= 0 {
+		c.pingInterval = 30 * time.Second
+	}
+	if c.workers <= 0 {
+		c.workers = 16
+	}
 
 	// 首次拨号
 	if err := c.redialBlocking(); err != nil {
@@ -118,7 +134,7 @@ func (c *WsClient) spawnLoops() {
 
 	/* ---- 心跳循环 ---- */
 	go func() {
-		tk := time.NewTicker(30 * time.Second)
+		tk := time.NewTicker(c.pingInterval)
 		defer tk.Stop()
 		for {
 			select {
@@ -139,8 +155,7 @@ func (c *WsClient) spawnLoops() {
 	}()
 
 	/* ---- 业务 worker 池 ---- */
-	const worker = 16
-	for i := 0; i < worker; i++ {
+	for i := 0; i < c.workers; i++ {
 		go func() {
 			for {
 				select {
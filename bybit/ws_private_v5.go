@@ -0,0 +1,261 @@
+package bybit
+
+import (
+	"context"
+	sjson "encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	PrivateWsURLV5        = "wss://stream.bybit.com/v5/private"
+	PrivateWsTestnetURLV5 = "wss://stream-testnet.bybit.com/v5/private"
+
+	// 私有頻道，可加 .spot/.linear/.inverse/.option 後綴只接收指定品類
+	TopicOrderV5     = "order"
+	TopicExecutionV5 = "execution"
+	TopicPositionV5  = "position"
+	TopicWalletV5    = "wallet"
+
+	wsPingIntervalV5 = 20 * time.Second
+	authExpiresV5    = 10 * time.Second // 簽名有效期
+	authTimeoutV5    = 10 * time.Second // 等待 auth 回包
+)
+
+// wsMessageV5 V5 推送及 op 回包的公共結構
+type wsMessageV5 struct {
+	Op           string           `json:"op"`
+	Success      *bool            `json:"success"`
+	RetMsg       string           `json:"ret_msg"`
+	ConnId       string           `json:"conn_id"`
+	ReqId        string           `json:"req_id"`
+	Id           string           `json:"id"`
+	Topic        string           `json:"topic"`
+	Type         string           `json:"type"` // 公共頻道：snapshot / delta
+	Ts           int64            `json:"ts"`
+	CreationTime int64            `json:"creationTime"`
+	Data         sjson.RawMessage `json:"data"`
+}
+
+// PrivateHandlersV5 私有頻道回調，未設置的頻道直接忽略
+// Raw 接收未識別 topic 的原始消息
+type PrivateHandlersV5 struct {
+	Order     func([]OrderStreamV5)
+	Execution func([]ExecutionV5)
+	Position  func([]PositionStreamV5)
+	Wallet    func([]WalletBalanceV5)
+	Raw       func([]byte) error
+}
+
+// PrivateWsClientV5 V5 私有 WebSocket：
+// 每次（重）連先 auth 再重發全部訂閱，20s 發送一次 ping
+type PrivateWsClientV5 struct {
+	*WsClient
+	b    *Client
+	hand PrivateHandlersV5
+
+	mu     sync.Mutex
+	topics []string
+}
+
+// NewPrivateWsClientV5 建立私有連接並訂閱 topics，opts 可覆蓋 URL（如測試網）
+func (b *Client) NewPrivateWsClientV5(ctx context.Context, hand PrivateHandlersV5, topics []string, opts ...func(*Options)) (*PrivateWsClientV5, error) {
+	p := &PrivateWsClientV5{b: b, hand: hand}
+	p.addTopics(topics)
+
+	options := []func(*Options){
+		SetURL(PrivateWsURLV5),
+		SetSubscriber(p.login, nil),
+		SetPingInterval(wsPingIntervalV5),
+		SetWorkers(1), // 保證同一訂單的推送按序處理
+	}
+	ws, err := NewWsClient(ctx, p.handle, append(options, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	p.WsClient = ws
+	return p, nil
+}
+
+// Subscribe 追加訂閱，斷線重連後自動重發
+func (p *PrivateWsClientV5) Subscribe(topics ...string) error {
+	added := p.addTopics(topics)
+	if len(added) == 0 {
+		return nil
+	}
+	return p.sendOp("subscribe", added)
+}
+
+// Unsubscribe 取消訂閱
+func (p *PrivateWsClientV5) Unsubscribe(topics ...string) error {
+	removed := p.removeTopics(topics)
+	if len(removed) == 0 {
+		return nil
+	}
+	return p.sendOp("unsubscribe", removed)
+}
+
+// Subscriptions 當前訂閱的 topic
+func (p *PrivateWsClientV5) Subscriptions() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.topics...)
+}
+
+func (p *PrivateWsClientV5) addTopics(topics []string) (added []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, t := range topics {
+		if !containsString(p.topics, t) {
+			p.topics = append(p.topics, t)
+			added = append(added, t)
+		}
+	}
+	return
+}
+
+func (p *PrivateWsClientV5) removeTopics(topics []string) (removed []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	kept := p.topics[:0]
+	for _, t := range p.topics {
+		if containsString(topics, t) {
+			removed = append(removed, t)
+			continue
+		}
+		kept = append(kept, t)
+	}
+	p.topics = kept
+	return
+}
+
+func (p *PrivateWsClientV5) sendOp(op string, args []string) error {
+	bs, _ := json.Marshal(map[string]interface{}{"op": op, "args": args})
+	return p.safeWrite(websocket.TextMessage, bs)
+}
+
+// login 作為 subscriber 在讀循環啟動前執行：先 auth 再重發訂閱
+func (p *PrivateWsClientV5) login(conn *websocket.Conn, _ ...string) error {
+	if err := p.b.wsAuthV5(conn); err != nil {
+		return err
+	}
+	topics := p.Subscriptions()
+	if len(topics) == 0 {
+		return nil
+	}
+	bs, _ := json.Marshal(map[string]interface{}{"op": "subscribe", "args": topics})
+	return conn.WriteMessage(websocket.TextMessage, bs)
+}
+
+// wsAuthV5 同步完成鑒權，調用時讀循環尚未啟動，可直接讀取回包
+// signature = hex(HMAC_SHA256(secret, "GET/realtime" + expires))
+// private 頻道回 success/ret_msg，trade 頻道回 retCode/retMsg
+func (b *Client) wsAuthV5(conn *websocket.Conn) error {
+	expires := b.timestamp() + authExpiresV5.Milliseconds()
+	sign := b.getSigned("GET/realtime" + strconv.FormatInt(expires, 10))
+	bs, _ := json.Marshal(map[string]interface{}{
+		"op":   "auth",
+		"args": []interface{}{b.apiKey, expires, sign},
+	})
+	if err := conn.WriteMessage(websocket.TextMessage, bs); err != nil {
+		return err
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(authTimeoutV5))
+	defer conn.SetReadDeadline(time.Time{})
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		var ret struct {
+			Op       string `json:"op"`
+			Success  *bool  `json:"success"`
+			RetMsg   string `json:"ret_msg"`
+			RetCode  int    `json:"retCode"`
+			RetMsgV5 string `json:"retMsg"`
+		}
+		if err = json.Unmarshal(msg, &ret); err != nil {
+			return err
+		}
+		if ret.Op != "auth" {
+			continue
+		}
+		if ret.RetCode != 0 {
+			return newAPIError(ret.RetCode, ret.RetMsgV5)
+		}
+		if ret.Success != nil && !*ret.Success {
+			return fmt.Errorf("bybit: auth failed: %s", ret.RetMsg)
+		}
+		return nil
+	}
+}
+
+func (p *PrivateWsClientV5) handle(bs []byte) error {
+	var msg wsMessageV5
+	if err := json.Unmarshal(bs, &msg); err != nil {
+		return err
+	}
+	// op 回包：pong / subscribe / unsubscribe
+	if msg.Op != "" {
+		if msg.Success != nil && !*msg.Success {
+			zap.S().Errorf("bybit %s failed: %s", msg.Op, msg.RetMsg)
+		}
+		return nil
+	}
+
+	switch strings.SplitN(msg.Topic, ".", 2)[0] {
+	case TopicOrderV5:
+		if p.hand.Order != nil {
+			var data []OrderStreamV5
+			if err := json.Unmarshal(msg.Data, &data); err != nil {
+				return err
+			}
+			p.hand.Order(data)
+		}
+	case TopicExecutionV5:
+		if p.hand.Execution != nil {
+			var data []ExecutionV5
+			if err := json.Unmarshal(msg.Data, &data); err != nil {
+				return err
+			}
+			p.hand.Execution(data)
+		}
+	case TopicPositionV5:
+		if p.hand.Position != nil {
+			var data []PositionStreamV5
+			if err := json.Unmarshal(msg.Data, &data); err != nil {
+				return err
+			}
+			p.hand.Position(data)
+		}
+	case TopicWalletV5:
+		if p.hand.Wallet != nil {
+			var data []WalletBalanceV5
+			if err := json.Unmarshal(msg.Data, &data); err != nil {
+				return err
+			}
+			p.hand.Wallet(data)
+		}
+	default:
+		if p.hand.Raw != nil {
+			return p.hand.Raw(bs)
+		}
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
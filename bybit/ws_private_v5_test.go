@@ -0,0 +1,215 @@
+package bybit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestClient_getSigned(t *testing.T) {
+	// RFC 4231 test case 2
+	b := New(nil, "", "", "Jefe", false)
+	if got, want := b.getSigned("what do ya want for nothing?"), "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"; got != want {
+		t.Errorf("getSigned() = %s, want %s", got, want)
+	}
+}
+
+// fakeBybitAuth 校驗 auth 簽名後回 authResp；auth 成功後其餘報文交給 onOp，返回值原樣回給客戶端
+type fakeBybitAuth struct {
+	*httptest.Server
+	mu      sync.Mutex
+	wmu     sync.Mutex
+	conn    *websocket.Conn
+	authErr error
+	ops     []string
+	onOp    func(req map[string]any, reply func(string))
+}
+
+func newFakeBybitAuth(t *testing.T, authResp string) *fakeBybitAuth {
+	f := &fakeBybitAuth{}
+	upgrader := websocket.Upgrader{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		f.mu.Lock()
+		f.conn = c
+		f.mu.Unlock()
+
+		_, data, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+		authErr := verifyAuthV5(data)
+		f.mu.Lock()
+		f.authErr = authErr
+		f.mu.Unlock()
+		f.write(authResp)
+
+		for {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req map[string]any
+			if json.Unmarshal(data, &req) != nil || req["op"] == "ping" {
+				continue
+			}
+			f.mu.Lock()
+			if args, ok := req["args"].([]any); ok && req["reqId"] == nil {
+				var topics []string
+				for _, a := range args {
+					topics = append(topics, fmt.Sprint(a))
+				}
+				f.ops = append(f.ops, fmt.Sprint(req["op"])+" "+strings.Join(topics, ","))
+			}
+			onOp := f.onOp
+			f.mu.Unlock()
+			if onOp != nil {
+				onOp(req, f.write)
+			}
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// verifyAuthV5 按文檔重算 signature = hex(HMAC_SHA256(secret, "GET/realtime" + expires))
+func verifyAuthV5(data []byte) error {
+	var req struct {
+		Op   string `json:"op"`
+		Args []any  `json:"args"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return err
+	}
+	if req.Op != "auth" || len(req.Args) != 3 || req.Args[0] != "key" {
+		return fmt.Errorf("auth message = %s", data)
+	}
+	expires, ok := req.Args[1].(float64)
+	if !ok || int64(expires) <= time.Now().UnixMilli() {
+		return fmt.Errorf("expires = %v, want a future timestamp in ms", req.Args[1])
+	}
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("GET/realtime" + strconv.FormatInt(int64(expires), 10)))
+	if want := hex.EncodeToString(mac.Sum(nil)); req.Args[2] != want {
+		return fmt.Errorf("signature = %v, want %s", req.Args[2], want)
+	}
+	return nil
+}
+
+func (f *fakeBybitAuth) write(msg string) {
+	f.mu.Lock()
+	c := f.conn
+	f.mu.Unlock()
+	f.wmu.Lock()
+	defer f.wmu.Unlock()
+	_ = c.WriteMessage(websocket.TextMessage, []byte(msg))
+}
+
+func (f *fakeBybitAuth) auth() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.authErr
+}
+
+func (f *fakeBybitAuth) received() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.ops...)
+}
+
+func (f *fakeBybitAuth) wsURL() string { return "ws" + strings.TrimPrefix(f.URL, "http") }
+
+func TestClient_wsAuthV5(t *testing.T) {
+	tests := []struct {
+		name     string
+		authResp string
+		wantErr  error
+	}{
+		{name: "private ok", authResp: `{"success":true,"ret_msg":"","op":"auth","conn_id":"c1"}`},
+		{name: "trade ok", authResp: `{"retCode":0,"retMsg":"OK","op":"auth","connId":"c1"}`},
+		{
+			name:     "private rejected",
+			authResp: `{"success":false,"ret_msg":"Params Error","op":"auth","conn_id":"c1"}`,
+			wantErr:  fmt.Errorf("bybit: auth failed: Params Error"),
+		},
+		{
+			name:     "trade rejected",
+			authResp: `{"retCode":10004,"retMsg":"Invalid sign","op":"auth","connId":"c1"}`,
+			wantErr:  newAPIError(10004, "Invalid sign"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeBybitAuth(t, tt.authResp)
+			conn, _, err := websocket.DefaultDialer.Dial(server.wsURL(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			b := New(nil, "", "key", "secret", false)
+			err = b.wsAuthV5(conn)
+			if authErr := server.auth(); authErr != nil {
+				t.Fatal(authErr)
+			}
+			if fmt.Sprint(err) != fmt.Sprint(tt.wantErr) {
+				t.Errorf("wsAuthV5() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrivateWsClientV5(t *testing.T) {
+	server := newFakeBybitAuth(t, `{"success":true,"ret_msg":"","op":"auth","conn_id":"c1"}`)
+	orders := make(chan string, 1)
+	b := New(nil, "", "key", "secret", false)
+	p, err := b.NewPrivateWsClientV5(context.Background(), PrivateHandlersV5{
+		Order: func(data []OrderStreamV5) { orders <- data[0].OrderId },
+	}, []string{TopicOrderV5}, SetURL(server.wsURL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if err := server.auth(); err != nil {
+		t.Fatal(err)
+	}
+
+	// auth 之後才發送訂閱
+	want := []string{"subscribe " + TopicOrderV5}
+	if !waitFor(time.Second, func() bool { return reflect.DeepEqual(server.received(), want) }) {
+		t.Fatalf("received %v, want %v", server.received(), want)
+	}
+	if err := p.Subscribe(TopicOrderV5, TopicWalletV5); err != nil {
+		t.Fatal(err)
+	}
+	want = append(want, "subscribe "+TopicWalletV5)
+	if !waitFor(time.Second, func() bool { return reflect.DeepEqual(server.received(), want) }) {
+		t.Fatalf("received %v, want %v", server.received(), want)
+	}
+
+	server.write(`{"id":"1","topic":"order","creationTime":1,"data":[{"category":"linear","symbol":"BTCUSDT","orderId":"o1","orderStatus":"New"}]}`)
+	select {
+	case id := <-orders:
+		if id != "o1" {
+			t.Errorf("order id = %s, want o1", id)
+		}
+	case <-time.After(time.Second):
+		t.Error("Order handler not called")
+	}
+}
@@ -0,0 +1,301 @@
+package bybit
+
+import (
+	"context"
+	sjson "encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	TradeWsURLV5        = "wss://stream.bybit.com/v5/trade"
+	TradeWsTestnetURLV5 = "wss://stream-testnet.bybit.com/v5/trade"
+
+	OpOrderCreateV5      = "order.create"
+	OpOrderAmendV5       = "order.amend"
+	OpOrderCancelV5      = "order.cancel"
+	OpOrderCreateBatchV5 = "order.create-batch"
+	OpOrderAmendBatchV5  = "order.amend-batch"
+	OpOrderCancelBatchV5 = "order.cancel-batch"
+
+	defaultTradeTimeoutV5 = 5 * time.Second
+)
+
+// ErrTradeTimeoutV5 在超時時間內未收到對應 reqId 的回包
+var ErrTradeTimeoutV5 = errors.New("bybit: trade request timeout")
+
+// RateLimitV5 回包 header 中的頻率限制信息
+type RateLimitV5 struct {
+	Limit     int    // X-Bapi-Limit 當前接口每秒上限
+	Remaining int    // X-Bapi-Limit-Status 剩餘次數
+	ResetAt   int64  // X-Bapi-Limit-Reset-Timestamp (ms)
+	TraceId   string // Traceid
+	TimeNow   int64  // Timenow 服務器時間 (ms)
+}
+
+func parseRateLimitV5(h map[string]string) (r RateLimitV5) {
+	r.Limit, _ = strconv.Atoi(h["X-Bapi-Limit"])
+	r.Remaining, _ = strconv.Atoi(h["X-Bapi-Limit-Status"])
+	r.ResetAt, _ = strconv.ParseInt(h["X-Bapi-Limit-Reset-Timestamp"], 10, 64)
+	r.TraceId = h["Traceid"]
+	r.TimeNow, _ = strconv.ParseInt(h["Timenow"], 10, 64)
+	return
+}
+
+// BatchOrderResultV5 批量操作中單筆訂單的結果，Code 非 0 表示該筆失敗
+type BatchOrderResultV5 struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	OrderId     string `json:"orderId"`
+	OrderLinkId string `json:"orderLinkId"`
+	CreateAt    string `json:"createAt"`
+	Code        int    `json:"-"`
+	Msg         string `json:"-"`
+}
+
+// Err 單筆失敗時返回 APIError
+func (r *BatchOrderResultV5) Err() error {
+	if r.Code == 0 {
+		return nil
+	}
+	return newAPIError(r.Code, r.Msg)
+}
+
+type tradeResponseV5 struct {
+	ReqId      string            `json:"reqId"`
+	RetCode    int               `json:"retCode"`
+	RetMsg     string            `json:"retMsg"`
+	Op         string            `json:"op"`
+	Data       sjson.RawMessage  `json:"data"`
+	RetExtInfo sjson.RawMessage  `json:"retExtInfo"`
+	Header     map[string]string `json:"header"`
+	ConnId     string            `json:"connId"`
+}
+
+// TradeWsClientV5 V5 /v5/trade 下單 WebSocket
+// 每個請求帶自增 reqId，回包按 reqId 交還給調用方；斷線重連後自動重新 auth
+type TradeWsClientV5 struct {
+	*WsClient
+	b       *Client
+	timeout atomic.Int64 // 等待回包的超時（time.Duration），可與請求並發讀寫
+	referer string
+
+	seq     int64
+	mu      sync.Mutex
+	pending map[string]chan *tradeResponseV5
+}
+
+// NewTradeWsClientV5 建立下單連接，opts 可覆蓋 URL（如測試網）
+func (b *Client) NewTradeWsClientV5(ctx context.Context, opts ...func(*Options)) (*TradeWsClientV5, error) {
+	t := &TradeWsClientV5{
+		b:       b,
+		referer: "AntBot",
+		pending: make(map[string]chan *tradeResponseV5),
+	}
+	t.timeout.Store(int64(defaultTradeTimeoutV5))
+	options := []func(*Options){
+		SetURL(TradeWsURLV5),
+		SetSubscriber(t.login, nil),
+		SetPingInterval(wsPingIntervalV5),
+		SetWorkers(1),
+	}
+	ws, err := NewWsClient(ctx, t.handle, append(options, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	t.WsClient = ws
+	return t, nil
+}
+
+// SetTimeout 設置等待回包的超時時間，默認 5s，可在請求進行中調用
+func (t *TradeWsClientV5) SetTimeout(d time.Duration) {
+	t.timeout.Store(int64(d))
+}
+
+// SetReferer 設置請求 header 中的 Referer（經紀商 id），須在發送請求前調用
+func (t *TradeWsClientV5) SetReferer(referer string) {
+	t.referer = referer
+}
+
+// CreateOrder 下單
+func (t *TradeWsClientV5) CreateOrder(param *CreateOrderParamV5) (result OrderResultV5, limit RateLimitV5, err error) {
+	limit, err = t.request(OpOrderCreateV5, param.params(), &result)
+	return
+}
+
+// AmendOrder 改單
+func (t *TradeWsClientV5) AmendOrder(param *AmendOrderParamV5) (result OrderResultV5, limit RateLimitV5, err error) {
+	limit, err = t.request(OpOrderAmendV5, param.params(), &result)
+	return
+}
+
+// CancelOrder 撤單
+func (t *TradeWsClientV5) CancelOrder(param *CancelOrderParamV5) (result OrderResultV5, limit RateLimitV5, err error) {
+	limit, err = t.request(OpOrderCancelV5, param.params(), &result)
+	return
+}
+
+// CreateOrderBatch 批量下單，單筆結果見 BatchOrderResultV5.Err
+// 支持 spot(最多 10 筆)、linear/inverse/option(最多 20 筆)
+func (t *TradeWsClientV5) CreateOrderBatch(category string, params []*CreateOrderParamV5) ([]BatchOrderResultV5, RateLimitV5, error) {
+	reqs := make([]map[string]interface{}, len(params))
+	for i, p := range params {
+		reqs[i] = p.params()
+	}
+	return t.batch(OpOrderCreateBatchV5, category, reqs)
+}
+
+// AmendOrderBatch 批量改單
+func (t *TradeWsClientV5) AmendOrderBatch(category string, params []*AmendOrderParamV5) ([]BatchOrderResultV5, RateLimitV5, error) {
+	reqs := make([]map[string]interface{}, len(params))
+	for i, p := range params {
+		reqs[i] = p.params()
+	}
+	return t.batch(OpOrderAmendBatchV5, category, reqs)
+}
+
+// CancelOrderBatch 批量撤單
+func (t *TradeWsClientV5) CancelOrderBatch(category string, params []*CancelOrderParamV5) ([]BatchOrderResultV5, RateLimitV5, error) {
+	reqs := make([]map[string]interface{}, len(params))
+	for i, p := range params {
+		reqs[i] = p.params()
+	}
+	return t.batch(OpOrderCancelBatchV5, category, reqs)
+}
+
+func (t *TradeWsClientV5) batch(op, category string, reqs []map[string]interface{}) (results []BatchOrderResultV5, limit RateLimitV5, err error) {
+	for _, r := range reqs {
+		delete(r, "category") // 批量接口 category 只在外層指定
+	}
+	arg := map[string]interface{}{"category": category, "request": reqs}
+
+	var data struct {
+		List []BatchOrderResultV5 `json:"list"`
+	}
+	var ext struct {
+		List []struct {
+			Code int    `json:"code"`
+			Msg  string `json:"msg"`
+		} `json:"list"`
+	}
+	var resp *tradeResponseV5
+	if resp, err = t.roundTrip(op, arg); resp != nil {
+		limit = parseRateLimitV5(resp.Header)
+	}
+	if err != nil {
+		return
+	}
+	if len(resp.Data) > 0 {
+		if err = json.Unmarshal(resp.Data, &data); err != nil {
+			return
+		}
+	}
+	if len(resp.RetExtInfo) > 0 {
+		if err = json.Unmarshal(resp.RetExtInfo, &ext); err != nil {
+			return
+		}
+	}
+	results = data.List
+	for i := range results {
+		if i < len(ext.List) {
+			results[i].Code, results[i].Msg = ext.List[i].Code, ext.List[i].Msg
+		}
+	}
+	return
+}
+
+func (t *TradeWsClientV5) request(op string, arg map[string]interface{}, result interface{}) (limit RateLimitV5, err error) {
+	var resp *tradeResponseV5
+	if resp, err = t.roundTrip(op, arg); resp != nil {
+		limit = parseRateLimitV5(resp.Header)
+	}
+	if err != nil {
+		return
+	}
+	if len(resp.Data) > 0 {
+		err = json.Unmarshal(resp.Data, result)
+	}
+	return
+}
+
+// roundTrip 發送請求並等待同 reqId 的回包，retCode 非 0 時同時返回回包與 APIError
+func (t *TradeWsClientV5) roundTrip(op string, arg interface{}) (*tradeResponseV5, error) {
+	reqId := strconv.FormatInt(atomic.AddInt64(&t.seq, 1), 10)
+	ch := make(chan *tradeResponseV5, 1)
+	t.mu.Lock()
+	t.pending[reqId] = ch
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		delete(t.pending, reqId)
+		t.mu.Unlock()
+	}()
+
+	bs, err := json.Marshal(map[string]interface{}{
+		"reqId": reqId,
+		"header": map[string]string{
+			"X-BAPI-TIMESTAMP":   strconv.FormatInt(t.b.timestamp(), 10),
+			"X-BAPI-RECV-WINDOW": strconv.Itoa(recvWindow),
+			"Referer":            t.referer,
+		},
+		"op":   op,
+		"args": []interface{}{arg},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = t.safeWrite(websocket.TextMessage, bs); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(time.Duration(t.timeout.Load()))
+	defer timer.Stop()
+	select {
+	case resp := <-ch:
+		if resp.RetCode != 0 {
+			return resp, newAPIError(resp.RetCode, resp.RetMsg)
+		}
+		return resp, nil
+	case <-timer.C:
+		return nil, ErrTradeTimeoutV5
+	case <-t.ctx.Done():
+		return nil, t.ctx.Err()
+	}
+}
+
+// login 每次（重）連後重新鑒權
+func (t *TradeWsClientV5) login(conn *websocket.Conn, _ ...string) error {
+	return t.b.wsAuthV5(conn)
+}
+
+func (t *TradeWsClientV5) handle(bs []byte) error {
+	var resp tradeResponseV5
+	if err := json.Unmarshal(bs, &resp); err != nil {
+		return err
+	}
+	if resp.ReqId == "" {
+		if resp.RetCode != 0 {
+			zap.S().Errorf("bybit trade %s failed: %d %s", resp.Op, resp.RetCode, resp.RetMsg)
+		}
+		return nil // pong 等
+	}
+
+	t.mu.Lock()
+	ch, ok := t.pending[resp.ReqId]
+	t.mu.Unlock()
+	if !ok {
+		zap.S().Warnf("bybit trade: late response reqId=%s op=%s", resp.ReqId, resp.Op)
+		return nil
+	}
+	select {
+	case ch <- &resp:
+	default:
+	}
+	return nil
+}
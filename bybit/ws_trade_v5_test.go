@@ -0,0 +1,165 @@
+package bybit
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tradeAckV5 order.* 回包：symbol 作為 orderId；symbol 為 BADUSDT 時 retCode=110007，
+// 批量請求中該筆的結果寫入 retExtInfo
+func tradeAckV5(req map[string]any) string {
+	arg := req["args"].([]any)[0].(map[string]any)
+	header := `{"X-Bapi-Limit":"10","X-Bapi-Limit-Status":"9","X-Bapi-Limit-Reset-Timestamp":"1711001595208","Traceid":"t1","Timenow":"1711001595209"}`
+	if list, ok := arg["request"].([]any); ok {
+		var data, ext []string
+		for _, r := range list {
+			symbol := r.(map[string]any)["symbol"]
+			if symbol == "BADUSDT" {
+				data = append(data, `{"category":"linear","symbol":"BADUSDT","orderId":"","orderLinkId":""}`)
+				ext = append(ext, `{"code":110007,"msg":"ab not enough for new order"}`)
+				continue
+			}
+			data = append(data, fmt.Sprintf(`{"category":"linear","symbol":"%s","orderId":"%s","orderLinkId":""}`, symbol, symbol))
+			ext = append(ext, `{"code":0,"msg":"OK"}`)
+		}
+		return fmt.Sprintf(`{"reqId":"%s","retCode":0,"retMsg":"OK","op":"%s","data":{"list":[%s]},"retExtInfo":{"list":[%s]},"header":%s}`,
+			req["reqId"], req["op"], strings.Join(data, ","), strings.Join(ext, ","), header)
+	}
+	if arg["symbol"] == "BADUSDT" {
+		return fmt.Sprintf(`{"reqId":"%s","retCode":110007,"retMsg":"ab not enough for new order","op":"%s","data":{},"header":%s}`,
+			req["reqId"], req["op"], header)
+	}
+	return fmt.Sprintf(`{"reqId":"%s","retCode":0,"retMsg":"OK","op":"%s","data":{"orderId":"%s","orderLinkId":"%v"},"header":%s}`,
+		req["reqId"], req["op"], arg["symbol"], arg["orderLinkId"], header)
+}
+
+func (f *fakeBybitAuth) onRequest(fn func(req map[string]any, reply func(string))) {
+	f.mu.Lock()
+	f.onOp = fn
+	f.mu.Unlock()
+}
+
+func newTestTradeWsV5(t *testing.T, fn func(req map[string]any, reply func(string))) *TradeWsClientV5 {
+	server := newFakeBybitAuth(t, `{"retCode":0,"retMsg":"OK","op":"auth","connId":"c1"}`)
+	server.onRequest(fn)
+	b := New(nil, "", "key", "secret", false)
+	tc, err := b.NewTradeWsClientV5(context.Background(), SetURL(server.wsURL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { tc.Close() })
+	if err := server.auth(); err != nil {
+		t.Fatal(err)
+	}
+	return tc
+}
+
+func TestTradeWsClientV5_reqIdCorrelation(t *testing.T) {
+	// 先到的 BTCUSDT 最後回包，回包順序與請求順序相反
+	btc := make(chan func(), 1)
+	tc := newTestTradeWsV5(t, func(req map[string]any, reply func(string)) {
+		if req["reqId"] == nil {
+			return
+		}
+		ack := tradeAckV5(req)
+		if req["args"].([]any)[0].(map[string]any)["symbol"] == "BTCUSDT" {
+			btc <- func() { reply(ack) }
+			return
+		}
+		reply(ack)
+	})
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string]string)
+	)
+	order := func(symbol string) {
+		defer wg.Done()
+		ret, _, err := tc.CreateOrder(&CreateOrderParamV5{Category: CategoryLinear, Symbol: symbol, Side: "Buy", OrderType: "Market", Qty: "1", OrderLinkId: "l-" + symbol})
+		if err != nil {
+			t.Errorf("CreateOrder(%s) error = %v", symbol, err)
+		}
+		mu.Lock()
+		results[symbol] = ret.OrderId + "/" + ret.OrderLinkId
+		mu.Unlock()
+	}
+	wg.Add(1)
+	go order("BTCUSDT")
+	var reply func()
+	select {
+	case reply = <-btc:
+	case <-time.After(time.Second):
+		t.Fatal("BTCUSDT order not received")
+	}
+	wg.Add(2)
+	go order("ETHUSDT")
+	go order("SOLUSDT")
+	tc.SetTimeout(2 * time.Second) // 與進行中的請求並發設置
+	if !waitFor(time.Second, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(results) == 2
+	}) {
+		t.Fatal("ETHUSDT/SOLUSDT orders not answered before BTCUSDT")
+	}
+	reply()
+	wg.Wait()
+
+	want := map[string]string{"BTCUSDT": "BTCUSDT/l-BTCUSDT", "ETHUSDT": "ETHUSDT/l-ETHUSDT", "SOLUSDT": "SOLUSDT/l-SOLUSDT"}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("results = %v, want %v", results, want)
+	}
+}
+
+func TestTradeWsClientV5_errors(t *testing.T) {
+	tc := newTestTradeWsV5(t, func(req map[string]any, reply func(string)) {
+		if req["reqId"] == nil {
+			return
+		}
+		if arg := req["args"].([]any)[0].(map[string]any); arg["symbol"] == "SLOWUSDT" {
+			return
+		}
+		reply(tradeAckV5(req))
+	})
+	tc.SetTimeout(100 * time.Millisecond)
+
+	wantLimit := RateLimitV5{Limit: 10, Remaining: 9, ResetAt: 1711001595208, TraceId: "t1", TimeNow: 1711001595209}
+	t.Run("retCode", func(t *testing.T) {
+		_, limit, err := tc.CreateOrder(&CreateOrderParamV5{Category: CategoryLinear, Symbol: "BADUSDT", Side: "Buy", OrderType: "Market", Qty: "1"})
+		if want := newAPIError(110007, "ab not enough for new order"); !reflect.DeepEqual(err, want) {
+			t.Errorf("CreateOrder() error = %#v, want %#v", err, want)
+		}
+		if limit != wantLimit {
+			t.Errorf("limit = %+v, want %+v", limit, wantLimit)
+		}
+	})
+	t.Run("batch partial", func(t *testing.T) {
+		results, limit, err := tc.CreateOrderBatch(CategoryLinear, []*CreateOrderParamV5{
+			{Symbol: "BTCUSDT", Side: "Buy", OrderType: "Market", Qty: "1"},
+			{Symbol: "BADUSDT", Side: "Buy", OrderType: "Market", Qty: "1"},
+		})
+		if err != nil {
+			t.Fatalf("CreateOrderBatch() error = %v", err)
+		}
+		if limit != wantLimit {
+			t.Errorf("limit = %+v, want %+v", limit, wantLimit)
+		}
+		if len(results) != 2 || results[0].OrderId != "BTCUSDT" || results[0].Err() != nil {
+			t.Fatalf("results = %+v, want BTCUSDT ok", results)
+		}
+		if want := newAPIError(110007, "ab not enough for new order"); !reflect.DeepEqual(results[1].Err(), want) {
+			t.Errorf("results[1].Err() = %#v, want %#v", results[1].Err(), want)
+		}
+	})
+	t.Run("timeout", func(t *testing.T) {
+		if _, _, err := tc.CancelOrder(&CancelOrderParamV5{Category: CategoryLinear, Symbol: "SLOWUSDT", OrderId: "1"}); err != ErrTradeTimeoutV5 {
+			t.Errorf("CancelOrder() error = %v, want %v", err, ErrTradeTimeoutV5)
+		}
+	})
+}
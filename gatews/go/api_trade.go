@@ -0,0 +1,267 @@
+package gatews
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// WebSocket API 交易：先 login，之后的 api 请求按 req_id 与回包对应
+// 现货连接 BaseUrl，合约连接 FuturesUsdtUrl / FuturesBtcUrl
+
+const (
+	ApiSpot    = "spot"
+	ApiFutures = "futures"
+
+	EventApi = "api"
+
+	ApiLogin       = "login"
+	ApiOrderPlace  = "order_place"
+	ApiOrderCancel = "order_cancel"
+	ApiOrderAmend  = "order_amend"
+	ApiOrderStatus = "order_status"
+)
+
+/*------------------ 请求 / 回包 ------------------*/
+
+type ApiRequest struct {
+	Time    int64      `json:"time"`
+	Channel string     `json:"channel"`
+	Event   string     `json:"event"`
+	Payload ApiPayload `json:"payload"`
+}
+
+type ApiPayload struct {
+	RequestId     string            `json:"req_id"`
+	ApiKey        string            `json:"api_key,omitempty"`
+	Signature     string            `json:"signature,omitempty"`
+	Timestamp     string            `json:"timestamp,omitempty"`
+	RequestParam  json.RawMessage   `json:"req_param,omitempty"`
+	RequestHeader map[string]string `json:"req_header,omitempty"`
+}
+
+type ApiResponseHeader struct {
+	ResponseTime string `json:"response_time"`
+	Status       string `json:"status"`
+	Channel      string `json:"channel"`
+	Event        string `json:"event"`
+	ClientId     string `json:"client_id"`
+	ConnId       string `json:"conn_id"`
+	TraceId      string `json:"trace_id"`
+}
+
+// ApiResponse 下单类请求会先回一条 Ack=true 的确认，再回 Ack=false 的结果
+type ApiResponse struct {
+	RequestId string            `json:"request_id"`
+	Ack       bool              `json:"ack"`
+	Header    ApiResponseHeader `json:"header"`
+	Data      struct {
+		Result json.RawMessage `json:"result"`
+		Errs   *ApiError       `json:"errs,omitempty"`
+	} `json:"data"`
+}
+
+// ApiError api 请求失败时的 label/message，如 INVALID_KEY / ORDER_NOT_FOUND
+type ApiError struct {
+	Label   string `json:"label"`
+	Message string `json:"message"`
+}
+
+func (e *ApiError) Error() string {
+	return e.Label + ": " + e.Message
+}
+
+type ApiLoginResult struct {
+	ApiKey string `json:"api_key"`
+	Uid    string `json:"uid"`
+}
+
+// SpotAmendParam 现货改单，Price/Amount 至少填一项
+type SpotAmendParam struct {
+	OrderId      string `json:"order_id"`
+	CurrencyPair string `json:"currency_pair"`
+	Account      string `json:"account,omitempty"`
+	Price        string `json:"price,omitempty"`
+	Amount       string `json:"amount,omitempty"`
+	AmendText    string `json:"amend_text,omitempty"`
+}
+
+// FuturesAmendParam 合约改单，Price/Size 至少填一项
+type FuturesAmendParam struct {
+	OrderId   string `json:"order_id"`
+	Price     string `json:"price,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	AmendText string `json:"amend_text,omitempty"`
+}
+
+/*------------------ 登录 ------------------*/
+
+// ApiLogin 登录 spot / futures api 通道，成功后断线重连会自动重新登录
+func (ws *WsService) ApiLogin(ctx context.Context, market string) (*ApiLoginResult, error) {
+	if ws.conf.Key == "" || ws.conf.Secret == "" {
+		return nil, newAuthEmptyErr()
+	}
+	var ret ApiLoginResult
+	if err := ws.apiCall(ctx, market+"."+ApiLogin, nil, &ret); err != nil {
+		return nil, err
+	}
+	ws.apiLogins.Store(market, true)
+	return &ret, nil
+}
+
+// relogin 重连后重新登录已登录过的通道
+func (ws *WsService) relogin() {
+	ws.apiLogins.Range(func(k, _ any) bool {
+		ctx, cancel := context.WithTimeout(ws.Ctx, defaultRequestTimeout)
+		defer cancel()
+		if _, err := ws.ApiLogin(ctx, k.(string)); err != nil {
+			zap.S().Warnf("重新登录 %s api 失败：%v", k, err)
+		}
+		return true
+	})
+}
+
+/*------------------ 现货 ------------------*/
+
+func (ws *WsService) SpotOrderPlace(ctx context.Context, order OrderMsg) (result OrderMsg, err error) {
+	err = ws.apiCall(ctx, ApiSpot+"."+ApiOrderPlace, order, &result)
+	return
+}
+
+func (ws *WsService) SpotOrderCancel(ctx context.Context, pair, orderId string) (result OrderMsg, err error) {
+	param := map[string]string{"currency_pair": pair, "order_id": orderId}
+	err = ws.apiCall(ctx, ApiSpot+"."+ApiOrderCancel, param, &result)
+	return
+}
+
+func (ws *WsService) SpotOrderAmend(ctx context.Context, param SpotAmendParam) (result OrderMsg, err error) {
+	err = ws.apiCall(ctx, ApiSpot+"."+ApiOrderAmend, param, &result)
+	return
+}
+
+func (ws *WsService) SpotOrderStatus(ctx context.Context, pair, orderId string) (result OrderMsg, err error) {
+	param := map[string]string{"currency_pair": pair, "order_id": orderId}
+	err = ws.apiCall(ctx, ApiSpot+"."+ApiOrderStatus, param, &result)
+	return
+}
+
+/*------------------ 合约 ------------------*/
+
+func (ws *WsService) FuturesOrderPlace(ctx context.Context, order FuturesOrderParam) (result FuturesOrderDetail, err error) {
+	err = ws.apiCall(ctx, ApiFutures+"."+ApiOrderPlace, order, &result)
+	return
+}
+
+func (ws *WsService) FuturesOrderCancel(ctx context.Context, orderId string) (result FuturesOrderDetail, err error) {
+	err = ws.apiCall(ctx, ApiFutures+"."+ApiOrderCancel, map[string]string{"order_id": orderId}, &result)
+	return
+}
+
+func (ws *WsService) FuturesOrderAmend(ctx context.Context, param FuturesAmendParam) (result FuturesOrderDetail, err error) {
+	err = ws.apiCall(ctx, ApiFutures+"."+ApiOrderAmend, param, &result)
+	return
+}
+
+func (ws *WsService) FuturesOrderStatus(ctx context.Context, orderId string) (result FuturesOrderDetail, err error) {
+	err = ws.apiCall(ctx, ApiFutures+"."+ApiOrderStatus, map[string]string{"order_id": orderId}, &result)
+	return
+}
+
+/*------------------ 核心 ------------------*/
+
+// apiCall 发送 api 请求并等待 req_id 对应的最终回包；ctx 未设置截止时间时使用默认超时
+func (ws *WsService) apiCall(ctx context.Context, channel string, param, result any) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultRequestTimeout)
+		defer cancel()
+	}
+
+	reqId := strconv.FormatInt(atomic.AddInt64(&ws.reqId, 1), 10)
+	req := ApiRequest{
+		Time:    time.Now().Unix(),
+		Channel: channel,
+		Event:   EventApi,
+		Payload: ApiPayload{RequestId: reqId},
+	}
+	if param != nil {
+		bs, err := json.Marshal(param)
+		if err != nil {
+			return err
+		}
+		req.Payload.RequestParam = bs
+	}
+	if channel == ApiSpot+"."+ApiLogin || channel == ApiFutures+"."+ApiLogin {
+		ts := strconv.FormatInt(req.Time, 10)
+		req.Payload.ApiKey = ws.conf.Key
+		req.Payload.Timestamp = ts
+		req.Payload.Signature = ws.apiSign(channel, req.Payload.RequestParam, ts)
+	}
+
+	wait := make(chan *ApiResponse, 2)
+	ws.apiCalls.Store(reqId, wait)
+	defer ws.apiCalls.Delete(reqId)
+
+	bs, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	if err = ws.safeWrite(websocket.TextMessage, bs); err != nil {
+		return err
+	}
+	ws.once.Do(ws.readMsg)
+
+	for {
+		select {
+		case resp := <-wait:
+			if resp.Ack {
+				continue // 下单确认，继续等待结果
+			}
+			if resp.Data.Errs != nil {
+				return resp.Data.Errs
+			}
+			if resp.Header.Status != "" && resp.Header.Status != "200" {
+				return fmt.Errorf("%s status %s", channel, resp.Header.Status)
+			}
+			if result == nil || len(resp.Data.Result) == 0 {
+				return nil
+			}
+			return json.Unmarshal(resp.Data.Result, result)
+		case <-ctx.Done():
+			return fmt.Errorf("%s req_id=%s: %w", channel, reqId, ctx.Err())
+		}
+	}
+}
+
+// apiSign signature = hex(HMAC_SHA512(secret, "api\n" + channel + "\n" + req_param + "\n" + timestamp))
+func (ws *WsService) apiSign(channel string, param []byte, ts string) string {
+	mac := hmac.New(sha512.New, []byte(ws.conf.Secret))
+	mac.Write([]byte(EventApi + "\n" + channel + "\n" + string(param) + "\n" + ts))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// dispatchApi 由读协程调用，交给等待中的 apiCall
+func (ws *WsService) dispatchApi(message []byte) {
+	var resp ApiResponse
+	if err := json.Unmarshal(message, &resp); err != nil || resp.RequestId == "" {
+		return
+	}
+	w, ok := ws.apiCalls.Load(resp.RequestId)
+	if !ok {
+		zap.S().Warnf("api 回包无人等待 req_id=%s channel=%s", resp.RequestId, resp.Header.Channel)
+		return
+	}
+	select {
+	case w.(chan *ApiResponse) <- &resp:
+	default:
+	}
+}
@@ -0,0 +1,270 @@
+package gatews
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWsService_apiSign(t *testing.T) {
+	// 签名原文按 Gate WebSocket API 文档的登录示例拼接，req_param 为空；期望值由 openssl 独立计算
+	tests := []struct {
+		channel string
+		want    string
+	}{
+		{channel: "spot.login", want: "3e133322aeffb544225b263d43fc10fc8e6b9b14126a09e4c945363d60afc591c441f08c3eff1770c1331171386501f6843560f889840e87ba1f3d99a80daffa"},
+		{channel: "futures.login", want: "7d9fc2b54fe263d2c1b8a1755e918c8f606395c08a3aeab3324c72273a92e8512720ccae4a5ad994b68a7aca4c3b9e663d19247b1e461717d939c20bc1c19cd1"},
+	}
+	ws := &WsService{conf: &ConnConf{Secret: "secret"}}
+	for _, tt := range tests {
+		t.Run(tt.channel, func(t *testing.T) {
+			if got := ws.apiSign(tt.channel, nil, "1681984544"); got != tt.want {
+				t.Errorf("apiSign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeGateApi 按文档格式校验登录签名，签名不符时回 INVALID_SIGNATURE；其余 api 请求交给 onApi 应答
+type fakeGateApi struct {
+	*httptest.Server
+	mu    sync.Mutex
+	wmu   sync.Mutex
+	conn  *websocket.Conn
+	onApi func(req ApiRequest, reply func(string))
+}
+
+func newFakeGateApi(t *testing.T, onApi func(req ApiRequest, reply func(string))) *fakeGateApi {
+	f := &fakeGateApi{onApi: onApi}
+	upgrader := websocket.Upgrader{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		f.mu.Lock()
+		f.conn = c
+		f.mu.Unlock()
+
+		for {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req ApiRequest
+			if json.Unmarshal(data, &req) != nil || req.Event != EventApi {
+				continue // 业务层 ping
+			}
+			if strings.HasSuffix(req.Channel, "."+ApiLogin) {
+				f.write(loginResp(req))
+				continue
+			}
+			if f.onApi != nil {
+				f.onApi(req, f.write)
+			}
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// loginResp signature = hex(HMAC_SHA512(secret, "api\n" + channel + "\n" + req_param + "\n" + timestamp))
+func loginResp(req ApiRequest) string {
+	p := req.Payload
+	mac := hmac.New(sha512.New, []byte("secret"))
+	mac.Write([]byte("api\n" + req.Channel + "\n" + string(p.RequestParam) + "\n" + p.Timestamp))
+	if p.ApiKey != "key" || p.Timestamp != strconv.FormatInt(req.Time, 10) || p.Signature != hex.EncodeToString(mac.Sum(nil)) {
+		return apiResp(p.RequestId, req.Channel, false, "401", `{"errs":{"label":"INVALID_SIGNATURE","message":"Signature mismatch"}}`)
+	}
+	return apiResp(p.RequestId, req.Channel, false, "200", `{"result":{"api_key":"key","uid":"110284739"}}`)
+}
+
+func apiResp(reqId, channel string, ack bool, status, data string) string {
+	return fmt.Sprintf(`{"request_id":"%s","ack":%t,"header":{"response_time":"1681985856667","status":"%s","channel":"%s","event":"api"},"data":%s}`,
+		reqId, ack, status, channel, data)
+}
+
+func (f *fakeGateApi) write(msg string) {
+	f.mu.Lock()
+	c := f.conn
+	f.mu.Unlock()
+	f.wmu.Lock()
+	defer f.wmu.Unlock()
+	_ = c.WriteMessage(websocket.TextMessage, []byte(msg))
+}
+
+func newTestApiService(t *testing.T, secret string, onApi func(req ApiRequest, reply func(string))) *WsService {
+	server := newFakeGateApi(t, onApi)
+	ws, err := NewWsService(context.Background(), &ConnConf{
+		URL:    "ws" + strings.TrimPrefix(server.URL, "http"),
+		Key:    "key",
+		Secret: secret,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ws.cancel()
+		_ = ws.Client.Close()
+	})
+	return ws
+}
+
+func TestWsService_ApiLogin(t *testing.T) {
+	tests := []struct {
+		name    string
+		market  string
+		secret  string
+		want    *ApiLoginResult
+		wantErr error
+	}{
+		{name: "spot", market: ApiSpot, secret: "secret", want: &ApiLoginResult{ApiKey: "key", Uid: "110284739"}},
+		{name: "futures", market: ApiFutures, secret: "secret", want: &ApiLoginResult{ApiKey: "key", Uid: "110284739"}},
+		{name: "rejected", market: ApiSpot, secret: "wrong", wantErr: &ApiError{Label: "INVALID_SIGNATURE", Message: "Signature mismatch"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := newTestApiService(t, tt.secret, nil)
+			got, err := ws.ApiLogin(context.Background(), tt.market)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("ApiLogin() error = %#v, want %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApiLogin() = %+v, want %+v", got, tt.want)
+			}
+			// 仅登录成功的通道在重连后重新登录
+			if _, ok := ws.apiLogins.Load(tt.market); ok != (tt.wantErr == nil) {
+				t.Errorf("apiLogins[%s] = %v, want %v", tt.market, ok, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+// spotOrderResp 先回 ack 再回结果，currency_pair 作为订单 id
+func spotOrderResp(req ApiRequest, reply func(string)) {
+	var order OrderMsg
+	_ = json.Unmarshal(req.Payload.RequestParam, &order)
+	reply(apiResp(req.Payload.RequestId, req.Channel, true, "200", `{"result":{"req_id":"`+req.Payload.RequestId+`"}}`))
+	reply(apiResp(req.Payload.RequestId, req.Channel, false, "200",
+		fmt.Sprintf(`{"result":{"id":"%s","text":"%s","currency_pair":"%s","status":"open"}}`, order.CurrencyPair, order.Text, order.CurrencyPair)))
+}
+
+func TestWsService_apiReqIdCorrelation(t *testing.T) {
+	// 先到的 BTC_USDT 最后回包，回包顺序与请求顺序相反
+	btc := make(chan func(), 1)
+	ws := newTestApiService(t, "secret", func(req ApiRequest, reply func(string)) {
+		if strings.Contains(string(req.Payload.RequestParam), "BTC_USDT") {
+			btc <- func() { spotOrderResp(req, reply) }
+			return
+		}
+		spotOrderResp(req, reply)
+	})
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string]string)
+	)
+	order := func(pair string) {
+		defer wg.Done()
+		ret, err := ws.SpotOrderPlace(context.Background(), OrderMsg{CurrencyPair: pair, Text: "t-" + pair, Side: "buy", Amount: "1", Price: "1"})
+		if err != nil {
+			t.Errorf("SpotOrderPlace(%s) error = %v", pair, err)
+		}
+		mu.Lock()
+		results[pair] = ret.Id + "/" + ret.Text
+		mu.Unlock()
+	}
+	wg.Add(1)
+	go order("BTC_USDT")
+	var reply func()
+	select {
+	case reply = <-btc:
+	case <-time.After(time.Second):
+		t.Fatal("BTC_USDT order not received")
+	}
+	wg.Add(2)
+	go order("ETH_USDT")
+	go order("SOL_USDT")
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		n := len(results)
+		mu.Unlock()
+		if n == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("ETH_USDT/SOL_USDT orders not answered before BTC_USDT")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	reply()
+	wg.Wait()
+
+	want := map[string]string{"BTC_USDT": "BTC_USDT/t-BTC_USDT", "ETH_USDT": "ETH_USDT/t-ETH_USDT", "SOL_USDT": "SOL_USDT/t-SOL_USDT"}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("results = %v, want %v", results, want)
+	}
+}
+
+func TestWsService_apiErrors(t *testing.T) {
+	ws := newTestApiService(t, "secret", func(req ApiRequest, reply func(string)) {
+		id := req.Payload.RequestId
+		switch param := string(req.Payload.RequestParam); {
+		case strings.Contains(param, `"404"`):
+			reply(apiResp(id, req.Channel, false, "400", `{"errs":{"label":"ORDER_NOT_FOUND","message":"Order not found"}}`))
+		case strings.Contains(param, `"500"`):
+			reply(apiResp(id, req.Channel, false, "500", `{}`))
+		case strings.Contains(param, `"slow"`):
+			reply(apiResp(id, req.Channel, true, "200", `{"result":{"req_id":"`+id+`"}}`)) // 只有 ack，没有结果
+		default:
+			reply(apiResp(id, req.Channel, false, "200", `{"result":{"id":1,"contract":"BTC_USDT","size":1,"status":"finished"}}`))
+		}
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		got, err := ws.FuturesOrderCancel(context.Background(), "1")
+		if err != nil {
+			t.Fatalf("FuturesOrderCancel() error = %v", err)
+		}
+		if want := (FuturesOrderDetail{Id: 1, Contract: "BTC_USDT", Size: 1, Status: "finished"}); !reflect.DeepEqual(got, want) {
+			t.Errorf("FuturesOrderCancel() = %+v, want %+v", got, want)
+		}
+	})
+	t.Run("errs", func(t *testing.T) {
+		_, err := ws.SpotOrderStatus(context.Background(), "BTC_USDT", "404")
+		if want := (&ApiError{Label: "ORDER_NOT_FOUND", Message: "Order not found"}); !reflect.DeepEqual(err, want) {
+			t.Errorf("SpotOrderStatus() error = %#v, want %#v", err, want)
+		}
+	})
+	t.Run("status", func(t *testing.T) {
+		_, err := ws.FuturesOrderStatus(context.Background(), "500")
+		if want := "futures.order_status status 500"; fmt.Sprint(err) != want {
+			t.Errorf("FuturesOrderStatus() error = %v, want %s", err, want)
+		}
+	})
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := ws.FuturesOrderAmend(ctx, FuturesAmendParam{OrderId: "slow", Price: "1"})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("FuturesOrderAmend() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"runtime/debug"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -36,14 +37,79 @@ func (ws *WsService) SubscribeWithOption(channel string, payload []string, op *S
 	}
 
 	// 初始化队列
-	msgCh, ok := ws.msgChs.Load(channel)
-	if !ok {
-		msgCh = make(chan *UpdateMsg, 1)
-		go ws.receiveCallMsg(channel, msgCh.(chan *UpdateMsg))
-		ws.msgChs.Store(channel, msgCh)
+	q := &channelQueue{ch: make(chan *UpdateMsg, 1), done: make(chan struct{})}
+	if v, loaded := ws.msgChs.LoadOrStore(channel, q); loaded {
+		q = v.(*channelQueue)
+	} else {
+		go ws.receiveCallMsg(channel, q)
+	}
+
+	return ws.newBaseChannel(channel, payload, q.ch, op)
+}
+
+// Unsubscribe 取消订阅并返回服务端回包，payload 须与订阅时一致（或为订阅列表的子集）；
+// 成功后从重连恢复列表中移除，channel 下再无订阅时停止其分发协程
+func (ws *WsService) Unsubscribe(channel string, payload []string) (*UpdateMsg, error) {
+	id := atomic.AddInt64(&ws.reqId, 1)
+	wait := make(chan *UpdateMsg, 1)
+	ws.pending.Store(id, wait)
+	defer ws.pending.Delete(id)
+
+	if err := ws.baseSubscribe(UnSubscribe, channel, payload, &SubscribeOptions{ID: id}); err != nil {
+		return nil, err
+	}
+	ws.once.Do(ws.readMsg)
+
+	timer := time.NewTimer(defaultRequestTimeout)
+	defer timer.Stop()
+	var msg *UpdateMsg
+	select {
+	case msg = <-wait:
+	case <-timer.C:
+		return nil, fmt.Errorf("unsubscribe %s timeout", channel)
+	case <-ws.Ctx.Done():
+		return nil, ws.Ctx.Err()
+	}
+	if msg.Error != nil {
+		return msg, msg.Error
+	}
+
+	if ws.removeHistory(channel, payload) {
+		if v, ok := ws.msgChs.LoadAndDelete(channel); ok {
+			close(v.(*channelQueue).done)
+		}
 	}
+	return msg, nil
+}
+
+// removeHistory 从恢复列表中移除 payload，返回该 channel 是否已无订阅
+func (ws *WsService) removeHistory(channel string, payload []string) bool {
+	ws.subMu.Lock()
+	defer ws.subMu.Unlock()
 
-	return ws.newBaseChannel(channel, payload, msgCh.(chan *UpdateMsg), op)
+	v, ok := ws.conf.subscribeMsg.Load(channel)
+	if !ok {
+		return true
+	}
+	var kept []requestHistory
+	for _, r := range v.([]requestHistory) {
+		switch {
+		case equalStrings(r.Payload, payload):
+			continue
+		case containsAll(r.Payload, payload):
+			r.Payload = removeStrings(r.Payload, payload)
+			if len(r.Payload) == 0 {
+				continue
+			}
+		}
+		kept = append(kept, r)
+	}
+	if len(kept) == 0 {
+		ws.conf.subscribeMsg.Delete(channel)
+		return true
+	}
+	ws.conf.subscribeMsg.Store(channel, kept)
+	return false
 }
 
 /*------------------ 内部核心 ------------------*/
@@ -94,12 +160,14 @@ func (ws *WsService) baseSubscribe(event, channel string, payload []string, op *
 		return nil
 	}
 
-	// ★ 仅非重连场景写历史
-	if op == nil || !op.IsReConnect {
+	// ★ 仅非重连场景写历史，取消订阅由 Unsubscribe 自行维护
+	if event == Subscribe && (op == nil || !op.IsReConnect) {
+		ws.subMu.Lock()
 		v, _ := ws.conf.subscribeMsg.LoadOrStore(channel, []requestHistory{})
 		h := v.([]requestHistory)
 		h = append(h, requestHistory{Channel: channel, Event: event, Payload: payload, op: op})
 		ws.conf.subscribeMsg.Store(channel, h)
+		ws.subMu.Unlock()
 	}
 
 	return nil
@@ -146,16 +214,27 @@ func (ws *WsService) readMsg() {
 					zap.S().Warnf("反序列化失败：%v, 原始:%s", err, string(message))
 					continue
 				}
-				if raw.Channel == "" {
+
+				// 带 id 的回包优先交给等待中的请求
+				if raw.Id != nil {
+					if w, ok := ws.pending.LoadAndDelete(*raw.Id); ok {
+						w.(chan *UpdateMsg) <- &raw
+						continue
+					}
+				}
+				if raw.Channel == "" { // api 回包没有顶层 channel
+					ws.dispatchApi(message)
 					continue
 				}
 
 				// 分发
-				if ch, ok := ws.msgChs.Load(raw.Channel); ok {
+				if v, ok := ws.msgChs.Load(raw.Channel); ok {
+					q := v.(*channelQueue)
 					select {
 					case <-ws.Ctx.Done():
 						return
-					case ch.(chan *UpdateMsg) <- &raw:
+					case <-q.done: // 已取消订阅
+					case q.ch <- &raw:
 					}
 				}
 			}
@@ -167,13 +246,19 @@ func (ws *WsService) readMsg() {
 
 type callBack func(*UpdateMsg)
 
+// channelQueue 单个 channel 的分发队列，done 关闭后分发协程退出
+type channelQueue struct {
+	ch   chan *UpdateMsg
+	done chan struct{}
+}
+
 func (ws *WsService) SetCallBack(channel string, call callBack) {
 	if call != nil {
 		ws.calls.Store(channel, call)
 	}
 }
 
-func (ws *WsService) receiveCallMsg(channel string, msgCh chan *UpdateMsg) {
+func (ws *WsService) receiveCallMsg(channel string, q *channelQueue) {
 	defer func() {
 		if e := recover(); e != nil {
 			zap.S().Error(e, string(debug.Stack()))
@@ -183,7 +268,9 @@ func (ws *WsService) receiveCallMsg(channel string, msgCh chan *UpdateMsg) {
 		select {
 		case <-ws.Ctx.Done():
 			return
-		case msg := <-msgCh:
+		case <-q.done:
+			return
+		case msg := <-q.ch:
 			if cb, ok := ws.calls.Load(channel); ok {
 				cb.(callBack)(msg)
 			}
@@ -197,3 +284,43 @@ func (ws *WsService) receiveCallMsg(channel string, msgCh chan *UpdateMsg) {
 func (ws *WsService) refreshDeadline() {
 	_ = ws.Client.SetReadDeadline(time.Now().Add(ws.conf.PingInterval * 4))
 }
+
+/*------------------ 工具 ------------------*/
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAll(set, sub []string) bool {
+	for _, s := range sub {
+		found := false
+		for _, v := range set {
+			if v == s {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func removeStrings(src, rm []string) []string {
+	var out []string
+	for _, s := range src {
+		if !containsAll(rm, []string{s}) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
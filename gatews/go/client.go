@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -22,6 +23,7 @@ const (
 	defaultHandshakeTimout = 30 * time.Second // ★ 延长
 	defaultNetTimeout      = 30 * time.Second // ★ 延长
 	wsWriteWait            = 5 * time.Second  // 写超时
+	defaultRequestTimeout  = 5 * time.Second  // 等待服务端回包
 )
 
 /*------------------ 核心结构 ------------------*/
@@ -33,8 +35,14 @@ type WsService struct {
 	Client        *websocket.Conn
 	reconnectCall chan struct{}
 	once          sync.Once
-	msgChs        *sync.Map
+	msgChs        *sync.Map // channel -> *channelQueue
 	calls         *sync.Map
+	pending       *sync.Map    // 请求 id -> chan *UpdateMsg，等待服务端回包
+	apiCalls      *sync.Map    // api req_id -> chan *ApiResponse
+	apiLogins     sync.Map     // 已登录的 api 通道，重连后重新登录
+	errCall       atomic.Value // errCallBack，订阅错误回调
+	reqId         int64
+	subMu         sync.Mutex // 保护 conf.subscribeMsg 的读改写
 	conf          *ConnConf
 }
 
@@ -78,6 +86,9 @@ func NewWsService(parent context.Context, conf *ConnConf, reconnect chan struct{
 		conf:          conf,
 		calls:         new(sync.Map),
 		msgChs:        new(sync.Map),
+		pending:       new(sync.Map),
+		apiCalls:      new(sync.Map),
+		reqId:         time.Now().UnixNano(),
 	}
 
 	// watchdog & ping
@@ -163,6 +174,7 @@ func (ws *WsService) reconnect() error {
 		}
 
 		ws.resubscribe()
+		go ws.relogin()
 		go ws.activePing()
 		return nil
 	}
@@ -200,12 +212,14 @@ func (ws *WsService) activePing() {
 			now := time.Now().Unix()
 			spot := fmt.Sprintf(`{"time":%d,"channel":"spot.ping"}`, now)
 			future := fmt.Sprintf(`{"time":%d,"channel":"futures.ping"}`, now)
+			options := fmt.Sprintf(`{"time":%d,"channel":"options.ping"}`, now)
 
 			if err := ws.safeWrite(websocket.TextMessage, []byte(spot)); err != nil {
 				zap.S().Warnf("Ping 写入失败: %v", err)
 				continue
 			}
 			_ = ws.safeWrite(websocket.TextMessage, []byte(future))
+			_ = ws.safeWrite(websocket.TextMessage, []byte(options))
 		}
 	}
 }
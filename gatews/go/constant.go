@@ -5,6 +5,13 @@ const (
 	FuturesBtcUrl  = "wss://fx-ws.gateio.ws/v4/ws/btc"
 	FuturesUsdtUrl = "wss://fx-ws.gateio.ws/v4/ws/usdt"
 
+	// 交割合约与永续共用 futures.* 频道
+	DeliveryUsdtUrl = "wss://fx-ws.gateio.ws/v4/ws/delivery/usdt"
+	DeliveryBtcUrl  = "wss://fx-ws.gateio.ws/v4/ws/delivery/btc"
+
+	OptionsUrl     = "wss://op-ws.gateio.live/v4/ws"
+	OptionsTestUrl = "wss://op-ws-testnet.gateio.live/v4/ws"
+
 	AuthMethodApiKey = "api_key"
 	MaxRetryConn     = 10
 )
@@ -44,6 +51,15 @@ const (
 	ChannelFutureAutoOrders       = "futures.autoorders"
 )
 
+// options channels
+const (
+	ChannelOptionsContractTicker = "options.contract_tickers"
+	ChannelOptionsUlTicker       = "options.ul_tickers"
+	ChannelOptionsOrderBook      = "options.order_book"
+	ChannelOptionsOrder          = "options.orders"
+	ChannelOptionsPositions      = "options.positions"
+)
+
 var (
 	authChannel = map[string]bool{
 		// spot
@@ -63,6 +79,10 @@ var (
 		ChannelFuturePositions:        true,
 		ChannelFutureAutoOrders:       true,
 		ChannelFutureBalance:          true,
+
+		// options
+		ChannelOptionsOrder:     true,
+		ChannelOptionsPositions: true,
 	}
 )
 
@@ -0,0 +1,468 @@
+package gatews
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	bookUpdateInterval = "100ms"
+	bookSnapshotLimit  = 100  // REST 快照档数，也是合约增量订阅的档数
+	bookPendingLimit   = 4096 // 等待快照期间最多缓存的增量条数
+	bookRetryInterval  = time.Second
+)
+
+type BookLevel struct {
+	Price float64
+	Size  float64
+}
+
+// bookUpdate 现货、合约增量统一后的格式
+type bookUpdate struct {
+	first, last int64 // U / u
+	ts          int64
+	bids, asks  []BookLevel
+}
+
+/*------------------ 单个交易对 ------------------*/
+
+// OrderBook 本地维护的深度，所有导出方法并发安全
+type OrderBook struct {
+	mu       sync.RWMutex
+	pair     string
+	bids     []BookLevel // 价格降序
+	asks     []BookLevel // 价格升序
+	lastId   int64       // 最近应用的 u
+	ts       int64       // ms
+	valid    bool
+	fetching bool         // 正在拉取快照
+	pending  []bookUpdate // 快照就绪前缓存的增量
+	removed  bool
+}
+
+func (b *OrderBook) Pair() string { return b.pair }
+
+// IsValid 快照已就绪且增量连续
+func (b *OrderBook) IsValid() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.valid
+}
+
+// LastId 最近应用的更新 id
+func (b *OrderBook) LastId() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.lastId
+}
+
+// Ts 最近一次更新的时间(ms)
+func (b *OrderBook) Ts() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.ts
+}
+
+func (b *OrderBook) BestBid() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid || len(b.bids) == 0 {
+		return BookLevel{}, false
+	}
+	return b.bids[0], true
+}
+
+func (b *OrderBook) BestAsk() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid || len(b.asks) == 0 {
+		return BookLevel{}, false
+	}
+	return b.asks[0], true
+}
+
+// Depth 返回前 n 档的拷贝，n <= 0 返回全部
+func (b *OrderBook) Depth(n int) (bids, asks []BookLevel) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid {
+		return nil, nil
+	}
+	return topLevels(b.bids, n), topLevels(b.asks, n)
+}
+
+func topLevels(src []BookLevel, n int) []BookLevel {
+	if n <= 0 || n > len(src) {
+		n = len(src)
+	}
+	dst := make([]BookLevel, n)
+	copy(dst, src[:n])
+	return dst
+}
+
+// apply 调用方持锁；u <= lastId 为旧增量直接跳过，U > lastId+1 表示断档
+func (b *OrderBook) apply(u *bookUpdate) (applied bool, err error) {
+	if u.last <= b.lastId {
+		return false, nil
+	}
+	if u.first > b.lastId+1 {
+		return false, fmt.Errorf("book %s gap: last=%d next U=%d", b.pair, b.lastId, u.first)
+	}
+	for _, l := range u.bids {
+		b.bids = applyLevel(b.bids, l, true)
+	}
+	for _, l := range u.asks {
+		b.asks = applyLevel(b.asks, l, false)
+	}
+	b.lastId, b.ts = u.last, u.ts
+	return true, nil
+}
+
+// applyLevel 插入/替换/删除(size=0) 一档，desc 表示价格降序
+func applyLevel(levels []BookLevel, l BookLevel, desc bool) []BookLevel {
+	i := sort.Search(len(levels), func(i int) bool {
+		if desc {
+			return levels[i].Price <= l.Price
+		}
+		return levels[i].Price >= l.Price
+	})
+	found := i < len(levels) && levels[i].Price == l.Price
+	switch {
+	case l.Size == 0:
+		if found {
+			levels = append(levels[:i], levels[i+1:]...)
+		}
+	case found:
+		levels[i] = l
+	default:
+		levels = append(levels, BookLevel{})
+		copy(levels[i+1:], levels[i:])
+		levels[i] = l
+	}
+	return levels
+}
+
+/*------------------ 管理器 ------------------*/
+
+// OrderBookManager 基于 order_book_update 增量维护本地深度：
+// 先缓存增量，再拉取 with_id=true 的 REST 快照，丢弃 u <= id 的增量后按 U/u 连续应用；
+// 断档（含断线重连）时作废本地深度并重新拉取快照
+type OrderBookManager struct {
+	ws       *WsService
+	rest     *RestClient
+	channel  string
+	settle   string // 合约结算币种，现货为空
+	mu       sync.RWMutex
+	books    map[string]*OrderBook
+	onUpdate func(book *OrderBook)
+}
+
+// NewSpotOrderBookManager 同一个 WsService 只应创建一个现货深度管理器；
+// onUpdate 在快照就绪及每次增量应用后回调
+func NewSpotOrderBookManager(ws *WsService, rest *RestClient, onUpdate func(*OrderBook)) *OrderBookManager {
+	return newOrderBookManager(ws, rest, ChannelSpotOrderBookUpdate, "", onUpdate)
+}
+
+// NewFuturesOrderBookManager settle: SettleUsdt / SettleBtc，ws 须连接到对应结算币种的地址
+func NewFuturesOrderBookManager(ws *WsService, rest *RestClient, settle string, onUpdate func(*OrderBook)) *OrderBookManager {
+	return newOrderBookManager(ws, rest, ChannelFutureOrderBookUpdate, settle, onUpdate)
+}
+
+func newOrderBookManager(ws *WsService, rest *RestClient, channel, settle string, onUpdate func(*OrderBook)) *OrderBookManager {
+	m := &OrderBookManager{
+		ws:       ws,
+		rest:     rest,
+		channel:  channel,
+		settle:   settle,
+		books:    make(map[string]*OrderBook),
+		onUpdate: onUpdate,
+	}
+	ws.SetCallBack(channel, m.handle)
+	return m
+}
+
+// Subscribe 订阅交易对（现货如 BTC_USDT，合约如 BTC_USDT）
+func (m *OrderBookManager) Subscribe(pairs ...string) error {
+	for _, p := range pairs {
+		m.mu.Lock()
+		_, ok := m.books[p]
+		if !ok {
+			m.books[p] = &OrderBook{pair: p}
+		}
+		m.mu.Unlock()
+		if ok {
+			continue
+		}
+		if err := m.ws.Subscribe(m.channel, m.payload(p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unsubscribe 取消订阅并丢弃本地深度
+func (m *OrderBookManager) Unsubscribe(pairs ...string) error {
+	for _, p := range pairs {
+		m.mu.Lock()
+		book, ok := m.books[p]
+		delete(m.books, p)
+		m.mu.Unlock()
+		if !ok {
+			continue
+		}
+		book.mu.Lock()
+		book.removed, book.valid = true, false
+		book.mu.Unlock()
+		if _, err := m.ws.Unsubscribe(m.channel, m.payload(p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *OrderBookManager) payload(pair string) []string {
+	if m.settle != "" {
+		return []string{pair, bookUpdateInterval, strconv.Itoa(bookSnapshotLimit)}
+	}
+	return []string{pair, bookUpdateInterval}
+}
+
+// Book 返回交易对的本地深度，未订阅返回 nil
+func (m *OrderBookManager) Book(pair string) *OrderBook {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.books[pair]
+}
+
+func (m *OrderBookManager) BestBid(pair string) (BookLevel, bool) {
+	if b := m.Book(pair); b != nil {
+		return b.BestBid()
+	}
+	return BookLevel{}, false
+}
+
+func (m *OrderBookManager) BestAsk(pair string) (BookLevel, bool) {
+	if b := m.Book(pair); b != nil {
+		return b.BestAsk()
+	}
+	return BookLevel{}, false
+}
+
+func (m *OrderBookManager) Depth(pair string, n int) (bids, asks []BookLevel) {
+	if b := m.Book(pair); b != nil {
+		return b.Depth(n)
+	}
+	return nil, nil
+}
+
+/*------------------ 增量处理 ------------------*/
+
+func (m *OrderBookManager) handle(msg *UpdateMsg) {
+	if msg.Event != "update" {
+		if msg.Error != nil {
+			zap.S().Errorf("[gate][book] %s %s: %v", msg.Channel, msg.Event, msg.Error)
+		}
+		return
+	}
+	pair, u, err := m.decode(msg.Result)
+	if err != nil {
+		zap.S().Warnf("[gate][book] decode: %v", err)
+		return
+	}
+	book := m.Book(pair)
+	if book == nil {
+		return
+	}
+
+	book.mu.Lock()
+	if !book.valid {
+		if len(book.pending) < bookPendingLimit {
+			book.pending = append(book.pending, *u)
+		}
+		m.startFetchLocked(book)
+		book.mu.Unlock()
+		return
+	}
+	applied, err := book.apply(u)
+	if err != nil {
+		zap.S().Warnf("[gate][book] %v, rebuild", err)
+		book.valid = false
+		book.pending = append(book.pending[:0], *u)
+		m.startFetchLocked(book)
+	}
+	book.mu.Unlock()
+
+	if applied && m.onUpdate != nil {
+		m.onUpdate(book)
+	}
+}
+
+func (m *OrderBookManager) decode(result json.RawMessage) (string, *bookUpdate, error) {
+	if m.settle != "" {
+		var d FuturesOrderBookUpdate
+		if err := json.Unmarshal(result, &d); err != nil {
+			return "", nil, err
+		}
+		u := &bookUpdate{first: d.FirstId, last: d.LastId, ts: d.TimeMillis}
+		u.bids = futuresLevels(d.Bids)
+		u.asks = futuresLevels(d.Asks)
+		return d.Contract, u, nil
+	}
+
+	var d SpotUpdateDepthMsg
+	if err := json.Unmarshal(result, &d); err != nil {
+		return "", nil, err
+	}
+	u := &bookUpdate{first: d.FirstId, last: d.LastId, ts: d.TimeInMilli}
+	var err error
+	if u.bids, err = spotLevels(d.Bid); err != nil {
+		return "", nil, err
+	}
+	if u.asks, err = spotLevels(d.Ask); err != nil {
+		return "", nil, err
+	}
+	return d.CurrencyPair, u, nil
+}
+
+func spotLevels(src [][]string) ([]BookLevel, error) {
+	levels := make([]BookLevel, 0, len(src))
+	for _, a := range src {
+		if len(a) < 2 {
+			continue
+		}
+		p, err := strconv.ParseFloat(a[0], 64)
+		if err != nil {
+			return nil, err
+		}
+		s, err := strconv.ParseFloat(a[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, BookLevel{p, s})
+	}
+	return levels, nil
+}
+
+func futuresLevels(src []FuturesOrderBookItem) []BookLevel {
+	levels := make([]BookLevel, 0, len(src))
+	for _, it := range src {
+		p, err := strconv.ParseFloat(it.P, 64)
+		if err != nil {
+			continue
+		}
+		levels = append(levels, BookLevel{p, float64(it.S)})
+	}
+	return levels
+}
+
+/*------------------ 快照 ------------------*/
+
+// startFetchLocked 调用方持有 book.mu
+func (m *OrderBookManager) startFetchLocked(book *OrderBook) {
+	if book.fetching || book.removed {
+		return
+	}
+	book.fetching = true
+	go m.rebuild(book)
+}
+
+// rebuild 拉取快照并衔接缓存的增量，快照早于缓存首条增量时稍后重试
+func (m *OrderBookManager) rebuild(book *OrderBook) {
+	for {
+		bids, asks, id, err := m.snapshot(book.pair)
+		if err == nil {
+			book.mu.Lock()
+			err = m.resetLocked(book, bids, asks, id)
+			if err == nil || book.removed {
+				book.fetching = false
+				book.mu.Unlock()
+				if err == nil && m.onUpdate != nil {
+					m.onUpdate(book)
+				}
+				return
+			}
+			book.mu.Unlock()
+		}
+		zap.S().Warnf("[gate][book][%s] snapshot: %v, retry in %v", book.pair, err, bookRetryInterval)
+
+		select {
+		case <-m.ws.Ctx.Done():
+			return
+		case <-time.After(bookRetryInterval):
+		}
+		book.mu.RLock()
+		removed := book.removed
+		book.mu.RUnlock()
+		if removed {
+			return
+		}
+	}
+}
+
+func (m *OrderBookManager) snapshot(pair string) (bids, asks []BookLevel, id int64, err error) {
+	ctx, cancel := context.WithTimeout(m.ws.Ctx, defaultRestTimeout)
+	defer cancel()
+
+	if m.settle != "" {
+		var s FuturesOrderBookSnapshot
+		if s, err = m.rest.GetFuturesOrderBook(ctx, m.settle, pair, bookSnapshotLimit); err != nil {
+			return
+		}
+		return futuresLevels(s.Bids), futuresLevels(s.Asks), s.Id, nil
+	}
+
+	var s SpotOrderBookSnapshot
+	if s, err = m.rest.GetSpotOrderBook(ctx, pair, bookSnapshotLimit); err != nil {
+		return
+	}
+	if bids, err = spotLevels(pairsToSlices(s.Bids)); err != nil {
+		return
+	}
+	asks, err = spotLevels(pairsToSlices(s.Asks))
+	return bids, asks, s.Id, err
+}
+
+func pairsToSlices(src [][2]string) [][]string {
+	dst := make([][]string, len(src))
+	for i := range src {
+		dst[i] = src[i][:]
+	}
+	return dst
+}
+
+// resetLocked 用快照覆盖本地深度并应用缓存增量；缓存中出现断档返回错误
+func (m *OrderBookManager) resetLocked(book *OrderBook, bids, asks []BookLevel, id int64) error {
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+	book.bids, book.asks = removeEmpty(bids), removeEmpty(asks)
+	book.lastId = id
+
+	pending := book.pending
+	book.pending = nil
+	for i := range pending {
+		if _, err := book.apply(&pending[i]); err != nil {
+			// 保留未应用的增量，下一次快照可能可以衔接
+			book.pending = append(book.pending, pending[i:]...)
+			return err
+		}
+	}
+	book.valid = true
+	return nil
+}
+
+func removeEmpty(levels []BookLevel) []BookLevel {
+	out := levels[:0]
+	for _, l := range levels {
+		if l.Size != 0 {
+			out = append(out, l)
+		}
+	}
+	return out
+}
@@ -0,0 +1,148 @@
+package gatews
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOrderBook_apply(t *testing.T) {
+	tests := []struct {
+		name        string
+		first, last int64
+		wantApplied bool
+		wantErr     bool
+		wantLastId  int64
+	}{
+		{name: "stale", first: 95, last: 100, wantLastId: 100},
+		{name: "bridging snapshot id", first: 95, last: 102, wantApplied: true, wantLastId: 102},
+		{name: "contiguous", first: 101, last: 101, wantApplied: true, wantLastId: 101},
+		{name: "gap", first: 103, last: 104, wantErr: true, wantLastId: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &OrderBook{pair: "BTC_USDT", lastId: 100, valid: true}
+			applied, err := b.apply(&bookUpdate{first: tt.first, last: tt.last, bids: []BookLevel{{30000, 1}}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if applied != tt.wantApplied {
+				t.Errorf("apply() applied = %v, want %v", applied, tt.wantApplied)
+			}
+			if b.lastId != tt.wantLastId {
+				t.Errorf("lastId = %d, want %d", b.lastId, tt.wantLastId)
+			}
+		})
+	}
+}
+
+// newTestBookManager 现货深度管理器，快照由 snapshots 依次返回，每次请求需向 release 发送一次才应答
+func newTestBookManager(t *testing.T, release chan struct{}, snapshots ...string) (*OrderBookManager, chan int64, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/spot/order_book" || r.URL.Query().Get("with_id") != "true" {
+			http.NotFound(w, r)
+			return
+		}
+		<-release
+		n := atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, snapshots[int(n)-1])
+	}))
+	t.Cleanup(server.Close)
+
+	rest, err := NewRestClient(&RestConf{URL: server.URL + "/api/v4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	updated := make(chan int64, 8)
+	m := &OrderBookManager{
+		ws:       &WsService{Ctx: ctx},
+		rest:     rest,
+		channel:  ChannelSpotOrderBookUpdate,
+		books:    map[string]*OrderBook{"BTC_USDT": {pair: "BTC_USDT"}},
+		onUpdate: func(b *OrderBook) { updated <- b.LastId() },
+	}
+	return m, updated, &requests
+}
+
+func spotUpdate(first, last int64, bids string) *UpdateMsg {
+	return &UpdateMsg{
+		Channel: ChannelSpotOrderBookUpdate,
+		Event:   "update",
+		Result:  []byte(fmt.Sprintf(`{"t":1,"s":"BTC_USDT","U":%d,"u":%d,"b":%s,"a":[]}`, first, last, bids)),
+	}
+}
+
+func waitUpdate(t *testing.T, updated chan int64, want int64) {
+	t.Helper()
+	select {
+	case got := <-updated:
+		if got != want {
+			t.Fatalf("onUpdate lastId = %d, want %d", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("onUpdate lastId=%d not called", want)
+	}
+}
+
+func bidPrices(b *OrderBook) []float64 {
+	bids, _ := b.Depth(0)
+	var px []float64
+	for _, l := range bids {
+		px = append(px, l.Price)
+	}
+	return px
+}
+
+func TestOrderBookManager_snapshotDeltaGap(t *testing.T) {
+	release := make(chan struct{})
+	m, updated, requests := newTestBookManager(t, release,
+		`{"id":100,"bids":[["30000","1"],["29999","2"]],"asks":[["30001","1"]]}`,
+		`{"id":109,"bids":[["30000","1"]],"asks":[["30001","1"]]}`,
+	)
+	book := m.Book("BTC_USDT")
+
+	// 快照前的增量先缓存：u <= id 的丢弃，U <= id+1 <= u 的衔接快照
+	m.handle(spotUpdate(98, 99, `[["29998","1"]]`))
+	m.handle(spotUpdate(100, 102, `[["30000.5","3"]]`))
+	if book.IsValid() {
+		t.Fatal("book valid before snapshot")
+	}
+	release <- struct{}{}
+	waitUpdate(t, updated, 102)
+	if got, want := bidPrices(book), []float64{30000.5, 30000, 29999}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bids after snapshot = %v, want %v", got, want)
+	}
+
+	// 连续增量
+	m.handle(spotUpdate(103, 103, `[["29999","0"]]`))
+	waitUpdate(t, updated, 103)
+	if got, want := bidPrices(book), []float64{30000.5, 30000}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bids after delta = %v, want %v", got, want)
+	}
+
+	// 断档后重新拉取快照，断档的增量由新快照衔接
+	m.handle(spotUpdate(110, 111, `[["30000.7","1"]]`))
+	if book.IsValid() {
+		t.Error("book still valid after gap")
+	}
+	if _, ok := m.BestBid("BTC_USDT"); ok {
+		t.Error("BestBid() ok on invalid book")
+	}
+	release <- struct{}{}
+	waitUpdate(t, updated, 111)
+	if got, want := bidPrices(book), []float64{30000.7, 30000}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bids after rebuild = %v, want %v", got, want)
+	}
+	if n := atomic.LoadInt32(requests); n != 2 {
+		t.Errorf("snapshot requests = %d, want 2", n)
+	}
+}
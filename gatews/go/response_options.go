@@ -0,0 +1,83 @@
+package gatews
+
+// OptionsContractTicker options.contract_tickers 推送
+type OptionsContractTicker struct {
+	Name         string `json:"name"`
+	LastPrice    string `json:"last_price"`
+	MarkPrice    string `json:"mark_price"`
+	IndexPrice   string `json:"index_price"`
+	PositionSize int64  `json:"position_size"`
+	Bid1Price    string `json:"bid1_price"`
+	Bid1Size     int64  `json:"bid1_size"`
+	Ask1Price    string `json:"ask1_price"`
+	Ask1Size     int64  `json:"ask1_size"`
+	Vega         string `json:"vega"`
+	Theta        string `json:"theta"`
+	Rho          string `json:"rho"`
+	Gamma        string `json:"gamma"`
+	Delta        string `json:"delta"`
+	MarkIv       string `json:"mark_iv"`
+	BidIv        string `json:"bid_iv"`
+	AskIv        string `json:"ask_iv"`
+	Leverage     string `json:"leverage"`
+}
+
+// OptionsUlTicker options.ul_tickers 标的推送
+type OptionsUlTicker struct {
+	Name       string `json:"name"`
+	IndexPrice string `json:"index_price"`
+	TradePut   int64  `json:"trade_put"`
+	TradeCall  int64  `json:"trade_call"`
+}
+
+type OptionsOrderBookItem struct {
+	P string `json:"p"`
+	S int64  `json:"s"`
+}
+
+// OptionsOrderBook options.order_book 有限档位全量深度
+type OptionsOrderBook struct {
+	TimeMillis int64                  `json:"t"`
+	Contract   string                 `json:"contract"`
+	Id         int64                  `json:"id"`
+	Asks       []OptionsOrderBookItem `json:"asks"`
+	Bids       []OptionsOrderBookItem `json:"bids"`
+}
+
+// OptionsOrder options.orders 推送，Size 正数买入、负数卖出
+type OptionsOrder struct {
+	Id           int64   `json:"id"`
+	User         int64   `json:"user"`
+	Contract     string  `json:"contract"`
+	Underlying   string  `json:"underlying"`
+	CreateTime   int64   `json:"create_time"`
+	FinishAs     string  `json:"finish_as"`
+	Status       string  `json:"status"`
+	Size         int64   `json:"size"`
+	Iceberg      int64   `json:"iceberg"`
+	Left         int64   `json:"left"`
+	Price        float64 `json:"price"`
+	FillPrice    float64 `json:"fill_price"`
+	IsClose      bool    `json:"is_close"`
+	IsLiq        bool    `json:"is_liq"`
+	IsReduceOnly bool    `json:"is_reduce_only"`
+	Tif          string  `json:"tif"`
+	Text         string  `json:"text"`
+	Tkfr         float64 `json:"tkfr"`
+	Mkfr         float64 `json:"mkfr"`
+	Refu         int64   `json:"refu"`
+	Refr         float64 `json:"refr"`
+	Time         int64   `json:"time"`
+	TimeMs       int64   `json:"time_ms"`
+}
+
+// OptionsPosition options.positions 推送
+type OptionsPosition struct {
+	Contract    string  `json:"contract"`
+	User        int64   `json:"user"`
+	Size        int64   `json:"size"`
+	EntryPrice  float64 `json:"entry_price"`
+	RealisedPnl float64 `json:"realised_pnl"`
+	Time        int64   `json:"time"`
+	TimeMs      int64   `json:"time_ms"`
+}
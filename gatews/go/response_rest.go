@@ -0,0 +1,181 @@
+package gatews
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// REST 接口独有的模型；行情、现货订单等与推送字段一致的直接复用
+// response_spot.go / response_future.go 中的结构
+
+/*------------------ 现货 ------------------*/
+
+type SpotCurrencyPair struct {
+	Id              string `json:"id"`
+	Base            string `json:"base"`
+	Quote           string `json:"quote"`
+	Fee             string `json:"fee"`
+	MinBaseAmount   string `json:"min_base_amount"`
+	MinQuoteAmount  string `json:"min_quote_amount"`
+	MaxBaseAmount   string `json:"max_base_amount"`
+	MaxQuoteAmount  string `json:"max_quote_amount"`
+	AmountPrecision int32  `json:"amount_precision"`
+	Precision       int32  `json:"precision"`
+	TradeStatus     string `json:"trade_status"` // untradable / buyable / sellable / tradable
+	SellStart       int64  `json:"sell_start"`
+	BuyStart        int64  `json:"buy_start"`
+}
+
+type SpotAccount struct {
+	Currency  string `json:"currency"`
+	Available string `json:"available"`
+	Locked    string `json:"locked"`
+	UpdateId  int64  `json:"update_id"`
+}
+
+// SpotOrderBookSnapshot with_id=true 时 Id 为深度更新 id，用于衔接 spot.order_book_update
+type SpotOrderBookSnapshot struct {
+	Id      int64       `json:"id"`
+	Current int64       `json:"current"` // ms
+	Update  int64       `json:"update"`  // ms
+	Asks    [][2]string `json:"asks"`
+	Bids    [][2]string `json:"bids"`
+}
+
+// spotCandle 现货 K 线返回数组：
+// [时间(s), 计价货币成交额, 收盘价, 最高价, 最低价, 开盘价, 基础货币成交量, 是否结束]
+type spotCandle SpotCandleUpdateMsg
+
+func (c *spotCandle) UnmarshalJSON(b []byte) error {
+	var a []string
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	if len(a) < 7 {
+		return fmt.Errorf("invalid candlestick: %s", string(b))
+	}
+	c.Time, c.Volume, c.Close, c.High, c.Low, c.Open, c.Amount = a[0], a[1], a[2], a[3], a[4], a[5], a[6]
+	return nil
+}
+
+/*------------------ 合约 ------------------*/
+
+type FuturesContract struct {
+	Name              string `json:"name"`
+	Type              string `json:"type"` // inverse / direct
+	QuantoMultiplier  string `json:"quanto_multiplier"`
+	LeverageMin       string `json:"leverage_min"`
+	LeverageMax       string `json:"leverage_max"`
+	MaintenanceRate   string `json:"maintenance_rate"`
+	MarkType          string `json:"mark_type"`
+	MarkPrice         string `json:"mark_price"`
+	IndexPrice        string `json:"index_price"`
+	LastPrice         string `json:"last_price"`
+	MakerFeeRate      string `json:"maker_fee_rate"`
+	TakerFeeRate      string `json:"taker_fee_rate"`
+	OrderPriceRound   string `json:"order_price_round"`
+	MarkPriceRound    string `json:"mark_price_round"`
+	FundingRate       string `json:"funding_rate"`
+	FundingInterval   int32  `json:"funding_interval"`
+	FundingNextApply  int64  `json:"funding_next_apply"`
+	RiskLimitBase     string `json:"risk_limit_base"`
+	RiskLimitStep     string `json:"risk_limit_step"`
+	RiskLimitMax      string `json:"risk_limit_max"`
+	OrderSizeMin      int64  `json:"order_size_min"`
+	OrderSizeMax      int64  `json:"order_size_max"`
+	OrderPriceDeviate string `json:"order_price_deviate"`
+	OrderbookId       int64  `json:"orderbook_id"`
+	TradeId           int64  `json:"trade_id"`
+	TradeSize         int64  `json:"trade_size"`
+	PositionSize      int64  `json:"position_size"`
+	InDelisting       bool   `json:"in_delisting"`
+	OrdersLimit       int32  `json:"orders_limit"`
+}
+
+type FuturesAccount struct {
+	Total          string `json:"total"`
+	UnrealisedPnl  string `json:"unrealised_pnl"`
+	PositionMargin string `json:"position_margin"`
+	OrderMargin    string `json:"order_margin"`
+	Available      string `json:"available"`
+	Point          string `json:"point"`
+	Currency       string `json:"currency"`
+	InDualMode     bool   `json:"in_dual_mode"`
+	EnableCredit   bool   `json:"enable_credit"`
+	Bonus          string `json:"bonus"`
+}
+
+// FuturesPosition REST 持仓，数值均为字符串；推送见 FuturesPositions
+type FuturesPosition struct {
+	User               int64  `json:"user"`
+	Contract           string `json:"contract"`
+	Size               int64  `json:"size"`
+	Leverage           string `json:"leverage"` // 0 表示全仓
+	RiskLimit          string `json:"risk_limit"`
+	LeverageMax        string `json:"leverage_max"`
+	MaintenanceRate    string `json:"maintenance_rate"`
+	Value              string `json:"value"`
+	Margin             string `json:"margin"`
+	EntryPrice         string `json:"entry_price"`
+	LiqPrice           string `json:"liq_price"`
+	MarkPrice          string `json:"mark_price"`
+	UnrealisedPnl      string `json:"unrealised_pnl"`
+	RealisedPnl        string `json:"realised_pnl"`
+	HistoryPnl         string `json:"history_pnl"`
+	LastClosePnl       string `json:"last_close_pnl"`
+	RealisedPoint      string `json:"realised_point"`
+	HistoryPoint       string `json:"history_point"`
+	AdlRanking         int32  `json:"adl_ranking"`
+	PendingOrders      int32  `json:"pending_orders"`
+	Mode               string `json:"mode"` // single / dual_long / dual_short
+	CrossLeverageLimit string `json:"cross_leverage_limit"`
+	UpdateTime         int64  `json:"update_time"`
+}
+
+// FuturesOrderBookSnapshot with_id=true 时 Id 为深度更新 id，用于衔接 futures.order_book_update
+type FuturesOrderBookSnapshot struct {
+	Id      int64                  `json:"id"`
+	Current float64                `json:"current"` // s
+	Update  float64                `json:"update"`  // s
+	Asks    []FuturesOrderBookItem `json:"asks"`
+	Bids    []FuturesOrderBookItem `json:"bids"`
+}
+
+// FuturesOrderParam 合约下单参数
+// Size 正数买入、负数卖出；Price 为 "0" 且 Tif=ioc 表示市价
+type FuturesOrderParam struct {
+	Contract   string `json:"contract"`
+	Size       int64  `json:"size"`
+	Iceberg    int64  `json:"iceberg,omitempty"`
+	Price      string `json:"price"`
+	Close      bool   `json:"close,omitempty"`
+	ReduceOnly bool   `json:"reduce_only,omitempty"`
+	Tif        string `json:"tif,omitempty"` // gtc / ioc / poc / fok
+	Text       string `json:"text,omitempty"`
+	AutoSize   string `json:"auto_size,omitempty"` // 双仓平仓：close_long / close_short
+}
+
+// FuturesOrderDetail REST 合约订单，价格字段为字符串；推送见 FuturesOrder
+type FuturesOrderDetail struct {
+	Id           int64   `json:"id"`
+	User         int64   `json:"user"`
+	CreateTime   float64 `json:"create_time"`
+	FinishTime   float64 `json:"finish_time"`
+	FinishAs     string  `json:"finish_as"`
+	Status       string  `json:"status"`
+	Contract     string  `json:"contract"`
+	Size         int64   `json:"size"`
+	Iceberg      int64   `json:"iceberg"`
+	Price        string  `json:"price"`
+	IsClose      bool    `json:"is_close"`
+	IsReduceOnly bool    `json:"is_reduce_only"`
+	IsLiq        bool    `json:"is_liq"`
+	Tif          string  `json:"tif"`
+	Left         int64   `json:"left"`
+	FillPrice    string  `json:"fill_price"`
+	Text         string  `json:"text"`
+	Tkfr         string  `json:"tkfr"`
+	Mkfr         string  `json:"mkfr"`
+	Refu         int64   `json:"refu"`
+	AutoSize     string  `json:"auto_size"`
+}
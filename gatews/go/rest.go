@@ -0,0 +1,156 @@
+package gatews
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	RestBaseUrl     = "https://api.gateio.ws/api/v4"
+	RestTestBaseUrl = "https://fx-api-testnet.gateio.ws/api/v4"
+
+	SettleUsdt = "usdt"
+	SettleBtc  = "btc"
+
+	defaultRestTimeout = 10 * time.Second
+)
+
+/*------------------ 错误 ------------------*/
+
+// RestError REST 接口非 2xx 返回，Label 如 INVALID_PARAM_VALUE / ORDER_NOT_FOUND
+type RestError struct {
+	StatusCode int    `json:"-"`
+	Label      string `json:"label"`
+	Message    string `json:"message"`
+}
+
+func (e *RestError) Error() string {
+	return fmt.Sprintf("gate rest %d %s: %s", e.StatusCode, e.Label, e.Message)
+}
+
+/*------------------ 客户端 ------------------*/
+
+// RestConf REST 配置，Key/Secret 为空时只能调用公共接口
+type RestConf struct {
+	URL        string
+	Key        string
+	Secret     string
+	Timeout    time.Duration
+	HttpClient *http.Client
+}
+
+type RestClient struct {
+	conf   *RestConf
+	base   *url.URL
+	client *http.Client
+}
+
+func NewRestClient(conf *RestConf) (*RestClient, error) {
+	if conf == nil {
+		conf = &RestConf{}
+	}
+	if conf.URL == "" {
+		conf.URL = RestBaseUrl
+	}
+	if conf.Timeout == 0 {
+		conf.Timeout = defaultRestTimeout
+	}
+	base, err := url.Parse(conf.URL)
+	if err != nil {
+		return nil, err
+	}
+	client := conf.HttpClient
+	if client == nil {
+		client = &http.Client{Timeout: conf.Timeout}
+	}
+	return &RestClient{conf: conf, base: base, client: client}, nil
+}
+
+// request 发送请求，signed 时按 restSign 签名
+func (c *RestClient) request(ctx context.Context, method, path string, query url.Values, body any, signed bool, result any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	u := *c.base
+	u.Path = c.base.Path + path
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	if signed {
+		if c.conf.Key == "" || c.conf.Secret == "" {
+			return newAuthEmptyErr()
+		}
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set("KEY", c.conf.Key)
+		req.Header.Set("Timestamp", ts)
+		req.Header.Set("SIGN", restSign(c.conf.Secret, method, u.Path, u.RawQuery, payload, ts))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		e := &RestError{StatusCode: resp.StatusCode}
+		if json.Unmarshal(data, e) != nil || e.Label == "" {
+			e.Message = string(data)
+		}
+		return e
+	}
+	if result == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
+
+// restSign v4 签名：SIGN = hex(HMAC_SHA512(secret, method\npath\nquery\nhex(SHA512(body))\ntimestamp))
+// path 含 /api/v4 前缀，timestamp 为秒
+func restSign(secret, method, path, query string, payload []byte, ts string) string {
+	bodyHash := sha512.Sum512(payload)
+	mac := hmac.New(sha512.New, []byte(secret))
+	mac.Write([]byte(method + "\n" + path + "\n" + query + "\n" + hex.EncodeToString(bodyHash[:]) + "\n" + ts))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *RestClient) publicGet(ctx context.Context, path string, query url.Values, result any) error {
+	return c.request(ctx, http.MethodGet, path, query, nil, false, result)
+}
+
+func (c *RestClient) signed(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	return c.request(ctx, method, path, query, body, true, result)
+}
+
+// setQuery 非零值才写入 query
+func setQuery[T comparable](q url.Values, k string, v T) {
+	var zero T
+	if v != zero {
+		q.Set(k, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,116 @@
+package gatews
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// 合约接口的 settle 为结算币种：SettleUsdt / SettleBtc
+
+/*------------------ 合约行情 ------------------*/
+
+func (c *RestClient) GetFuturesContracts(ctx context.Context, settle string) (contracts []FuturesContract, err error) {
+	err = c.publicGet(ctx, "/futures/"+settle+"/contracts", nil, &contracts)
+	return
+}
+
+func (c *RestClient) GetFuturesContract(ctx context.Context, settle, contract string) (result FuturesContract, err error) {
+	err = c.publicGet(ctx, "/futures/"+settle+"/contracts/"+contract, nil, &result)
+	return
+}
+
+// GetFuturesTickers contract 为空返回全部合约
+func (c *RestClient) GetFuturesTickers(ctx context.Context, settle, contract string) (tickers []FuturesTicker, err error) {
+	q := url.Values{}
+	setQuery(q, "contract", contract)
+	err = c.publicGet(ctx, "/futures/"+settle+"/tickers", q, &tickers)
+	return
+}
+
+// GetFuturesOrderBook limit 默认 10，最大 100；返回带 id 的快照
+func (c *RestClient) GetFuturesOrderBook(ctx context.Context, settle, contract string, limit int) (book FuturesOrderBookSnapshot, err error) {
+	q := url.Values{"contract": {contract}, "with_id": {"true"}}
+	setQuery(q, "limit", limit)
+	err = c.publicGet(ctx, "/futures/"+settle+"/order_book", q, &book)
+	return
+}
+
+// GetFuturesCandlesticks interval: 10s 1m 5m 15m 30m 1h 4h 8h 1d 7d
+// contract 加 mark_ / index_ 前缀可获取标记价格、指数价格 K 线
+func (c *RestClient) GetFuturesCandlesticks(ctx context.Context, settle, contract, interval string, from, to int64, limit int) (candles []FuturesCandlestick, err error) {
+	q := url.Values{"contract": {contract}}
+	setQuery(q, "interval", interval)
+	setQuery(q, "from", from)
+	setQuery(q, "to", to)
+	setQuery(q, "limit", limit)
+	if err = c.publicGet(ctx, "/futures/"+settle+"/candlesticks", q, &candles); err != nil {
+		return
+	}
+	for i := range candles {
+		candles[i].N = interval + "_" + contract
+	}
+	return
+}
+
+/*------------------ 合约账户 / 持仓 ------------------*/
+
+func (c *RestClient) GetFuturesAccount(ctx context.Context, settle string) (account FuturesAccount, err error) {
+	err = c.signed(ctx, http.MethodGet, "/futures/"+settle+"/accounts", nil, nil, &account)
+	return
+}
+
+func (c *RestClient) GetFuturesPositions(ctx context.Context, settle string) (positions []FuturesPosition, err error) {
+	err = c.signed(ctx, http.MethodGet, "/futures/"+settle+"/positions", nil, nil, &positions)
+	return
+}
+
+// GetFuturesPosition 单仓模式下的持仓
+func (c *RestClient) GetFuturesPosition(ctx context.Context, settle, contract string) (position FuturesPosition, err error) {
+	err = c.signed(ctx, http.MethodGet, "/futures/"+settle+"/positions/"+contract, nil, nil, &position)
+	return
+}
+
+// SetFuturesLeverage leverage 为 0 表示全仓，此时 crossLeverageLimit 为全仓杠杆上限
+func (c *RestClient) SetFuturesLeverage(ctx context.Context, settle, contract, leverage, crossLeverageLimit string) (position FuturesPosition, err error) {
+	q := url.Values{"leverage": {leverage}}
+	setQuery(q, "cross_leverage_limit", crossLeverageLimit)
+	err = c.signed(ctx, http.MethodPost, "/futures/"+settle+"/positions/"+contract+"/leverage", q, nil, &position)
+	return
+}
+
+/*------------------ 合约订单 ------------------*/
+
+func (c *RestClient) CreateFuturesOrder(ctx context.Context, settle string, order FuturesOrderParam) (result FuturesOrderDetail, err error) {
+	err = c.signed(ctx, http.MethodPost, "/futures/"+settle+"/orders", nil, order, &result)
+	return
+}
+
+// GetFuturesOrder orderId 可以是订单 id 或下单时的 text
+func (c *RestClient) GetFuturesOrder(ctx context.Context, settle, orderId string) (result FuturesOrderDetail, err error) {
+	err = c.signed(ctx, http.MethodGet, "/futures/"+settle+"/orders/"+url.PathEscape(orderId), nil, nil, &result)
+	return
+}
+
+// GetFuturesOrders status: open / finished；contract 为空表示全部合约，limit 最大 1000
+func (c *RestClient) GetFuturesOrders(ctx context.Context, settle, contract, status string, limit, offset int) (orders []FuturesOrderDetail, err error) {
+	q := url.Values{"status": {status}}
+	setQuery(q, "contract", contract)
+	setQuery(q, "limit", limit)
+	setQuery(q, "offset", offset)
+	err = c.signed(ctx, http.MethodGet, "/futures/"+settle+"/orders", q, nil, &orders)
+	return
+}
+
+func (c *RestClient) CancelFuturesOrder(ctx context.Context, settle, orderId string) (result FuturesOrderDetail, err error) {
+	err = c.signed(ctx, http.MethodDelete, "/futures/"+settle+"/orders/"+url.PathEscape(orderId), nil, nil, &result)
+	return
+}
+
+// CancelFuturesOrders 撤销合约全部挂单，side: ask / bid，空表示两边
+func (c *RestClient) CancelFuturesOrders(ctx context.Context, settle, contract, side string) (orders []FuturesOrderDetail, err error) {
+	q := url.Values{"contract": {contract}}
+	setQuery(q, "side", side)
+	err = c.signed(ctx, http.MethodDelete, "/futures/"+settle+"/orders", q, nil, &orders)
+	return
+}
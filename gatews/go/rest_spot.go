@@ -0,0 +1,98 @@
+package gatews
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+/*------------------ 现货行情 ------------------*/
+
+// GetSpotCurrencyPairs 全部交易对
+func (c *RestClient) GetSpotCurrencyPairs(ctx context.Context) (pairs []SpotCurrencyPair, err error) {
+	err = c.publicGet(ctx, "/spot/currency_pairs", nil, &pairs)
+	return
+}
+
+// GetSpotTickers pair 为空返回全部交易对
+func (c *RestClient) GetSpotTickers(ctx context.Context, pair string) (tickers []SpotTickerMsg, err error) {
+	q := url.Values{}
+	setQuery(q, "currency_pair", pair)
+	err = c.publicGet(ctx, "/spot/tickers", q, &tickers)
+	return
+}
+
+// GetSpotOrderBook limit 默认 10，最大 100；返回带 id 的快照
+func (c *RestClient) GetSpotOrderBook(ctx context.Context, pair string, limit int) (book SpotOrderBookSnapshot, err error) {
+	q := url.Values{"currency_pair": {pair}, "with_id": {"true"}}
+	setQuery(q, "limit", limit)
+	err = c.publicGet(ctx, "/spot/order_book", q, &book)
+	return
+}
+
+// GetSpotCandlesticks interval: 10s 1m 5m 15m 30m 1h 4h 8h 1d 7d 30d
+// from/to 为秒级时间戳，与 limit 不能同时使用；0 表示不传
+func (c *RestClient) GetSpotCandlesticks(ctx context.Context, pair, interval string, from, to int64, limit int) (candles []SpotCandleUpdateMsg, err error) {
+	q := url.Values{"currency_pair": {pair}}
+	setQuery(q, "interval", interval)
+	setQuery(q, "from", from)
+	setQuery(q, "to", to)
+	setQuery(q, "limit", limit)
+	var raw []spotCandle
+	if err = c.publicGet(ctx, "/spot/candlesticks", q, &raw); err != nil {
+		return
+	}
+	candles = make([]SpotCandleUpdateMsg, len(raw))
+	for i := range raw {
+		candles[i] = SpotCandleUpdateMsg(raw[i])
+		candles[i].Name = interval + "_" + pair
+	}
+	return
+}
+
+/*------------------ 现货账户 / 订单 ------------------*/
+
+// GetSpotAccounts currency 为空返回全部币种
+func (c *RestClient) GetSpotAccounts(ctx context.Context, currency string) (accounts []SpotAccount, err error) {
+	q := url.Values{}
+	setQuery(q, "currency", currency)
+	err = c.signed(ctx, http.MethodGet, "/spot/accounts", q, nil, &accounts)
+	return
+}
+
+// CreateSpotOrder 下单，必填 CurrencyPair/Side/Amount，限价单需 Price
+func (c *RestClient) CreateSpotOrder(ctx context.Context, order OrderMsg) (result OrderMsg, err error) {
+	err = c.signed(ctx, http.MethodPost, "/spot/orders", nil, order, &result)
+	return
+}
+
+// GetSpotOrder orderId 可以是订单 id 或下单时的 text
+func (c *RestClient) GetSpotOrder(ctx context.Context, pair, orderId string) (result OrderMsg, err error) {
+	q := url.Values{"currency_pair": {pair}}
+	err = c.signed(ctx, http.MethodGet, "/spot/orders/"+url.PathEscape(orderId), q, nil, &result)
+	return
+}
+
+// GetSpotOrders status: open / finished；page 从 1 开始，limit 最大 100
+func (c *RestClient) GetSpotOrders(ctx context.Context, pair, status string, page, limit int) (orders []OrderMsg, err error) {
+	q := url.Values{"currency_pair": {pair}, "status": {status}}
+	setQuery(q, "page", page)
+	setQuery(q, "limit", limit)
+	err = c.signed(ctx, http.MethodGet, "/spot/orders", q, nil, &orders)
+	return
+}
+
+// CancelSpotOrder 撤单
+func (c *RestClient) CancelSpotOrder(ctx context.Context, pair, orderId string) (result OrderMsg, err error) {
+	q := url.Values{"currency_pair": {pair}}
+	err = c.signed(ctx, http.MethodDelete, "/spot/orders/"+url.PathEscape(orderId), q, nil, &result)
+	return
+}
+
+// CancelSpotOrders 撤销交易对全部挂单，side 为空表示两边
+func (c *RestClient) CancelSpotOrders(ctx context.Context, pair, side string) (orders []OrderMsg, err error) {
+	q := url.Values{"currency_pair": {pair}}
+	setQuery(q, "side", side)
+	err = c.signed(ctx, http.MethodDelete, "/spot/orders", q, nil, &orders)
+	return
+}
@@ -0,0 +1,158 @@
+package gatews
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRestSign(t *testing.T) {
+	// 签名原文取自 Gate APIv4 文档示例，空 body 的哈希即文档给出的 SHA512("")；期望值由 openssl 独立计算
+	tests := []struct {
+		name                string
+		method, path, query string
+		body                string
+		want                string
+	}{
+		{
+			name:   "get with query",
+			method: http.MethodGet,
+			path:   "/api/v4/futures/orders",
+			query:  "contract=BTC_USD&status=finished&limit=50",
+			want:   "55f84ea195d6fe57ce62464daaa7c3c02fa9d1dde954e4c898289c9a2407a3d6fb3faf24deff16790d726b66ac9f74526668b13bd01029199cc4fcc522418b8a",
+		},
+		{
+			name:   "post with body",
+			method: http.MethodPost,
+			path:   "/api/v4/futures/orders",
+			body:   `{"contract":"BTC_USD","size":100,"price":"6800","tif":"gtc"}`,
+			want:   "84482f7f5be77fb90333473ffb3809ab3cb1757507c6b90e0b9a2becf6cbd1c972576dda3057c0f0b053956b82284a0bdb5995e34d31479c8f59392d417a94a1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := restSign("secret", tt.method, tt.path, tt.query, []byte(tt.body), "1541993715"); got != tt.want {
+				t.Errorf("restSign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestClient_request(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(c *RestClient) (any, error)
+		status   int
+		respond  string
+		method   string
+		path     string
+		query    string
+		wantBody string
+		signed   bool
+		want     any
+		wantErr  error
+	}{
+		{
+			name: "public",
+			call: func(c *RestClient) (any, error) {
+				return c.GetSpotTickers(context.Background(), "BTC_USDT")
+			},
+			respond: `[{"currency_pair":"BTC_USDT","last":"30000"}]`,
+			method:  http.MethodGet,
+			path:    "/api/v4/spot/tickers",
+			query:   "currency_pair=BTC_USDT",
+			want:    []SpotTickerMsg{{CurrencyPair: "BTC_USDT", Last: "30000"}},
+		},
+		{
+			name: "signed get with query",
+			call: func(c *RestClient) (any, error) {
+				return c.GetFuturesOrders(context.Background(), SettleUsdt, "BTC_USDT", "open", 10, 0)
+			},
+			respond: `[]`,
+			method:  http.MethodGet,
+			path:    "/api/v4/futures/usdt/orders",
+			query:   "contract=BTC_USDT&limit=10&status=open",
+			signed:  true,
+			want:    []FuturesOrderDetail{},
+		},
+		{
+			name: "signed post",
+			call: func(c *RestClient) (any, error) {
+				return c.CreateSpotOrder(context.Background(), OrderMsg{CurrencyPair: "BTC_USDT", Side: "buy", Amount: "1", Price: "30000"})
+			},
+			respond:  `{"id":"1","currency_pair":"BTC_USDT","status":"open"}`,
+			method:   http.MethodPost,
+			path:     "/api/v4/spot/orders",
+			wantBody: `{"currency_pair":"BTC_USDT","side":"buy","amount":"1","price":"30000"}`,
+			signed:   true,
+			want:     OrderMsg{Id: "1", CurrencyPair: "BTC_USDT", Status: "open"},
+		},
+		{
+			name: "error label",
+			call: func(c *RestClient) (any, error) {
+				return c.GetSpotOrder(context.Background(), "BTC_USDT", "404")
+			},
+			status:  http.StatusNotFound,
+			respond: `{"label":"ORDER_NOT_FOUND","message":"Order not found"}`,
+			method:  http.MethodGet,
+			path:    "/api/v4/spot/orders/404",
+			query:   "currency_pair=BTC_USDT",
+			signed:  true,
+			want:    OrderMsg{},
+			wantErr: &RestError{StatusCode: http.StatusNotFound, Label: "ORDER_NOT_FOUND", Message: "Order not found"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reqErr error
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				defer fmt.Fprint(w, tt.respond)
+				body, _ := io.ReadAll(r.Body)
+				if r.Method != tt.method || r.URL.Path != tt.path || r.URL.RawQuery != tt.query || string(body) != tt.wantBody {
+					reqErr = fmt.Errorf("request = %s %s?%s %s, want %s %s?%s %s", r.Method, r.URL.Path, r.URL.RawQuery, body, tt.method, tt.path, tt.query, tt.wantBody)
+					return
+				}
+				ts := r.Header.Get("Timestamp")
+				if !tt.signed {
+					if ts != "" || r.Header.Get("SIGN") != "" {
+						reqErr = errors.New("public request carries SIGN")
+					}
+					return
+				}
+				if sec, err := strconv.ParseInt(ts, 10, 64); err != nil || time.Since(time.Unix(sec, 0)).Abs() > time.Minute {
+					reqErr = fmt.Errorf("Timestamp = %q, want seconds", ts)
+					return
+				}
+				if got, want := r.Header.Get("SIGN"), restSign("secret", r.Method, r.URL.Path, r.URL.RawQuery, body, ts); got != want || r.Header.Get("KEY") != "key" {
+					reqErr = fmt.Errorf("KEY/SIGN = %s/%s, want key/%s", r.Header.Get("KEY"), got, want)
+				}
+			}))
+			defer server.Close()
+
+			c, err := NewRestClient(&RestConf{URL: server.URL + "/api/v4", Key: "key", Secret: "secret"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tt.call(c)
+			if reqErr != nil {
+				t.Fatal(reqErr)
+			}
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("error = %#v, want %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("result = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
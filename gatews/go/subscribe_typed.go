@@ -0,0 +1,235 @@
+package gatews
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"go.uber.org/zap"
+)
+
+// 带类型的订阅：Result 按 channel 解码为 response_spot.go / response_future.go 中的结构
+// 同一 channel 只保留最后一次设置的回调，与 SetCallBack 一致
+// 服务端推送的 Error 交给 SetErrorCallBack 设置的回调，未设置时只打日志
+
+const (
+	EventUpdate = "update"
+	EventAll    = "all"
+
+	AllContracts = "!all"
+)
+
+type errCallBack func(channel string, err ServiceError)
+
+// SetErrorCallBack 设置订阅错误回调，如订阅参数错误、鉴权失败
+func (ws *WsService) SetErrorCallBack(call func(channel string, err ServiceError)) {
+	if call != nil {
+		ws.errCall.Store(errCallBack(call))
+	}
+}
+
+func (ws *WsService) serviceError(msg *UpdateMsg) {
+	if v := ws.errCall.Load(); v != nil {
+		v.(errCallBack)(msg.Channel, *msg.Error)
+		return
+	}
+	zap.S().Errorf("%s %s: %v", msg.Channel, msg.Event, msg.Error)
+}
+
+/*------------------ 解码 ------------------*/
+
+// decodeResult Result 可能是对象也可能是数组，统一解码为切片
+func decodeResult[T any](raw json.RawMessage) ([]T, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '[' {
+		var list []T
+		err := json.Unmarshal(raw, &list)
+		return list, err
+	}
+	var one T
+	if err := json.Unmarshal(raw, &one); err != nil {
+		return nil, err
+	}
+	return []T{one}, nil
+}
+
+// typedCallBack 只处理 update/all 推送，订阅回包中的 Error 交给错误回调
+func typedCallBack[T any](ws *WsService, fn func([]T)) callBack {
+	return func(msg *UpdateMsg) {
+		if msg.Error != nil {
+			ws.serviceError(msg)
+			return
+		}
+		if msg.Event != EventUpdate && msg.Event != EventAll {
+			return
+		}
+		list, err := decodeResult[T](msg.Result)
+		if err != nil {
+			zap.S().Warnf("%s 解码失败：%v, 原始:%s", msg.Channel, err, string(msg.Result))
+			return
+		}
+		if len(list) > 0 {
+			fn(list)
+		}
+	}
+}
+
+// each 逐条回调，用于单条推送的 channel
+func each[T any](fn func(T)) func([]T) {
+	return func(list []T) {
+		for _, v := range list {
+			fn(v)
+		}
+	}
+}
+
+func subscribeTyped[T any](ws *WsService, channel string, payload []string, fn func([]T)) error {
+	ws.SetCallBack(channel, typedCallBack(ws, fn))
+	return ws.Subscribe(channel, payload)
+}
+
+// futuresPayload 合约、期权私有频道 payload 为 [user_id, contract...]，contract 为空表示全部
+func futuresPayload(userId string, contracts []string) []string {
+	if len(contracts) == 0 {
+		contracts = []string{AllContracts}
+	}
+	return append([]string{userId}, contracts...)
+}
+
+/*------------------ 现货 ------------------*/
+
+func (ws *WsService) SubscribeSpotTickers(pairs []string, fn func(SpotTickerMsg)) error {
+	return subscribeTyped(ws, ChannelSpotTicker, pairs, each(fn))
+}
+
+func (ws *WsService) SubscribeSpotTrades(pairs []string, fn func(SpotTradeMsg)) error {
+	return subscribeTyped(ws, ChannelSpotPublicTrade, pairs, each(fn))
+}
+
+// SubscribeSpotCandlesticks interval: 10s 1m 5m 15m 30m 1h 4h 8h 1d 7d 30d
+func (ws *WsService) SubscribeSpotCandlesticks(interval, pair string, fn func(SpotCandleUpdateMsg)) error {
+	return subscribeTyped(ws, ChannelSpotCandleStick, []string{interval, pair}, each(fn))
+}
+
+func (ws *WsService) SubscribeSpotBookTicker(pairs []string, fn func(SpotBookTickerMsg)) error {
+	return subscribeTyped(ws, ChannelSpotBookTicker, pairs, each(fn))
+}
+
+// SubscribeSpotOrderBook 有限档位全量深度，level: 5/10/20/50/100，interval: 100ms/1000ms
+func (ws *WsService) SubscribeSpotOrderBook(pair, level, interval string, fn func(SpotUpdateAllDepthMsg)) error {
+	return subscribeTyped(ws, ChannelSpotOrderBook, []string{pair, level, interval}, each(fn))
+}
+
+// SubscribeSpotOrderBookUpdate 增量深度，interval: 20ms/100ms；需要维护本地深度时使用 OrderBookManager
+func (ws *WsService) SubscribeSpotOrderBookUpdate(pair, interval string, fn func(SpotUpdateDepthMsg)) error {
+	return subscribeTyped(ws, ChannelSpotOrderBookUpdate, []string{pair, interval}, each(fn))
+}
+
+// SubscribeSpotOrders pairs 为 ["!all"] 表示全部交易对
+func (ws *WsService) SubscribeSpotOrders(pairs []string, fn func([]SpotOrderMsg)) error {
+	return subscribeTyped(ws, ChannelSpotOrder, pairs, fn)
+}
+
+func (ws *WsService) SubscribeSpotUserTrades(pairs []string, fn func([]SpotUserTradesMsg)) error {
+	return subscribeTyped(ws, ChannelSpotUserTrade, pairs, fn)
+}
+
+func (ws *WsService) SubscribeSpotBalances(fn func([]SpotBalancesMsg)) error {
+	return subscribeTyped(ws, ChannelSpotBalance, []string{}, fn)
+}
+
+func (ws *WsService) SubscribeSpotFundingBalances(fn func([]SpotFundingBalancesMsg)) error {
+	return subscribeTyped(ws, ChannelSpotFundingBalance, []string{}, fn)
+}
+
+func (ws *WsService) SubscribeSpotMarginBalances(fn func([]SpotMarginBalancesMsg)) error {
+	return subscribeTyped(ws, ChannelSpotMarginBalance, []string{}, fn)
+}
+
+/*------------------ 合约 ------------------*/
+
+func (ws *WsService) SubscribeFuturesTickers(contracts []string, fn func([]FuturesTicker)) error {
+	return subscribeTyped(ws, ChannelFutureTicker, contracts, fn)
+}
+
+func (ws *WsService) SubscribeFuturesTrades(contracts []string, fn func([]FuturesTrade)) error {
+	return subscribeTyped(ws, ChannelFutureTrade, contracts, fn)
+}
+
+func (ws *WsService) SubscribeFuturesBookTicker(contracts []string, fn func(FuturesBookTicker)) error {
+	return subscribeTyped(ws, ChannelFutureBookTicker, contracts, each(fn))
+}
+
+// SubscribeFuturesOrderBookUpdate frequency: 20ms/100ms，level: 20/50/100（20ms 仅支持 20）
+func (ws *WsService) SubscribeFuturesOrderBookUpdate(contract, frequency, level string, fn func(FuturesOrderBookUpdate)) error {
+	return subscribeTyped(ws, ChannelFutureOrderBookUpdate, []string{contract, frequency, level}, each(fn))
+}
+
+// SubscribeFuturesCandlesticks contract 加 mark_ / index_ 前缀订阅标记价格、指数价格 K 线
+func (ws *WsService) SubscribeFuturesCandlesticks(interval, contract string, fn func([]FuturesCandlestick)) error {
+	return subscribeTyped(ws, ChannelFutureCandleStick, []string{interval, contract}, fn)
+}
+
+// 以下为私有频道，contracts 为空表示全部合约
+
+func (ws *WsService) SubscribeFuturesOrders(userId string, contracts []string, fn func([]FuturesOrder)) error {
+	return subscribeTyped(ws, ChannelFutureOrder, futuresPayload(userId, contracts), fn)
+}
+
+func (ws *WsService) SubscribeFuturesUserTrades(userId string, contracts []string, fn func([]FuturesUserTrade)) error {
+	return subscribeTyped(ws, ChannelFutureUserTrade, futuresPayload(userId, contracts), fn)
+}
+
+func (ws *WsService) SubscribeFuturesLiquidates(userId string, contracts []string, fn func([]FuturesLiquidate)) error {
+	return subscribeTyped(ws, ChannelFutureLiquidates, futuresPayload(userId, contracts), fn)
+}
+
+func (ws *WsService) SubscribeFuturesAutoDeleverages(userId string, contracts []string, fn func([]FuturesAutoDeleverages)) error {
+	return subscribeTyped(ws, ChannelFutureAutoDeleverages, futuresPayload(userId, contracts), fn)
+}
+
+func (ws *WsService) SubscribeFuturesPositionCloses(userId string, contracts []string, fn func([]FuturesPositionCloses)) error {
+	return subscribeTyped(ws, ChannelFuturePositionCloses, futuresPayload(userId, contracts), fn)
+}
+
+func (ws *WsService) SubscribeFuturesReduceRiskLimits(userId string, contracts []string, fn func([]FuturesReduceRiskLimits)) error {
+	return subscribeTyped(ws, ChannelFutureReduceRiskLimits, futuresPayload(userId, contracts), fn)
+}
+
+func (ws *WsService) SubscribeFuturesPositions(userId string, contracts []string, fn func([]FuturesPositions)) error {
+	return subscribeTyped(ws, ChannelFuturePositions, futuresPayload(userId, contracts), fn)
+}
+
+func (ws *WsService) SubscribeFuturesAutoOrders(userId string, contracts []string, fn func([]FuturesAutoOrder)) error {
+	return subscribeTyped(ws, ChannelFutureAutoOrders, futuresPayload(userId, contracts), fn)
+}
+
+// SubscribeFuturesBalances 余额频道 payload 只有 user_id
+func (ws *WsService) SubscribeFuturesBalances(userId string, fn func([]FuturesBalance)) error {
+	return subscribeTyped(ws, ChannelFutureBalance, []string{userId}, fn)
+}
+
+/*------------------ 期权 ------------------*/
+
+// 期权连接 OptionsUrl；交割合约连接 DeliveryUsdtUrl，使用上面的 SubscribeFutures* 即可
+
+func (ws *WsService) SubscribeOptionsContractTickers(contracts []string, fn func(OptionsContractTicker)) error {
+	return subscribeTyped(ws, ChannelOptionsContractTicker, contracts, each(fn))
+}
+
+// SubscribeOptionsUlTickers underlyings 为标的，如 BTC_USDT
+func (ws *WsService) SubscribeOptionsUlTickers(underlyings []string, fn func(OptionsUlTicker)) error {
+	return subscribeTyped(ws, ChannelOptionsUlTicker, underlyings, each(fn))
+}
+
+// SubscribeOptionsOrderBook limit: 5/10/20/50，interval: 0 表示实时
+func (ws *WsService) SubscribeOptionsOrderBook(contract, limit, interval string, fn func(OptionsOrderBook)) error {
+	return subscribeTyped(ws, ChannelOptionsOrderBook, []string{contract, limit, interval}, each(fn))
+}
+
+func (ws *WsService) SubscribeOptionsOrders(userId string, contracts []string, fn func([]OptionsOrder)) error {
+	return subscribeTyped(ws, ChannelOptionsOrder, futuresPayload(userId, contracts), fn)
+}
+
+func (ws *WsService) SubscribeOptionsPositions(userId string, contracts []string, fn func([]OptionsPosition)) error {
+	return subscribeTyped(ws, ChannelOptionsPositions, futuresPayload(userId, contracts), fn)
+}
@@ -0,0 +1,215 @@
+package gatews
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestDecodeResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []SpotTickerMsg
+		wantErr bool
+	}{
+		{name: "object", raw: ` {"currency_pair":"BTC_USDT","last":"30000"}`, want: []SpotTickerMsg{{CurrencyPair: "BTC_USDT", Last: "30000"}}},
+		{
+			name: "array",
+			raw:  `[{"currency_pair":"BTC_USDT"},{"currency_pair":"ETH_USDT"}]`,
+			want: []SpotTickerMsg{{CurrencyPair: "BTC_USDT"}, {CurrencyPair: "ETH_USDT"}},
+		},
+		{name: "bad", raw: `"30000"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeResult[SpotTickerMsg](json.RawMessage(tt.raw))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeResult() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypedCallBack(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     UpdateMsg
+		want    [][]FuturesOrder
+		wantErr string
+	}{
+		{
+			name: "update",
+			msg:  UpdateMsg{Channel: ChannelFutureOrder, Event: EventUpdate, Result: json.RawMessage(`[{"id":1},{"id":2}]`)},
+			want: [][]FuturesOrder{{{Id: 1}, {Id: 2}}},
+		},
+		{
+			name: "all",
+			msg:  UpdateMsg{Channel: ChannelFutureOrder, Event: EventAll, Result: json.RawMessage(`{"id":3}`)},
+			want: [][]FuturesOrder{{{Id: 3}}},
+		},
+		{
+			name: "subscribe ack",
+			msg:  UpdateMsg{Channel: ChannelFutureOrder, Event: Subscribe, Result: json.RawMessage(`{"status":"success"}`)},
+		},
+		{
+			name:    "error",
+			msg:     UpdateMsg{Channel: ChannelFutureOrder, Event: Subscribe, Error: &ServiceError{Code: 4, Message: "invalid argument"}},
+			wantErr: ChannelFutureOrder + ": invalid argument",
+		},
+		{
+			name: "decode failed",
+			msg:  UpdateMsg{Channel: ChannelFutureOrder, Event: EventUpdate, Result: json.RawMessage(`[1]`)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws := &WsService{}
+			var got [][]FuturesOrder
+			var gotErr string
+			ws.SetErrorCallBack(func(channel string, err ServiceError) { gotErr = channel + ": " + err.Error() })
+			typedCallBack(ws, func(list []FuturesOrder) { got = append(got, list) })(&tt.msg)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("callback = %+v, want %+v", got, tt.want)
+			}
+			if gotErr != tt.wantErr {
+				t.Errorf("error callback = %q, want %q", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+// fakeGateSub 按文档格式校验订阅签名 SIGN = hex(HMAC_SHA512(secret, "channel=<channel>&event=<event>&time=<time>"))，
+// 记录订阅请求并回 subscribe 成功
+type fakeGateSub struct {
+	*httptest.Server
+	mu   sync.Mutex
+	conn *websocket.Conn
+	subs []string
+	err  error
+}
+
+func newFakeGateSub(t *testing.T) *fakeGateSub {
+	f := &fakeGateSub{}
+	upgrader := websocket.Upgrader{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		f.mu.Lock()
+		f.conn = c
+		f.mu.Unlock()
+
+		for {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req Request
+			if json.Unmarshal(data, &req) != nil || req.Event != Subscribe {
+				continue
+			}
+			mac := hmac.New(sha512.New, []byte("secret"))
+			mac.Write([]byte(fmt.Sprintf("channel=%s&event=%s&time=%d", req.Channel, req.Event, req.Time)))
+			f.mu.Lock()
+			if want := hex.EncodeToString(mac.Sum(nil)); req.Auth.Method != AuthMethodApiKey || req.Auth.Key != "key" || req.Auth.Secret != want {
+				f.err = fmt.Errorf("auth = %+v, want SIGN %s", req.Auth, want)
+			}
+			f.subs = append(f.subs, req.Channel+" "+strings.Join(req.Payload, ","))
+			f.mu.Unlock()
+			f.push(fmt.Sprintf(`{"time":%d,"channel":"%s","event":"subscribe","result":{"status":"success"}}`, req.Time, req.Channel))
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeGateSub) push(msg string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_ = f.conn.WriteMessage(websocket.TextMessage, []byte(msg))
+}
+
+func (f *fakeGateSub) subscribed() ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.subs...), f.err
+}
+
+func TestWsService_subscribeTyped(t *testing.T) {
+	server := newFakeGateSub(t)
+	ws, err := NewWsService(context.Background(), &ConnConf{
+		URL:    "ws" + strings.TrimPrefix(server.URL, "http"),
+		Key:    "key",
+		Secret: "secret",
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ws.cancel()
+		_ = ws.Client.Close()
+	})
+
+	tickers := make(chan SpotTickerMsg, 2)
+	orders := make(chan []FuturesOrder, 1)
+	if err := ws.SubscribeSpotTickers([]string{"BTC_USDT", "ETH_USDT"}, func(m SpotTickerMsg) { tickers <- m }); err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.SubscribeFuturesOrders("10001", nil, func(list []FuturesOrder) { orders <- list }); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{ChannelSpotTicker + " BTC_USDT,ETH_USDT", ChannelFutureOrder + " 10001," + AllContracts}
+	deadline := time.Now().Add(time.Second)
+	for {
+		subs, err := server.subscribed()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reflect.DeepEqual(subs, want) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("subscribed %v, want %v", subs, want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// 单条推送的 channel 逐条回调，列表推送的 channel 整批回调
+	server.push(`{"time":1,"channel":"spot.tickers","event":"update","result":{"currency_pair":"BTC_USDT","last":"30000"}}`)
+	server.push(`{"time":1,"channel":"futures.orders","event":"update","result":[{"id":1,"contract":"BTC_USDT"},{"id":2,"contract":"ETH_USDT"}]}`)
+	select {
+	case got := <-tickers:
+		if want := (SpotTickerMsg{CurrencyPair: "BTC_USDT", Last: "30000"}); got != want {
+			t.Errorf("ticker = %+v, want %+v", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Error("ticker callback not called")
+	}
+	select {
+	case got := <-orders:
+		if len(got) != 2 || got[0].Id != 1 || got[1].Contract != "ETH_USDT" {
+			t.Errorf("orders = %+v, want ids 1,2", got)
+		}
+	case <-time.After(time.Second):
+		t.Error("orders callback not called")
+	}
+}
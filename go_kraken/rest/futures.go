@@ -37,18 +37,35 @@ func (api *Kraken) Authentication(endPoint string, postData string) (nonce strin
 }
 
 func (api *Kraken) prepareFuturesRequest(method string, data url.Values, retType interface{}) error {
+	return api.futuresRequest(http.MethodGet, method, data, retType)
+}
+
+// futuresRequest - GET sends data as query string, POST as form body. Both are signed as postData.
+func (api *Kraken) futuresRequest(httpMethod, method string, data url.Values, retType interface{}) error {
 	if data == nil {
 		data = url.Values{}
 	}
 	endPoint := fmt.Sprintf("%s/%s", APIFuturesV3, method)
 	requestURL := fmt.Sprintf("%s%s", APIFuturesBase, endPoint)
 	reqData := data.Encode()
-	req, err := http.NewRequest("GET", requestURL, strings.NewReader(reqData))
+
+	var body io.Reader
+	if httpMethod == http.MethodGet {
+		if reqData != "" {
+			requestURL = fmt.Sprintf("%s?%s", requestURL, reqData)
+		}
+	} else {
+		body = strings.NewReader(reqData)
+	}
+	req, err := http.NewRequest(httpMethod, requestURL, body)
 	if err != nil {
 		return errors.Wrap(err, "error during request creation")
 	}
 
 	req.Header.Add("Accept", "application/json")
+	if body != nil {
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	}
 	if len(api.key) > 0 {
 		nonce, authent := api.Authentication(endPoint, reqData)
 		req.Header.Add("APIKey", api.key)
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
 /*
@@ -62,3 +64,168 @@ func (api *Kraken) GetFuturesCandles(symbol, period string, from, to int64) (Fut
 	}
 	return ret, nil
 }
+
+// Futures order types
+const (
+	FuturesOrderLimit        = "lmt"
+	FuturesOrderPostOnly     = "post"
+	FuturesOrderIOC          = "ioc"
+	FuturesOrderMarket       = "mkt"
+	FuturesOrderStop         = "stp"
+	FuturesOrderTakeProfit   = "take_profit"
+	FuturesOrderTrailingStop = "trailing_stop"
+)
+
+// setFuturesArgs - optional arguments of futures requests
+func setFuturesArgs(data url.Values, args map[string]interface{}) {
+	for key, value := range args {
+		switch v := value.(type) {
+		case string:
+			data.Set(key, v)
+		case int:
+			data.Set(key, strconv.Itoa(v))
+		case int64:
+			data.Set(key, strconv.FormatInt(v, 10))
+		case float64:
+			data.Set(key, strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			data.Set(key, strconv.FormatBool(v))
+		default:
+			log.Printf("[WARNING] Unknown value type %v for key %s", value, key)
+		}
+	}
+}
+
+// SendFuturesOrder - 合约下单
+// side: buy / sell，orderType: lmt / post / ioc / mkt / stp / take_profit / trailing_stop
+// args: limitPrice, stopPrice, cliOrdId, reduceOnly, triggerSignal, trailingStopMaxDeviation, trailingStopDeviationUnit
+// 下单被拒绝时 Result 仍为 success，需检查 SendStatus.Status 是否为 placed
+func (api *Kraken) SendFuturesOrder(symbol, side, orderType string, size float64, args map[string]interface{}) (response FuturesSendOrderResponse, err error) {
+	data := url.Values{
+		"symbol":    {symbol}, // PF_XBTUSD
+		"side":      {side},
+		"orderType": {orderType},
+		"size":      {strconv.FormatFloat(size, 'f', -1, 64)},
+	}
+	setFuturesArgs(data, args)
+
+	err = api.futuresRequest(http.MethodPost, "sendorder", data, &response)
+	return
+}
+
+// EditFuturesOrder - 改单，orderID 为空时使用 args 中的 cliOrdId
+// args: size, limitPrice, stopPrice, trailingStopMaxDeviation, trailingStopDeviationUnit
+func (api *Kraken) EditFuturesOrder(orderID string, args map[string]interface{}) (response FuturesEditOrderResponse, err error) {
+	data := url.Values{}
+	if orderID != "" {
+		data.Set("orderId", orderID)
+	}
+	setFuturesArgs(data, args)
+
+	err = api.futuresRequest(http.MethodPost, "editorder", data, &response)
+	return
+}
+
+// CancelFuturesOrder - 撤单，orderID 与 cliOrdID 二选一
+func (api *Kraken) CancelFuturesOrder(orderID, cliOrdID string) (response FuturesCancelOrderResponse, err error) {
+	data := url.Values{}
+	if orderID != "" {
+		data.Set("order_id", orderID)
+	}
+	if cliOrdID != "" {
+		data.Set("cliOrdId", cliOrdID)
+	}
+
+	err = api.futuresRequest(http.MethodPost, "cancelorder", data, &response)
+	return
+}
+
+// CancelAllFuturesOrders - 撤销全部挂单，symbol 为空表示全部合约
+func (api *Kraken) CancelAllFuturesOrders(symbol string) (response FuturesCancelOrderResponse, err error) {
+	data := url.Values{}
+	if symbol != "" {
+		data.Set("symbol", symbol)
+	}
+
+	err = api.futuresRequest(http.MethodPost, "cancelallorders", data, &response)
+	return
+}
+
+// CancelAllFuturesOrdersAfter - dead man's switch，timeout 秒后撤销全部挂单，0 表示关闭
+func (api *Kraken) CancelAllFuturesOrdersAfter(timeout int64) (response FuturesDeadManSwitchResponse, err error) {
+	data := url.Values{
+		"timeout": {strconv.FormatInt(timeout, 10)},
+	}
+
+	err = api.futuresRequest(http.MethodPost, "cancelallordersafter", data, &response)
+	return
+}
+
+// BatchFuturesOrder - 批量下单 / 改单 / 撤单
+func (api *Kraken) BatchFuturesOrder(instructions []FuturesBatchInstruction) (response FuturesBatchOrderResponse, err error) {
+	body, err := json.Marshal(map[string]interface{}{"batchOrder": instructions})
+	if err != nil {
+		return response, err
+	}
+	data := url.Values{
+		"json": {string(body)},
+	}
+
+	err = api.futuresRequest(http.MethodPost, "batchorder", data, &response)
+	return
+}
+
+// GetFuturesOpenOrders - 当前挂单
+func (api *Kraken) GetFuturesOpenOrders() (response FuturesOpenOrdersResponse, err error) {
+	err = api.futuresRequest(http.MethodGet, "openorders", nil, &response)
+	return
+}
+
+// GetFuturesFills - 最近 100 条成交，lastFillTime 不为空时返回该时间之前的成交
+func (api *Kraken) GetFuturesFills(lastFillTime string) (response FuturesFillsResponse, err error) {
+	data := url.Values{}
+	if lastFillTime != "" {
+		data.Set("lastFillTime", lastFillTime)
+	}
+
+	err = api.futuresRequest(http.MethodGet, "fills", data, &response)
+	return
+}
+
+// GetFuturesAccounts - 账户信息
+func (api *Kraken) GetFuturesAccounts() (response FuturesAccountsResponse, err error) {
+	err = api.futuresRequest(http.MethodGet, "accounts", nil, &response)
+	return
+}
+
+// GetFuturesInstruments - 合约列表
+func (api *Kraken) GetFuturesInstruments() (response FuturesInstrumentsResponse, err error) {
+	err = api.futuresRequest(http.MethodGet, "instruments", nil, &response)
+	return
+}
+
+// GetFuturesTickers - 全部合约行情
+func (api *Kraken) GetFuturesTickers() (response FuturesTickersResponse, err error) {
+	err = api.futuresRequest(http.MethodGet, "tickers", nil, &response)
+	return
+}
+
+// GetFuturesOrderBook - 深度
+func (api *Kraken) GetFuturesOrderBook(symbol string) (response FuturesOrderBookResponse, err error) {
+	data := url.Values{
+		"symbol": {symbol},
+	}
+
+	err = api.futuresRequest(http.MethodGet, "orderbook", data, &response)
+	return
+}
+
+// GetFuturesHistoricalFundingRates - 历史资金费率
+func (api *Kraken) GetFuturesHistoricalFundingRates(symbol string) (response FuturesFundingRatesResponse, err error) {
+	data := url.Values{
+		"symbol": {symbol},
+	}
+
+	err = api.futuresRequest(http.MethodGet, "historicalfundingrates", data, &response)
+	return
+}
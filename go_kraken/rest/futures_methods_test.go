@@ -0,0 +1,261 @@
+package rest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// futuresHTTPMock - records the request to check method, url and body
+type futuresHTTPMock struct {
+	httpMock
+	req  *http.Request
+	body string
+}
+
+func (c *futuresHTTPMock) Do(req *http.Request) (*http.Response, error) {
+	c.req = req
+	if req.Body != nil {
+		b, _ := io.ReadAll(req.Body)
+		c.body = string(b)
+	}
+	return c.httpMock.Do(req)
+}
+
+func futuresResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+	}
+}
+
+var futuresServerTime = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func TestKraken_SendFuturesOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		resp     *http.Response
+		want     FuturesSendOrderResponse
+		wantBody url.Values
+		wantErr  bool
+	}{
+		{
+			name:    "Error returned from Kraken",
+			err:     ErrSomething,
+			resp:    &http.Response{},
+			wantErr: true,
+		},
+		{
+			name:    "Result is error",
+			resp:    futuresResponse(`{"result":"error","serverTime":"2024-05-01T10:00:00Z","error":"apiLimitExceeded"}`),
+			wantErr: true,
+		},
+		{
+			name: "Order placed",
+			resp: futuresResponse(`{"result":"success","sendStatus":{"order_id":"179f9af8-e45e-469d-b3e9-2fd4675cb7d0","cliOrdId":"my-1","status":"placed","receivedTime":"2024-05-01T10:00:00Z","orderEvents":[{"type":"PLACE","order":{"orderId":"179f9af8-e45e-469d-b3e9-2fd4675cb7d0","cliOrdId":"my-1","type":"lmt","symbol":"PF_XBTUSD","side":"buy","quantity":0.01,"filled":0,"limitPrice":60000,"reduceOnly":false,"timestamp":"2024-05-01T10:00:00Z","lastUpdateTimestamp":"2024-05-01T10:00:00Z"}}]},"serverTime":"2024-05-01T10:00:00Z"}`),
+			want: FuturesSendOrderResponse{
+				ResponseDerivatives: ResponseDerivatives{Result: "success", ServerTime: futuresServerTime},
+				SendStatus: FuturesSendStatus{
+					OrderID:      "179f9af8-e45e-469d-b3e9-2fd4675cb7d0",
+					CliOrdID:     "my-1",
+					Status:       "placed",
+					ReceivedTime: futuresServerTime,
+					OrderEvents: []FuturesOrderEvent{
+						{
+							Type: "PLACE",
+							Order: &FuturesOrder{
+								OrderID:             "179f9af8-e45e-469d-b3e9-2fd4675cb7d0",
+								CliOrdID:            "my-1",
+								Type:                FuturesOrderLimit,
+								Symbol:              "PF_XBTUSD",
+								Side:                Buy,
+								Quantity:            0.01,
+								LimitPrice:          60000,
+								Timestamp:           futuresServerTime,
+								LastUpdateTimestamp: futuresServerTime,
+							},
+						},
+					},
+				},
+			},
+			wantBody: url.Values{
+				"symbol":     {"PF_XBTUSD"},
+				"side":       {Buy},
+				"orderType":  {FuturesOrderLimit},
+				"size":       {"0.01"},
+				"limitPrice": {"60000"},
+				"cliOrdId":   {"my-1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &futuresHTTPMock{httpMock: httpMock{Error: tt.err, Response: tt.resp}}
+			api := &Kraken{client: client}
+			got, err := api.SendFuturesOrder("PF_XBTUSD", Buy, FuturesOrderLimit, 0.01, map[string]interface{}{
+				"limitPrice": 60000.0,
+				"cliOrdId":   "my-1",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Kraken.SendFuturesOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Kraken.SendFuturesOrder() = %+v, want %+v", got, tt.want)
+			}
+			if client.req.Method != http.MethodPost || client.req.URL.Path != "/derivatives/api/v3/sendorder" {
+				t.Errorf("Kraken.SendFuturesOrder() request = %s %s", client.req.Method, client.req.URL)
+			}
+			if client.body != tt.wantBody.Encode() {
+				t.Errorf("Kraken.SendFuturesOrder() body = %s, want %s", client.body, tt.wantBody.Encode())
+			}
+		})
+	}
+}
+
+func TestKraken_EditFuturesOrder(t *testing.T) {
+	client := &futuresHTTPMock{httpMock: httpMock{Response: futuresResponse(`{"result":"success","editStatus":{"orderId":"O1","status":"edited","receivedTime":"2024-05-01T10:00:00Z","orderEvents":[]},"serverTime":"2024-05-01T10:00:00Z"}`)}}
+	api := &Kraken{client: client}
+	got, err := api.EditFuturesOrder("O1", map[string]interface{}{"limitPrice": 61000.5})
+	if err != nil {
+		t.Fatalf("Kraken.EditFuturesOrder() error = %v", err)
+	}
+	want := FuturesEditOrderResponse{
+		ResponseDerivatives: ResponseDerivatives{Result: "success", ServerTime: futuresServerTime},
+		EditStatus:          FuturesEditStatus{OrderID: "O1", Status: "edited", ReceivedTime: futuresServerTime, OrderEvents: []FuturesOrderEvent{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Kraken.EditFuturesOrder() = %+v, want %+v", got, want)
+	}
+	if wantBody := "limitPrice=61000.5&orderId=O1"; client.body != wantBody {
+		t.Errorf("Kraken.EditFuturesOrder() body = %s, want %s", client.body, wantBody)
+	}
+}
+
+func TestKraken_CancelAllFuturesOrders(t *testing.T) {
+	client := &futuresHTTPMock{httpMock: httpMock{Response: futuresResponse(`{"result":"success","cancelStatus":{"receivedTime":"2024-05-01T10:00:00Z","cancelOnly":"PF_XBTUSD","status":"cancelled","cancelledOrders":[{"order_id":"O1"},{"order_id":"O2","cliOrdId":"my-2"}],"orderEvents":[]},"serverTime":"2024-05-01T10:00:00Z"}`)}}
+	api := &Kraken{client: client}
+	got, err := api.CancelAllFuturesOrders("PF_XBTUSD")
+	if err != nil {
+		t.Fatalf("Kraken.CancelAllFuturesOrders() error = %v", err)
+	}
+	want := []FuturesCancelledOrder{{OrderID: "O1"}, {OrderID: "O2", CliOrdID: "my-2"}}
+	if got.CancelStatus.Status != "cancelled" || !reflect.DeepEqual(got.CancelStatus.CancelledOrders, want) {
+		t.Errorf("Kraken.CancelAllFuturesOrders() = %+v", got.CancelStatus)
+	}
+	if client.body != "symbol=PF_XBTUSD" {
+		t.Errorf("Kraken.CancelAllFuturesOrders() body = %s", client.body)
+	}
+}
+
+func TestKraken_CancelAllFuturesOrdersAfter(t *testing.T) {
+	client := &futuresHTTPMock{httpMock: httpMock{Response: futuresResponse(`{"result":"success","status":{"currentTime":"2024-05-01T10:00:00Z","triggerTime":"2024-05-01T10:01:00Z"},"serverTime":"2024-05-01T10:00:00Z"}`)}}
+	api := &Kraken{client: client}
+	got, err := api.CancelAllFuturesOrdersAfter(60)
+	if err != nil {
+		t.Fatalf("Kraken.CancelAllFuturesOrdersAfter() error = %v", err)
+	}
+	if !got.Status.TriggerTime.Equal(futuresServerTime.Add(time.Minute)) {
+		t.Errorf("Kraken.CancelAllFuturesOrdersAfter() trigger time = %v", got.Status.TriggerTime)
+	}
+	if client.body != "timeout=60" {
+		t.Errorf("Kraken.CancelAllFuturesOrdersAfter() body = %s", client.body)
+	}
+}
+
+func TestKraken_BatchFuturesOrder(t *testing.T) {
+	client := &futuresHTTPMock{httpMock: httpMock{Response: futuresResponse(`{"result":"success","batchStatus":[{"status":"placed","order_tag":"1","order_id":"O1","dateTimeReceived":"2024-05-01T10:00:00Z","orderEvents":[]},{"status":"cancelled","order_id":"O0","orderEvents":[]}],"serverTime":"2024-05-01T10:00:00Z"}`)}}
+	api := &Kraken{client: client}
+	got, err := api.BatchFuturesOrder([]FuturesBatchInstruction{
+		{Order: "send", OrderTag: "1", OrderType: FuturesOrderLimit, Symbol: "PF_XBTUSD", Side: Buy, Size: 0.01, LimitPrice: 60000},
+		{Order: "cancel", OrderID: "O0"},
+	})
+	if err != nil {
+		t.Fatalf("Kraken.BatchFuturesOrder() error = %v", err)
+	}
+	if len(got.BatchStatus) != 2 || got.BatchStatus[0].OrderID != "O1" || got.BatchStatus[1].Status != "cancelled" {
+		t.Errorf("Kraken.BatchFuturesOrder() = %+v", got.BatchStatus)
+	}
+	body, _ := url.ParseQuery(client.body)
+	wantJSON := `{"batchOrder":[{"order":"send","order_tag":"1","orderType":"lmt","symbol":"PF_XBTUSD","side":"buy","size":0.01,"limitPrice":60000},{"order":"cancel","order_id":"O0"}]}`
+	if body.Get("json") != wantJSON {
+		t.Errorf("Kraken.BatchFuturesOrder() json = %s, want %s", body.Get("json"), wantJSON)
+	}
+}
+
+func TestKraken_GetFuturesFills(t *testing.T) {
+	client := &futuresHTTPMock{httpMock: httpMock{Response: futuresResponse(`{"result":"success","fills":[{"fill_id":"F1","symbol":"PF_XBTUSD","side":"buy","order_id":"O1","size":0.01,"price":60000,"fillTime":"2024-05-01T10:00:00Z","fillType":"maker"}],"serverTime":"2024-05-01T10:00:00Z"}`)}}
+	api := &Kraken{client: client}
+	got, err := api.GetFuturesFills("2024-05-01T11:00:00Z")
+	if err != nil {
+		t.Fatalf("Kraken.GetFuturesFills() error = %v", err)
+	}
+	want := []FuturesFill{{FillID: "F1", OrderID: "O1", Symbol: "PF_XBTUSD", Side: Buy, Size: 0.01, Price: 60000, FillTime: futuresServerTime, FillType: "maker"}}
+	if !reflect.DeepEqual(got.Fills, want) {
+		t.Errorf("Kraken.GetFuturesFills() = %+v, want %+v", got.Fills, want)
+	}
+	if client.req.Method != http.MethodGet || client.req.URL.Query().Get("lastFillTime") != "2024-05-01T11:00:00Z" {
+		t.Errorf("Kraken.GetFuturesFills() request = %s %s", client.req.Method, client.req.URL)
+	}
+}
+
+func TestKraken_GetFuturesAccounts(t *testing.T) {
+	client := &futuresHTTPMock{httpMock: httpMock{Response: futuresResponse(`{"result":"success","accounts":{"cash":{"type":"cashAccount","balances":{"xbt":0.1}},"flex":{"type":"multiCollateralMarginAccount","currencies":{"USD":{"quantity":1000,"value":1000,"collateral":1000,"available":900}},"initialMargin":100,"balanceValue":1000,"portfolioValue":1010,"pnl":10,"availableMargin":900,"marginEquity":1010}},"serverTime":"2024-05-01T10:00:00Z"}`)}}
+	api := &Kraken{client: client}
+	got, err := api.GetFuturesAccounts()
+	if err != nil {
+		t.Fatalf("Kraken.GetFuturesAccounts() error = %v", err)
+	}
+	want := map[string]FuturesAccount{
+		"cash": {Type: "cashAccount", Balances: map[string]float64{"xbt": 0.1}},
+		"flex": {
+			Type:            "multiCollateralMarginAccount",
+			Currencies:      map[string]FuturesFlexCurrency{"USD": {Quantity: 1000, Value: 1000, CollateralValue: 1000, Available: 900}},
+			InitialMargin:   100,
+			BalanceValue:    1000,
+			PortfolioValue:  1010,
+			Pnl:             10,
+			AvailableMargin: 900,
+			MarginEquity:    1010,
+		},
+	}
+	if !reflect.DeepEqual(got.Accounts, want) {
+		t.Errorf("Kraken.GetFuturesAccounts() = %+v, want %+v", got.Accounts, want)
+	}
+}
+
+func TestKraken_GetFuturesOrderBook(t *testing.T) {
+	client := &futuresHTTPMock{httpMock: httpMock{Response: futuresResponse(`{"result":"success","orderBook":{"bids":[[60000,1.5],[59999.5,2]],"asks":[[60000.5,0.3]]},"serverTime":"2024-05-01T10:00:00Z"}`)}}
+	api := &Kraken{client: client}
+	got, err := api.GetFuturesOrderBook("PF_XBTUSD")
+	if err != nil {
+		t.Fatalf("Kraken.GetFuturesOrderBook() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.OrderBook.Bids, [][2]float64{{60000, 1.5}, {59999.5, 2}}) || !reflect.DeepEqual(got.OrderBook.Asks, [][2]float64{{60000.5, 0.3}}) {
+		t.Errorf("Kraken.GetFuturesOrderBook() = %+v", got.OrderBook)
+	}
+	if client.req.URL.String() != APIFuturesBase+APIFuturesV3+"/orderbook?symbol=PF_XBTUSD" {
+		t.Errorf("Kraken.GetFuturesOrderBook() url = %s", client.req.URL)
+	}
+}
+
+func TestKraken_GetFuturesHistoricalFundingRates(t *testing.T) {
+	client := &futuresHTTPMock{httpMock: httpMock{Response: futuresResponse(`{"result":"success","rates":[{"timestamp":"2024-05-01T10:00:00Z","fundingRate":-0.0000012,"relativeFundingRate":-0.00002}],"serverTime":"2024-05-01T10:00:00Z"}`)}}
+	api := &Kraken{client: client}
+	got, err := api.GetFuturesHistoricalFundingRates("PF_XBTUSD")
+	if err != nil {
+		t.Fatalf("Kraken.GetFuturesHistoricalFundingRates() error = %v", err)
+	}
+	want := []FuturesFundingRate{{Timestamp: futuresServerTime, FundingRate: -0.0000012, RelativeFundingRate: -0.00002}}
+	if !reflect.DeepEqual(got.Rates, want) {
+		t.Errorf("Kraken.GetFuturesHistoricalFundingRates() = %+v, want %+v", got.Rates, want)
+	}
+}
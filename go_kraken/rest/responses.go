@@ -811,3 +811,304 @@ type FuturesCandles struct {
 	} `json:"candles"`
 	MoreCandles bool `json:"more_candles"`
 }
+
+// FuturesOrderEvent - event of futures order (PLACE, EXECUTION, EDIT, CANCEL, REJECT ...). Fields depend on Type.
+type FuturesOrderEvent struct {
+	Type                 string        `json:"type"`
+	Reason               string        `json:"reason,omitempty"`
+	Order                *FuturesOrder `json:"order,omitempty"`
+	OldOrder             *FuturesOrder `json:"oldOrder,omitempty"`
+	ReducedQuantity      float64       `json:"reducedQuantity,omitempty"`
+	ExecutionID          string        `json:"executionId,omitempty"`
+	Price                float64       `json:"price,omitempty"`
+	Amount               float64       `json:"amount,omitempty"`
+	OrderPriorEdit       *FuturesOrder `json:"orderPriorEdit,omitempty"`
+	OrderPriorExecution  *FuturesOrder `json:"orderPriorExecution,omitempty"`
+	TakerReducedQuantity float64       `json:"takerReducedQuantity,omitempty"`
+}
+
+// FuturesOrder - order in futures order events
+type FuturesOrder struct {
+	OrderID             string    `json:"orderId"`
+	CliOrdID            string    `json:"cliOrdId,omitempty"`
+	Type                string    `json:"type"`
+	Symbol              string    `json:"symbol"`
+	Side                string    `json:"side"`
+	Quantity            float64   `json:"quantity"`
+	Filled              float64   `json:"filled"`
+	LimitPrice          float64   `json:"limitPrice"`
+	StopPrice           float64   `json:"stopPrice,omitempty"`
+	ReduceOnly          bool      `json:"reduceOnly"`
+	Timestamp           time.Time `json:"timestamp"`
+	LastUpdateTimestamp time.Time `json:"lastUpdateTimestamp"`
+}
+
+// FuturesSendStatus - status of sendorder. Status other than "placed" means the order was not accepted,
+// e.g. insufficientAvailableFunds, postWouldExecute, iocWouldNotExecute.
+type FuturesSendStatus struct {
+	OrderID      string              `json:"order_id"`
+	CliOrdID     string              `json:"cliOrdId,omitempty"`
+	Status       string              `json:"status"`
+	ReceivedTime time.Time           `json:"receivedTime"`
+	OrderEvents  []FuturesOrderEvent `json:"orderEvents"`
+}
+
+// FuturesSendOrderResponse - response of sendorder
+type FuturesSendOrderResponse struct {
+	ResponseDerivatives
+	SendStatus FuturesSendStatus `json:"sendStatus"`
+}
+
+// FuturesEditStatus - status of editorder, "edited" on success
+type FuturesEditStatus struct {
+	OrderID      string              `json:"orderId"`
+	CliOrdID     string              `json:"cliOrdId,omitempty"`
+	Status       string              `json:"status"`
+	ReceivedTime time.Time           `json:"receivedTime"`
+	OrderEvents  []FuturesOrderEvent `json:"orderEvents"`
+}
+
+// FuturesEditOrderResponse - response of editorder
+type FuturesEditOrderResponse struct {
+	ResponseDerivatives
+	EditStatus FuturesEditStatus `json:"editStatus"`
+}
+
+// FuturesCancelledOrder - order cancelled by cancelallorders
+type FuturesCancelledOrder struct {
+	OrderID  string `json:"order_id"`
+	CliOrdID string `json:"cliOrdId,omitempty"`
+}
+
+// FuturesCancelStatus - status of cancelorder / cancelallorders, "cancelled" on success
+type FuturesCancelStatus struct {
+	OrderID         string                  `json:"order_id,omitempty"`
+	CliOrdID        string                  `json:"cliOrdId,omitempty"`
+	Status          string                  `json:"status"`
+	ReceivedTime    time.Time               `json:"receivedTime"`
+	CancelOnly      string                  `json:"cancelOnly,omitempty"`
+	CancelledOrders []FuturesCancelledOrder `json:"cancelledOrders,omitempty"`
+	OrderEvents     []FuturesOrderEvent     `json:"orderEvents"`
+}
+
+// FuturesCancelOrderResponse - response of cancelorder and cancelallorders
+type FuturesCancelOrderResponse struct {
+	ResponseDerivatives
+	CancelStatus FuturesCancelStatus `json:"cancelStatus"`
+}
+
+// FuturesDeadManSwitchResponse - response of cancelallordersafter. Zero TriggerTime means the switch is disabled.
+type FuturesDeadManSwitchResponse struct {
+	ResponseDerivatives
+	Status struct {
+		CurrentTime time.Time `json:"currentTime"`
+		TriggerTime time.Time `json:"triggerTime"`
+	} `json:"status"`
+}
+
+// FuturesBatchInstruction - instruction of batchorder. Order is one of send, edit, cancel.
+type FuturesBatchInstruction struct {
+	Order         string  `json:"order"`
+	OrderTag      string  `json:"order_tag,omitempty"`
+	OrderType     string  `json:"orderType,omitempty"`
+	Symbol        string  `json:"symbol,omitempty"`
+	Side          string  `json:"side,omitempty"`
+	Size          float64 `json:"size,omitempty"`
+	LimitPrice    float64 `json:"limitPrice,omitempty"`
+	StopPrice     float64 `json:"stopPrice,omitempty"`
+	CliOrdID      string  `json:"cliOrdId,omitempty"`
+	ReduceOnly    bool    `json:"reduceOnly,omitempty"`
+	TriggerSignal string  `json:"triggerSignal,omitempty"`
+	OrderID       string  `json:"order_id,omitempty"`
+}
+
+// FuturesBatchStatus - result of single batch instruction
+type FuturesBatchStatus struct {
+	OrderID          string              `json:"order_id"`
+	OrderTag         string              `json:"order_tag,omitempty"`
+	CliOrdID         string              `json:"cliOrdId,omitempty"`
+	Status           string              `json:"status"`
+	DateTimeReceived time.Time           `json:"dateTimeReceived"`
+	OrderEvents      []FuturesOrderEvent `json:"orderEvents"`
+}
+
+// FuturesBatchOrderResponse - response of batchorder
+type FuturesBatchOrderResponse struct {
+	ResponseDerivatives
+	BatchStatus []FuturesBatchStatus `json:"batchStatus"`
+}
+
+// FuturesOpenOrder - open order
+type FuturesOpenOrder struct {
+	OrderID        string    `json:"order_id"`
+	CliOrdID       string    `json:"cliOrdId,omitempty"`
+	Symbol         string    `json:"symbol"`
+	Side           string    `json:"side"`
+	OrderType      string    `json:"orderType"`
+	LimitPrice     float64   `json:"limitPrice"`
+	StopPrice      float64   `json:"stopPrice,omitempty"`
+	UnfilledSize   float64   `json:"unfilledSize"`
+	FilledSize     float64   `json:"filledSize"`
+	Status         string    `json:"status"`
+	ReduceOnly     bool      `json:"reduceOnly"`
+	TriggerSignal  string    `json:"triggerSignal,omitempty"`
+	ReceivedTime   time.Time `json:"receivedTime"`
+	LastUpdateTime time.Time `json:"lastUpdateTime"`
+}
+
+// FuturesOpenOrdersResponse - response of openorders
+type FuturesOpenOrdersResponse struct {
+	ResponseDerivatives
+	OpenOrders []FuturesOpenOrder `json:"openOrders"`
+}
+
+// FuturesFill - fill of futures order. FillType: maker, taker, liquidation, assignee, assignor ...
+type FuturesFill struct {
+	FillID   string    `json:"fill_id"`
+	OrderID  string    `json:"order_id"`
+	CliOrdID string    `json:"cliOrdId,omitempty"`
+	Symbol   string    `json:"symbol"`
+	Side     string    `json:"side"`
+	Size     float64   `json:"size"`
+	Price    float64   `json:"price"`
+	FillTime time.Time `json:"fillTime"`
+	FillType string    `json:"fillType"`
+}
+
+// FuturesFillsResponse - response of fills
+type FuturesFillsResponse struct {
+	ResponseDerivatives
+	Fills []FuturesFill `json:"fills"`
+}
+
+// FuturesFlexCurrency - collateral currency of multi-collateral account
+type FuturesFlexCurrency struct {
+	Quantity        float64 `json:"quantity"`
+	Value           float64 `json:"value"`
+	CollateralValue float64 `json:"collateral"`
+	Available       float64 `json:"available"`
+}
+
+// FuturesAccount - account of accounts response. Type is one of cashAccount, marginAccount, multiCollateralMarginAccount,
+// fields not related to the type are left empty.
+type FuturesAccount struct {
+	Type string `json:"type"`
+
+	// cashAccount / marginAccount
+	Currency           string             `json:"currency,omitempty"`
+	Balances           map[string]float64 `json:"balances,omitempty"`
+	Auxiliary          map[string]float64 `json:"auxiliary,omitempty"`
+	MarginRequirements map[string]float64 `json:"marginRequirements,omitempty"`
+	TriggerEstimates   map[string]float64 `json:"triggerEstimates,omitempty"`
+
+	// multiCollateralMarginAccount (flex)
+	Currencies        map[string]FuturesFlexCurrency `json:"currencies,omitempty"`
+	InitialMargin     float64                        `json:"initialMargin,omitempty"`
+	MaintenanceMargin float64                        `json:"maintenanceMargin,omitempty"`
+	BalanceValue      float64                        `json:"balanceValue,omitempty"`
+	PortfolioValue    float64                        `json:"portfolioValue,omitempty"`
+	CollateralValue   float64                        `json:"collateralValue,omitempty"`
+	Pnl               float64                        `json:"pnl,omitempty"`
+	UnrealizedFunding float64                        `json:"unrealizedFunding,omitempty"`
+	TotalUnrealized   float64                        `json:"totalUnrealized,omitempty"`
+	AvailableMargin   float64                        `json:"availableMargin,omitempty"`
+	MarginEquity      float64                        `json:"marginEquity,omitempty"`
+}
+
+// FuturesAccountsResponse - response of accounts, keyed by account name (cash, flex, fi_xbtusd ...)
+type FuturesAccountsResponse struct {
+	ResponseDerivatives
+	Accounts map[string]FuturesAccount `json:"accounts"`
+}
+
+// FuturesMarginLevel - margin level of instrument
+type FuturesMarginLevel struct {
+	Contracts           float64 `json:"contracts,omitempty"`
+	NumNonContractUnits float64 `json:"numNonContractUnits,omitempty"`
+	InitialMargin       float64 `json:"initialMargin"`
+	MaintenanceMargin   float64 `json:"maintenanceMargin"`
+}
+
+// FuturesInstrument - instrument. Perpetuals have Type flexible_futures and symbol prefix PF_.
+type FuturesInstrument struct {
+	Symbol                      string               `json:"symbol"`
+	Type                        string               `json:"type"`
+	Underlying                  string               `json:"underlying,omitempty"`
+	Base                        string               `json:"base,omitempty"`
+	Quote                       string               `json:"quote,omitempty"`
+	Pair                        string               `json:"pair,omitempty"`
+	Tradeable                   bool                 `json:"tradeable"`
+	TickSize                    float64              `json:"tickSize"`
+	ContractSize                float64              `json:"contractSize"`
+	ContractValueTradePrecision float64              `json:"contractValueTradePrecision"`
+	ImpactMidSize               float64              `json:"impactMidSize"`
+	MaxPositionSize             float64              `json:"maxPositionSize"`
+	FundingRateCoefficient      float64              `json:"fundingRateCoefficient,omitempty"`
+	MaxRelativeFundingRate      float64              `json:"maxRelativeFundingRate,omitempty"`
+	PostOnly                    bool                 `json:"postOnly"`
+	MarginLevels                []FuturesMarginLevel `json:"marginLevels"`
+	OpeningDate                 time.Time            `json:"openingDate"`
+	Category                    string               `json:"category,omitempty"`
+	Tags                        []string             `json:"tags,omitempty"`
+}
+
+// FuturesInstrumentsResponse - response of instruments
+type FuturesInstrumentsResponse struct {
+	ResponseDerivatives
+	Instruments []FuturesInstrument `json:"instruments"`
+}
+
+// FuturesTicker - ticker of instrument
+type FuturesTicker struct {
+	Symbol                string    `json:"symbol"`
+	Tag                   string    `json:"tag,omitempty"`
+	Pair                  string    `json:"pair,omitempty"`
+	Last                  float64   `json:"last"`
+	LastTime              time.Time `json:"lastTime"`
+	LastSize              float64   `json:"lastSize"`
+	MarkPrice             float64   `json:"markPrice"`
+	IndexPrice            float64   `json:"indexPrice"`
+	Bid                   float64   `json:"bid"`
+	BidSize               float64   `json:"bidSize"`
+	Ask                   float64   `json:"ask"`
+	AskSize               float64   `json:"askSize"`
+	Vol24h                float64   `json:"vol24h"`
+	VolumeQuote           float64   `json:"volumeQuote"`
+	OpenInterest          float64   `json:"openInterest"`
+	Open24h               float64   `json:"open24h"`
+	High24h               float64   `json:"high24h"`
+	Low24h                float64   `json:"low24h"`
+	Change24h             float64   `json:"change24h"`
+	FundingRate           float64   `json:"fundingRate,omitempty"`
+	FundingRatePrediction float64   `json:"fundingRatePrediction,omitempty"`
+	Suspended             bool      `json:"suspended"`
+	PostOnly              bool      `json:"postOnly"`
+}
+
+// FuturesTickersResponse - response of tickers
+type FuturesTickersResponse struct {
+	ResponseDerivatives
+	Tickers []FuturesTicker `json:"tickers"`
+}
+
+// FuturesOrderBookResponse - response of orderbook. Each level is [price, size].
+type FuturesOrderBookResponse struct {
+	ResponseDerivatives
+	OrderBook struct {
+		Bids [][2]float64 `json:"bids"`
+		Asks [][2]float64 `json:"asks"`
+	} `json:"orderBook"`
+}
+
+// FuturesFundingRate - historical funding rate
+type FuturesFundingRate struct {
+	Timestamp           time.Time `json:"timestamp"`
+	FundingRate         float64   `json:"fundingRate"`
+	RelativeFundingRate float64   `json:"relativeFundingRate"`
+}
+
+// FuturesFundingRatesResponse - response of historicalfundingrates
+type FuturesFundingRatesResponse struct {
+	ResponseDerivatives
+	Rates []FuturesFundingRate `json:"rates"`
+}
@@ -3,6 +3,7 @@ package websocket
 // URLs
 const (
 	ProdBaseURL_V2     = "wss://ws.kraken.com/v2"
+	AuthBaseURL_V2     = "wss://ws-auth.kraken.com/v2"
 	ProdBaseURL        = "wss://ws.kraken.com"
 	AuthBaseURL        = "wss://ws-auth.kraken.com"
 	SandboxBaseURL     = "wss://beta-ws.kraken.com"
@@ -58,6 +59,37 @@ const (
 	FUTURES_CANDLES_1D_SNAPSHOT  = "candles_trade_1d_snapshot"
 )
 
+// Spot WS v2 channels
+const (
+	ChanV2Ticker     = "ticker"
+	ChanV2Book       = "book"
+	ChanV2Trade      = "trade"
+	ChanV2OHLC       = "ohlc"
+	ChanV2Instrument = "instrument"
+	ChanV2Executions = "executions"
+	ChanV2Balances   = "balances"
+	ChanV2Heartbeat  = "heartbeat"
+	ChanV2Status     = "status"
+)
+
+// Spot WS v2 methods
+const (
+	MethodSubscribe   = "subscribe"
+	MethodUnsubscribe = "unsubscribe"
+	MethodPing        = "ping"
+	MethodPong        = "pong"
+	MethodAddOrder    = "add_order"
+	MethodAmendOrder  = "amend_order"
+	MethodCancelOrder = "cancel_order"
+	MethodBatchAdd    = "batch_add"
+)
+
+// Spot WS v2 message types
+const (
+	TypeSnapshot = "snapshot"
+	TypeUpdate   = "update"
+)
+
 // Events
 const (
 	EventSubscribe                  = "subscribe"
@@ -137,8 +169,6 @@ const (
 	OrderTypeSettlePosition  = "settle-position"
 )
 
-
-
 // Pairs
 const (
 	ADACAD  = "ADA/CAD"
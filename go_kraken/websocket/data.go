@@ -14,6 +14,7 @@ type Update struct {
 	ChannelName string
 	Pair        string
 	Sequence    Seq
+	Type        string // v2: snapshot / update
 }
 
 // Message - data structure of default Kraken WS update
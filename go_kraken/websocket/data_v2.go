@@ -0,0 +1,190 @@
+package websocket
+
+import (
+	"encoding/json"
+)
+
+// MessageV2 - v2 channel message: {"channel": ..., "type": "snapshot"|"update", "data": [...]}
+type MessageV2 struct {
+	Channel string          `json:"channel"`
+	Type    string          `json:"type"`
+	Data    json.RawMessage `json:"data"`
+
+	// set on responses to requests
+	Method string `json:"method"`
+	ReqID  int64  `json:"req_id"`
+}
+
+func (msg MessageV2) toUpdate(pair string, data interface{}) Update {
+	return Update{
+		ChannelName: msg.Channel,
+		Pair:        pair,
+		Type:        msg.Type,
+		Data:        data,
+	}
+}
+
+// TickerV2 - data structure of `ticker` channel
+type TickerV2 struct {
+	Symbol    string      `json:"symbol"`
+	Bid       json.Number `json:"bid"`
+	BidQty    json.Number `json:"bid_qty"`
+	Ask       json.Number `json:"ask"`
+	AskQty    json.Number `json:"ask_qty"`
+	Last      json.Number `json:"last"`
+	Volume    json.Number `json:"volume"`
+	VWAP      json.Number `json:"vwap"`
+	Low       json.Number `json:"low"`
+	High      json.Number `json:"high"`
+	Change    json.Number `json:"change"`
+	ChangePct json.Number `json:"change_pct"`
+}
+
+// BookLevelV2 - price level of `book` channel
+type BookLevelV2 struct {
+	Price json.Number `json:"price"`
+	Qty   json.Number `json:"qty"`
+}
+
+// BookV2 - data structure of `book` channel. Qty 0 in update means the level is removed.
+type BookV2 struct {
+	Symbol    string        `json:"symbol"`
+	Bids      []BookLevelV2 `json:"bids"`
+	Asks      []BookLevelV2 `json:"asks"`
+	Checksum  uint32        `json:"checksum"`
+	Timestamp string        `json:"timestamp,omitempty"`
+}
+
+// TradeV2 - data structure of `trade` channel
+type TradeV2 struct {
+	Symbol    string      `json:"symbol"`
+	Side      string      `json:"side"`
+	Price     json.Number `json:"price"`
+	Qty       json.Number `json:"qty"`
+	OrdType   string      `json:"ord_type"`
+	TradeID   int64       `json:"trade_id"`
+	Timestamp string      `json:"timestamp"`
+}
+
+// OHLCV2 - data structure of `ohlc` channel
+type OHLCV2 struct {
+	Symbol        string      `json:"symbol"`
+	Open          json.Number `json:"open"`
+	High          json.Number `json:"high"`
+	Low           json.Number `json:"low"`
+	Close         json.Number `json:"close"`
+	Trades        int64       `json:"trades"`
+	Volume        json.Number `json:"volume"`
+	VWAP          json.Number `json:"vwap"`
+	IntervalBegin string      `json:"interval_begin"`
+	Interval      int64       `json:"interval"`
+	Timestamp     string      `json:"timestamp"`
+}
+
+// InstrumentAssetV2 - asset of `instrument` channel
+type InstrumentAssetV2 struct {
+	ID               string      `json:"id"`
+	Status           string      `json:"status"`
+	Precision        int         `json:"precision"`
+	PrecisionDisplay int         `json:"precision_display"`
+	Borrowable       bool        `json:"borrowable"`
+	CollateralValue  json.Number `json:"collateral_value"`
+	MarginRate       json.Number `json:"margin_rate"`
+}
+
+// InstrumentPairV2 - pair of `instrument` channel
+type InstrumentPairV2 struct {
+	Symbol             string      `json:"symbol"`
+	Base               string      `json:"base"`
+	Quote              string      `json:"quote"`
+	Status             string      `json:"status"`
+	QtyPrecision       int         `json:"qty_precision"`
+	QtyIncrement       json.Number `json:"qty_increment"`
+	PricePrecision     int         `json:"price_precision"`
+	PriceIncrement     json.Number `json:"price_increment"`
+	CostPrecision      int         `json:"cost_precision"`
+	CostMin            json.Number `json:"cost_min"`
+	QtyMin             json.Number `json:"qty_min"`
+	Marginable         bool        `json:"marginable"`
+	HasIndex           bool        `json:"has_index"`
+	MarginInitial      json.Number `json:"margin_initial"`
+	PositionLimitLong  int64       `json:"position_limit_long"`
+	PositionLimitShort int64       `json:"position_limit_short"`
+}
+
+// InstrumentV2 - data structure of `instrument` channel (data is an object, not an array)
+type InstrumentV2 struct {
+	Assets []InstrumentAssetV2 `json:"assets"`
+	Pairs  []InstrumentPairV2  `json:"pairs"`
+}
+
+// FeeV2 - fee of execution
+type FeeV2 struct {
+	Asset string      `json:"asset"`
+	Qty   json.Number `json:"qty"`
+}
+
+// ExecutionV2 - data structure of `executions` channel. Fields depend on ExecType.
+type ExecutionV2 struct {
+	ExecType     string      `json:"exec_type"` // pending_new / new / trade / filled / canceled / expired / amended / restated / status
+	ExecID       string      `json:"exec_id,omitempty"`
+	TradeID      int64       `json:"trade_id,omitempty"`
+	OrderID      string      `json:"order_id"`
+	ClOrdID      string      `json:"cl_ord_id,omitempty"`
+	OrderUserref int64       `json:"order_userref,omitempty"`
+	Symbol       string      `json:"symbol,omitempty"`
+	Side         string      `json:"side,omitempty"`
+	OrderType    string      `json:"order_type,omitempty"`
+	OrderQty     json.Number `json:"order_qty,omitempty"`
+	LimitPrice   json.Number `json:"limit_price,omitempty"`
+	TimeInForce  string      `json:"time_in_force,omitempty"`
+	OrderStatus  string      `json:"order_status,omitempty"`
+	CumQty       json.Number `json:"cum_qty,omitempty"`
+	CumCost      json.Number `json:"cum_cost,omitempty"`
+	AvgPrice     json.Number `json:"avg_price,omitempty"`
+	LastQty      json.Number `json:"last_qty,omitempty"`
+	LastPrice    json.Number `json:"last_price,omitempty"`
+	Cost         json.Number `json:"cost,omitempty"`
+	LiquidityInd string      `json:"liquidity_ind,omitempty"` // t / m
+	FeeUsdEquiv  json.Number `json:"fee_usd_equiv,omitempty"`
+	Fees         []FeeV2     `json:"fees,omitempty"`
+	PostOnly     bool        `json:"post_only,omitempty"`
+	ReduceOnly   bool        `json:"reduce_only,omitempty"`
+	Reason       string      `json:"reason,omitempty"`
+	Timestamp    string      `json:"timestamp"`
+}
+
+// WalletV2 - wallet of balances snapshot
+type WalletV2 struct {
+	Type    string      `json:"type"`
+	ID      string      `json:"id"`
+	Balance json.Number `json:"balance"`
+}
+
+// BalanceV2 - data structure of `balances` channel.
+// Snapshot fills Wallets, update fills ledger fields (LedgerID, Type, Amount, Fee ...).
+type BalanceV2 struct {
+	Asset      string      `json:"asset"`
+	AssetClass string      `json:"asset_class"`
+	Balance    json.Number `json:"balance"`
+	Wallets    []WalletV2  `json:"wallets,omitempty"`
+
+	LedgerID   string      `json:"ledger_id,omitempty"`
+	RefID      string      `json:"ref_id,omitempty"`
+	Timestamp  string      `json:"timestamp,omitempty"`
+	Type       string      `json:"type,omitempty"`
+	Subtype    string      `json:"subtype,omitempty"`
+	Category   string      `json:"category,omitempty"`
+	Amount     json.Number `json:"amount,omitempty"`
+	Fee        json.Number `json:"fee,omitempty"`
+	WalletType string      `json:"wallet_type,omitempty"`
+	WalletID   string      `json:"wallet_id,omitempty"`
+}
+
+// StatusV2 - data structure of `status` channel
+type StatusV2 struct {
+	System       string `json:"system"`
+	APIVersion   string `json:"api_version"`
+	ConnectionID uint64 `json:"connection_id"`
+	Version      string `json:"version"`
+}
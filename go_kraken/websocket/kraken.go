@@ -34,6 +34,10 @@ type Kraken struct {
 	lastPong  atomic.Int64  // UnixNano
 	reconnect chan struct{} // 长驻、缓冲 1
 	wg        sync.WaitGroup
+
+	// v2: req_id -> chan ResponseV2
+	reqID   atomic.Int64
+	pending sync.Map
 }
 
 // NewKraken -
@@ -164,7 +168,11 @@ func (k *Kraken) managerThread() {
 				continue
 			}
 
-			if err := k.send(PingRequest{Event: EventPing}); err != nil {
+			var ping any = PingRequest{Event: EventPing}
+			if k.isV2() {
+				ping = RequestV2{Method: MethodPing}
+			}
+			if err := k.send(ping); err != nil {
 				zap.S().Error(err)
 				k.triggerReconnect()
 			}
@@ -258,6 +266,9 @@ func (k *Kraken) handleMessage(data []byte) error {
 	if ProdBaseFuturesURL == k.url {
 		return k.handleFuturesEvent(data)
 	}
+	if k.isV2() {
+		return k.handleV2Message(data)
+	}
 
 	switch data[0] {
 	case '[':
@@ -0,0 +1,291 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// v2RequestTimeout - default timeout of v2 request when ctx has no deadline
+const v2RequestTimeout = 5 * time.Second
+
+// Spot WS v2 client uses the same `Kraken` connection manager:
+//
+//	k := NewKraken(AuthBaseURL_V2)
+//	k.Authenticate(key, secret) // token for executions / balances / trading
+//	k.Connect()
+//	k.SubscribeExecutionsV2(ctx)
+//	k.AddOrderV2(ctx, AddOrderParamsV2{...})
+//
+// Channel data is published to `Listen()` as `Update` with `Type` set to snapshot / update.
+
+func (k *Kraken) isV2() bool {
+	return k.url == ProdBaseURL_V2 || k.url == AuthBaseURL_V2
+}
+
+func (k *Kraken) handleV2Message(data []byte) error {
+	var msg MessageV2
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+	if msg.Method != "" {
+		return k.handleV2Response(data)
+	}
+
+	switch msg.Channel {
+	case ChanV2Heartbeat:
+	case ChanV2Status:
+		var status []StatusV2
+		if err := json.Unmarshal(msg.Data, &status); err != nil {
+			return err
+		}
+		for _, s := range status {
+			zap.S().Infof("Status: %s, API version: %s, Connection ID: %d", s.System, s.APIVersion, s.ConnectionID)
+		}
+	case ChanV2Ticker:
+		var tickers []TickerV2
+		if err := json.Unmarshal(msg.Data, &tickers); err != nil {
+			return err
+		}
+		for _, t := range tickers {
+			k.msg <- msg.toUpdate(t.Symbol, t)
+		}
+	case ChanV2Book:
+		var books []BookV2
+		if err := json.Unmarshal(msg.Data, &books); err != nil {
+			return err
+		}
+		for _, b := range books {
+			k.msg <- msg.toUpdate(b.Symbol, b)
+		}
+	case ChanV2Trade:
+		var trades []TradeV2
+		if err := json.Unmarshal(msg.Data, &trades); err != nil {
+			return err
+		}
+		if len(trades) > 0 {
+			k.msg <- msg.toUpdate(trades[0].Symbol, trades)
+		}
+	case ChanV2OHLC:
+		var candles []OHLCV2
+		if err := json.Unmarshal(msg.Data, &candles); err != nil {
+			return err
+		}
+		for _, c := range candles {
+			k.msg <- msg.toUpdate(c.Symbol, c)
+		}
+	case ChanV2Instrument:
+		var instrument InstrumentV2
+		if err := json.Unmarshal(msg.Data, &instrument); err != nil {
+			return err
+		}
+		k.msg <- msg.toUpdate("", instrument)
+	case ChanV2Executions:
+		var executions []ExecutionV2
+		if err := json.Unmarshal(msg.Data, &executions); err != nil {
+			return err
+		}
+		k.msg <- msg.toUpdate("", executions)
+	case ChanV2Balances:
+		var balances []BalanceV2
+		if err := json.Unmarshal(msg.Data, &balances); err != nil {
+			return err
+		}
+		k.msg <- msg.toUpdate("", balances)
+	default:
+		zap.S().Warnf("unknown message: %s", data)
+	}
+	return nil
+}
+
+func (k *Kraken) handleV2Response(data []byte) error {
+	var resp ResponseV2
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	if resp.ReqID != 0 {
+		if ch, ok := k.pending.Load(resp.ReqID); ok {
+			select {
+			case ch.(chan ResponseV2) <- resp:
+			default:
+				zap.S().Warnf("response dropped: %s", data)
+			}
+			return nil
+		}
+	}
+	if !resp.Success && resp.Error != "" {
+		zap.S().Errorf("%s: %s", resp.Method, resp.Error)
+	}
+	return nil
+}
+
+// requestV2 - sends request and waits for `expect` responses with the same req_id
+func (k *Kraken) requestV2(ctx context.Context, method string, params interface{}, expect int) ([]ResponseV2, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, v2RequestTimeout)
+		defer cancel()
+	}
+	if expect < 1 {
+		expect = 1
+	}
+
+	id := k.reqID.Add(1)
+	ch := make(chan ResponseV2, expect)
+	k.pending.Store(id, ch)
+	defer k.pending.Delete(id)
+
+	if err := k.send(RequestV2{Method: method, Params: params, ReqID: id}); err != nil {
+		return nil, err
+	}
+
+	responses := make([]ResponseV2, 0, expect)
+	for len(responses) < expect {
+		select {
+		case resp := <-ch:
+			if !resp.Success {
+				return responses, &ErrorV2{Method: method, ReqID: id, Message: resp.Error}
+			}
+			responses = append(responses, resp)
+		case <-ctx.Done():
+			return responses, errors.Wrapf(ctx.Err(), "%s (req_id %d)", method, id)
+		}
+	}
+	return responses, nil
+}
+
+func decodeResultsV2[T any](responses []ResponseV2) ([]T, error) {
+	results := make([]T, 0, len(responses))
+	for _, resp := range responses {
+		var result T
+		if len(resp.Result) > 0 {
+			if err := json.Unmarshal(resp.Result, &result); err != nil {
+				return results, err
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// SubscribeV2 - subscribes to channel. Kraken acknowledges each symbol separately, so one result per symbol is returned.
+func (k *Kraken) SubscribeV2(ctx context.Context, params SubscribeParamsV2) ([]SubscribeResultV2, error) {
+	if params.Token == "" && isPrivateV2(params.Channel) {
+		params.Token = k.token
+	}
+	responses, err := k.requestV2(ctx, MethodSubscribe, params, len(params.Symbol))
+	if err != nil {
+		return nil, err
+	}
+	return decodeResultsV2[SubscribeResultV2](responses)
+}
+
+// UnsubscribeV2 - unsubscribes from channel
+func (k *Kraken) UnsubscribeV2(ctx context.Context, params SubscribeParamsV2) ([]SubscribeResultV2, error) {
+	if params.Token == "" && isPrivateV2(params.Channel) {
+		params.Token = k.token
+	}
+	responses, err := k.requestV2(ctx, MethodUnsubscribe, params, len(params.Symbol))
+	if err != nil {
+		return nil, err
+	}
+	return decodeResultsV2[SubscribeResultV2](responses)
+}
+
+func isPrivateV2(channel string) bool {
+	return channel == ChanV2Executions || channel == ChanV2Balances
+}
+
+// SubscribeTickerV2 -
+func (k *Kraken) SubscribeTickerV2(ctx context.Context, symbols []string) ([]SubscribeResultV2, error) {
+	return k.SubscribeV2(ctx, SubscribeParamsV2{Channel: ChanV2Ticker, Symbol: symbols})
+}
+
+// SubscribeBookV2 - depth: 10, 25, 100, 500, 1000
+func (k *Kraken) SubscribeBookV2(ctx context.Context, symbols []string, depth int64) ([]SubscribeResultV2, error) {
+	return k.SubscribeV2(ctx, SubscribeParamsV2{Channel: ChanV2Book, Symbol: symbols, Depth: depth})
+}
+
+// SubscribeTradeV2 -
+func (k *Kraken) SubscribeTradeV2(ctx context.Context, symbols []string) ([]SubscribeResultV2, error) {
+	return k.SubscribeV2(ctx, SubscribeParamsV2{Channel: ChanV2Trade, Symbol: symbols})
+}
+
+// SubscribeOHLCV2 - interval in minutes: 1, 5, 15, 30, 60, 240, 1440, 10080, 21600
+func (k *Kraken) SubscribeOHLCV2(ctx context.Context, symbols []string, interval int64) ([]SubscribeResultV2, error) {
+	return k.SubscribeV2(ctx, SubscribeParamsV2{Channel: ChanV2OHLC, Symbol: symbols, Interval: interval})
+}
+
+// SubscribeInstrumentV2 -
+func (k *Kraken) SubscribeInstrumentV2(ctx context.Context) ([]SubscribeResultV2, error) {
+	return k.SubscribeV2(ctx, SubscribeParamsV2{Channel: ChanV2Instrument})
+}
+
+// SubscribeExecutionsV2 - requires `Authenticate`
+func (k *Kraken) SubscribeExecutionsV2(ctx context.Context) ([]SubscribeResultV2, error) {
+	return k.SubscribeV2(ctx, SubscribeParamsV2{Channel: ChanV2Executions})
+}
+
+// SubscribeBalancesV2 - requires `Authenticate`
+func (k *Kraken) SubscribeBalancesV2(ctx context.Context) ([]SubscribeResultV2, error) {
+	return k.SubscribeV2(ctx, SubscribeParamsV2{Channel: ChanV2Balances})
+}
+
+// AddOrderV2 -
+func (k *Kraken) AddOrderV2(ctx context.Context, params AddOrderParamsV2) (AddOrderResultV2, error) {
+	if params.Token == "" {
+		params.Token = k.token
+	}
+	var result AddOrderResultV2
+	responses, err := k.requestV2(ctx, MethodAddOrder, params, 1)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(responses[0].Result, &result)
+	return result, err
+}
+
+// AmendOrderV2 -
+func (k *Kraken) AmendOrderV2(ctx context.Context, params AmendOrderParamsV2) (AmendOrderResultV2, error) {
+	if params.Token == "" {
+		params.Token = k.token
+	}
+	var result AmendOrderResultV2
+	responses, err := k.requestV2(ctx, MethodAmendOrder, params, 1)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(responses[0].Result, &result)
+	return result, err
+}
+
+// CancelOrderV2 - Kraken answers each cancelled order separately, results are returned in arrival order
+func (k *Kraken) CancelOrderV2(ctx context.Context, params CancelOrderParamsV2) ([]CancelOrderResultV2, error) {
+	if params.Token == "" {
+		params.Token = k.token
+	}
+	expect := len(params.OrderID) + len(params.ClOrdID) + len(params.OrderUserref)
+	responses, err := k.requestV2(ctx, MethodCancelOrder, params, expect)
+	results, decodeErr := decodeResultsV2[CancelOrderResultV2](responses)
+	if err != nil {
+		return results, err
+	}
+	return results, decodeErr
+}
+
+// BatchAddV2 -
+func (k *Kraken) BatchAddV2(ctx context.Context, params BatchAddParamsV2) ([]AddOrderResultV2, error) {
+	if params.Token == "" {
+		params.Token = k.token
+	}
+	var results []AddOrderResultV2
+	responses, err := k.requestV2(ctx, MethodBatchAdd, params, 1)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(responses[0].Result, &results)
+	return results, err
+}
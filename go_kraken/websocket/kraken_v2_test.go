@@ -0,0 +1,339 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestKraken_handleV2Message(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []Update
+		wantErr bool
+	}{
+		{
+			name: "ticker snapshot",
+			data: `{"channel":"ticker","type":"snapshot","data":[{"symbol":"BTC/USD","bid":63000.1,"bid_qty":0.5,"ask":63000.2,"ask_qty":1.25,"last":63000.1,"volume":1500.5,"vwap":62800.3,"low":62000,"high":64000,"change":100.1,"change_pct":0.16}]}`,
+			want: []Update{
+				{
+					ChannelName: ChanV2Ticker,
+					Pair:        "BTC/USD",
+					Type:        TypeSnapshot,
+					Data: TickerV2{
+						Symbol:    "BTC/USD",
+						Bid:       "63000.1",
+						BidQty:    "0.5",
+						Ask:       "63000.2",
+						AskQty:    "1.25",
+						Last:      "63000.1",
+						Volume:    "1500.5",
+						VWAP:      "62800.3",
+						Low:       "62000",
+						High:      "64000",
+						Change:    "100.1",
+						ChangePct: "0.16",
+					},
+				},
+			},
+		},
+		{
+			name: "book update",
+			data: `{"channel":"book","type":"update","data":[{"symbol":"BTC/USD","bids":[{"price":63000.1,"qty":0}],"asks":[{"price":63000.5,"qty":0.1}],"checksum":2439117997,"timestamp":"2024-05-01T10:00:00.000000Z"}]}`,
+			want: []Update{
+				{
+					ChannelName: ChanV2Book,
+					Pair:        "BTC/USD",
+					Type:        TypeUpdate,
+					Data: BookV2{
+						Symbol:    "BTC/USD",
+						Bids:      []BookLevelV2{{Price: "63000.1", Qty: "0"}},
+						Asks:      []BookLevelV2{{Price: "63000.5", Qty: "0.1"}},
+						Checksum:  2439117997,
+						Timestamp: "2024-05-01T10:00:00.000000Z",
+					},
+				},
+			},
+		},
+		{
+			name: "trades are published together",
+			data: `{"channel":"trade","type":"update","data":[{"symbol":"ETH/USD","side":"buy","price":3000.5,"qty":0.1,"ord_type":"market","trade_id":1,"timestamp":"2024-05-01T10:00:00.000000Z"},{"symbol":"ETH/USD","side":"sell","price":3000.4,"qty":0.2,"ord_type":"limit","trade_id":2,"timestamp":"2024-05-01T10:00:00.100000Z"}]}`,
+			want: []Update{
+				{
+					ChannelName: ChanV2Trade,
+					Pair:        "ETH/USD",
+					Type:        TypeUpdate,
+					Data: []TradeV2{
+						{Symbol: "ETH/USD", Side: SideBuy, Price: "3000.5", Qty: "0.1", OrdType: OrderTypeMarket, TradeID: 1, Timestamp: "2024-05-01T10:00:00.000000Z"},
+						{Symbol: "ETH/USD", Side: SideSell, Price: "3000.4", Qty: "0.2", OrdType: OrderTypeLimit, TradeID: 2, Timestamp: "2024-05-01T10:00:00.100000Z"},
+					},
+				},
+			},
+		},
+		{
+			name: "instrument data is an object",
+			data: `{"channel":"instrument","type":"snapshot","data":{"assets":[{"id":"USD","status":"enabled","precision":4,"precision_display":2,"borrowable":true,"collateral_value":1,"margin_rate":0.025}],"pairs":[{"symbol":"BTC/USD","base":"BTC","quote":"USD","status":"online","qty_precision":8,"qty_increment":0.00000001,"price_precision":1,"price_increment":0.1,"cost_precision":5,"cost_min":0.5,"qty_min":0.0001,"marginable":true,"has_index":true}]}}`,
+			want: []Update{
+				{
+					ChannelName: ChanV2Instrument,
+					Type:        TypeSnapshot,
+					Data: InstrumentV2{
+						Assets: []InstrumentAssetV2{{ID: "USD", Status: "enabled", Precision: 4, PrecisionDisplay: 2, Borrowable: true, CollateralValue: "1", MarginRate: "0.025"}},
+						Pairs:  []InstrumentPairV2{{Symbol: "BTC/USD", Base: "BTC", Quote: "USD", Status: "online", QtyPrecision: 8, QtyIncrement: "0.00000001", PricePrecision: 1, PriceIncrement: "0.1", CostPrecision: 5, CostMin: "0.5", QtyMin: "0.0001", Marginable: true, HasIndex: true}},
+					},
+				},
+			},
+		},
+		{
+			name: "executions",
+			data: `{"channel":"executions","type":"update","data":[{"order_id":"OAAAAA-BBBBB-CCCCCC","exec_type":"trade","exec_id":"TAAAAA-BBBBB-CCCCCC","symbol":"BTC/USD","side":"buy","last_qty":0.01,"last_price":63000,"liquidity_ind":"m","cost":630,"order_status":"partially_filled","order_type":"limit","fees":[{"asset":"USD","qty":1.008}],"timestamp":"2024-05-01T10:00:00.000000Z"}]}`,
+			want: []Update{
+				{
+					ChannelName: ChanV2Executions,
+					Type:        TypeUpdate,
+					Data: []ExecutionV2{
+						{
+							ExecType:     "trade",
+							ExecID:       "TAAAAA-BBBBB-CCCCCC",
+							OrderID:      "OAAAAA-BBBBB-CCCCCC",
+							Symbol:       "BTC/USD",
+							Side:         SideBuy,
+							OrderType:    OrderTypeLimit,
+							OrderStatus:  "partially_filled",
+							LastQty:      "0.01",
+							LastPrice:    "63000",
+							Cost:         "630",
+							LiquidityInd: "m",
+							Fees:         []FeeV2{{Asset: "USD", Qty: "1.008"}},
+							Timestamp:    "2024-05-01T10:00:00.000000Z",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "balances snapshot",
+			data: `{"channel":"balances","type":"snapshot","data":[{"asset":"BTC","asset_class":"currency","balance":1.5,"wallets":[{"type":"spot","id":"main","balance":1.5}]}]}`,
+			want: []Update{
+				{
+					ChannelName: ChanV2Balances,
+					Type:        TypeSnapshot,
+					Data: []BalanceV2{
+						{Asset: "BTC", AssetClass: "currency", Balance: "1.5", Wallets: []WalletV2{{Type: "spot", ID: "main", Balance: "1.5"}}},
+					},
+				},
+			},
+		},
+		{
+			name: "heartbeat is skipped",
+			data: `{"channel":"heartbeat"}`,
+		},
+		{
+			name: "unmatched response is skipped",
+			data: `{"method":"subscribe","req_id":42,"success":false,"error":"Currency pair not supported"}`,
+		},
+		{
+			name:    "invalid data",
+			data:    `{"channel":"ticker","type":"update","data":{"symbol":1}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := NewKraken(ProdBaseURL_V2)
+			if err := k.handleMessage([]byte(tt.data)); (err != nil) != tt.wantErr {
+				t.Errorf("handleMessage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var got []Update
+			for len(k.msg) > 0 {
+				got = append(got, <-k.msg)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("handleMessage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newV2TestServer - answers each request with responses built by reply
+func newV2TestServer(t *testing.T, reply func(req RequestV2) []string) *Kraken {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req RequestV2
+			if err := json.Unmarshal(data, &req); err != nil {
+				return
+			}
+			for _, resp := range reply(req) {
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(resp)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := NewKraken(AuthBaseURL_V2)
+	k.token = "token"
+	k.conn = conn
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		k.safeCloseConn()
+	})
+	k.wg.Add(1)
+	go k.listenSocket(ctx)
+	return k
+}
+
+func TestKraken_AddOrderV2(t *testing.T) {
+	var params json.RawMessage
+	k := newV2TestServer(t, func(req RequestV2) []string {
+		params, _ = json.Marshal(req.Params)
+		id, _ := json.Marshal(req.ReqID)
+		return []string{
+			`{"method":"add_order","req_id":` + string(id) + `,"result":{"order_id":"OAAAAA-BBBBB-CCCCCC","cl_ord_id":"my-1"},"success":true,"time_in":"2024-05-01T10:00:00.000000Z","time_out":"2024-05-01T10:00:00.001000Z"}`,
+		}
+	})
+
+	got, err := k.AddOrderV2(context.Background(), AddOrderParamsV2{
+		OrderType:  OrderTypeLimit,
+		Side:       SideBuy,
+		OrderQty:   0.01,
+		Symbol:     "BTC/USD",
+		LimitPrice: 60000,
+		ClOrdID:    "my-1",
+	})
+	if err != nil {
+		t.Fatalf("AddOrderV2() error = %v", err)
+	}
+	want := AddOrderResultV2{OrderID: "OAAAAA-BBBBB-CCCCCC", ClOrdID: "my-1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AddOrderV2() = %v, want %v", got, want)
+	}
+	wantParams := `{"cl_ord_id":"my-1","limit_price":60000,"order_qty":0.01,"order_type":"limit","side":"buy","symbol":"BTC/USD","token":"token"}`
+	if string(params) != wantParams {
+		t.Errorf("AddOrderV2() params = %s, want %s", params, wantParams)
+	}
+}
+
+func TestKraken_AmendOrderV2_Error(t *testing.T) {
+	k := newV2TestServer(t, func(req RequestV2) []string {
+		id, _ := json.Marshal(req.ReqID)
+		return []string{
+			`{"method":"amend_order","req_id":` + string(id) + `,"success":false,"error":"EOrder:Unknown order","time_in":"","time_out":""}`,
+		}
+	})
+
+	_, err := k.AmendOrderV2(context.Background(), AmendOrderParamsV2{OrderID: "OAAAAA", LimitPrice: 61000})
+	var apiErr *ErrorV2
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("AmendOrderV2() error = %v, want *ErrorV2", err)
+	}
+	if apiErr.Method != MethodAmendOrder || apiErr.Message != "EOrder:Unknown order" {
+		t.Errorf("AmendOrderV2() error = %+v", apiErr)
+	}
+}
+
+func TestKraken_CancelOrderV2(t *testing.T) {
+	k := newV2TestServer(t, func(req RequestV2) []string {
+		id, _ := json.Marshal(req.ReqID)
+		return []string{
+			`{"method":"cancel_order","req_id":` + string(id) + `,"result":{"order_id":"O1"},"success":true}`,
+			`{"method":"cancel_order","req_id":` + string(id) + `,"result":{"order_id":"O2"},"success":true}`,
+		}
+	})
+
+	got, err := k.CancelOrderV2(context.Background(), CancelOrderParamsV2{OrderID: []string{"O1", "O2"}})
+	if err != nil {
+		t.Fatalf("CancelOrderV2() error = %v", err)
+	}
+	want := []CancelOrderResultV2{{OrderID: "O1"}, {OrderID: "O2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CancelOrderV2() = %v, want %v", got, want)
+	}
+}
+
+func TestKraken_BatchAddV2(t *testing.T) {
+	k := newV2TestServer(t, func(req RequestV2) []string {
+		id, _ := json.Marshal(req.ReqID)
+		return []string{
+			`{"method":"batch_add","req_id":` + string(id) + `,"result":[{"order_id":"O1"},{"order_id":"O2"}],"success":true}`,
+		}
+	})
+
+	got, err := k.BatchAddV2(context.Background(), BatchAddParamsV2{
+		Symbol: "BTC/USD",
+		Orders: []BatchOrderV2{
+			{OrderType: OrderTypeLimit, Side: SideBuy, OrderQty: 0.01, LimitPrice: 60000},
+			{OrderType: OrderTypeLimit, Side: SideSell, OrderQty: 0.01, LimitPrice: 70000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchAddV2() error = %v", err)
+	}
+	want := []AddOrderResultV2{{OrderID: "O1"}, {OrderID: "O2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BatchAddV2() = %v, want %v", got, want)
+	}
+}
+
+func TestKraken_SubscribeV2(t *testing.T) {
+	k := newV2TestServer(t, func(req RequestV2) []string {
+		id, _ := json.Marshal(req.ReqID)
+		return []string{
+			`{"method":"subscribe","req_id":` + string(id) + `,"result":{"channel":"book","symbol":"BTC/USD","depth":10,"snapshot":true},"success":true}`,
+			`{"method":"subscribe","req_id":` + string(id) + `,"result":{"channel":"book","symbol":"ETH/USD","depth":10,"snapshot":true},"success":true}`,
+		}
+	})
+
+	got, err := k.SubscribeBookV2(context.Background(), []string{"BTC/USD", "ETH/USD"}, Depth10)
+	if err != nil {
+		t.Fatalf("SubscribeBookV2() error = %v", err)
+	}
+	want := []SubscribeResultV2{
+		{Channel: ChanV2Book, Symbol: "BTC/USD", Depth: Depth10, Snapshot: true},
+		{Channel: ChanV2Book, Symbol: "ETH/USD", Depth: Depth10, Snapshot: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SubscribeBookV2() = %v, want %v", got, want)
+	}
+}
+
+func TestKraken_requestV2_Timeout(t *testing.T) {
+	k := newV2TestServer(t, func(req RequestV2) []string {
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := k.AddOrderV2(ctx, AddOrderParamsV2{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("AddOrderV2() error = %v, want deadline exceeded", err)
+	}
+	if _, ok := k.pending.Load(k.reqID.Load()); ok {
+		t.Error("pending request was not removed")
+	}
+}
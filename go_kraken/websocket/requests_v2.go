@@ -0,0 +1,163 @@
+package websocket
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RequestV2 - v2 request envelope: {"method": ..., "params": {...}, "req_id": ...}
+type RequestV2 struct {
+	Method string      `json:"method"`
+	Params interface{} `json:"params,omitempty"`
+	ReqID  int64       `json:"req_id,omitempty"`
+}
+
+// ResponseV2 - v2 response to a request, matched by `req_id`
+type ResponseV2 struct {
+	Method  string          `json:"method"`
+	ReqID   int64           `json:"req_id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Success bool            `json:"success"`
+	Error   string          `json:"error,omitempty"`
+	TimeIn  string          `json:"time_in"`
+	TimeOut string          `json:"time_out"`
+}
+
+// ErrorV2 - error returned by v2 request with `success: false`
+type ErrorV2 struct {
+	Method  string
+	ReqID   int64
+	Message string
+}
+
+func (e *ErrorV2) Error() string {
+	return fmt.Sprintf("%s (req_id %d): %s", e.Method, e.ReqID, e.Message)
+}
+
+// SubscribeParamsV2 - params for subscribe/unsubscribe. Token is required for `executions` and `balances`.
+type SubscribeParamsV2 struct {
+	Channel      string   `json:"channel"`
+	Symbol       []string `json:"symbol,omitempty"`
+	Depth        int64    `json:"depth,omitempty"`
+	Interval     int64    `json:"interval,omitempty"`
+	Snapshot     *bool    `json:"snapshot,omitempty"`
+	EventTrigger string   `json:"event_trigger,omitempty"`
+	SnapOrders   *bool    `json:"snap_orders,omitempty"`
+	SnapTrades   *bool    `json:"snap_trades,omitempty"`
+	OrderStatus  *bool    `json:"order_status,omitempty"`
+	RateCounter  *bool    `json:"ratecounter,omitempty"`
+	Token        string   `json:"token,omitempty"`
+}
+
+// SubscribeResultV2 - result of subscribe/unsubscribe request
+type SubscribeResultV2 struct {
+	Channel  string `json:"channel"`
+	Symbol   string `json:"symbol,omitempty"`
+	Depth    int64  `json:"depth,omitempty"`
+	Interval int64  `json:"interval,omitempty"`
+	Snapshot bool   `json:"snapshot"`
+}
+
+// TriggerParamsV2 - trigger for stop-loss / take-profit orders
+type TriggerParamsV2 struct {
+	Reference string  `json:"reference,omitempty"` // last / index
+	Price     float64 `json:"price"`
+	PriceType string  `json:"price_type,omitempty"` // static / pct / quote
+}
+
+// AddOrderParamsV2 - params of `add_order`
+type AddOrderParamsV2 struct {
+	OrderType     string           `json:"order_type"`
+	Side          string           `json:"side"`
+	OrderQty      float64          `json:"order_qty"`
+	Symbol        string           `json:"symbol"`
+	LimitPrice    float64          `json:"limit_price,omitempty"`
+	TimeInForce   string           `json:"time_in_force,omitempty"` // gtc / gtd / ioc
+	Margin        bool             `json:"margin,omitempty"`
+	PostOnly      bool             `json:"post_only,omitempty"`
+	ReduceOnly    bool             `json:"reduce_only,omitempty"`
+	ExpireTime    string           `json:"expire_time,omitempty"`
+	Deadline      string           `json:"deadline,omitempty"`
+	ClOrdID       string           `json:"cl_ord_id,omitempty"`
+	OrderUserref  int64            `json:"order_userref,omitempty"`
+	DisplayQty    float64          `json:"display_qty,omitempty"`
+	FeePreference string           `json:"fee_preference,omitempty"` // base / quote
+	StpType       string           `json:"stp_type,omitempty"`
+	CashOrderQty  float64          `json:"cash_order_qty,omitempty"`
+	Validate      bool             `json:"validate,omitempty"`
+	Triggers      *TriggerParamsV2 `json:"triggers,omitempty"`
+	Token         string           `json:"token"`
+}
+
+// AddOrderResultV2 - result of `add_order`
+type AddOrderResultV2 struct {
+	OrderID      string   `json:"order_id"`
+	ClOrdID      string   `json:"cl_ord_id,omitempty"`
+	OrderUserref int64    `json:"order_userref,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"`
+}
+
+// AmendOrderParamsV2 - params of `amend_order`. One of OrderID or ClOrdID is required.
+type AmendOrderParamsV2 struct {
+	OrderID          string  `json:"order_id,omitempty"`
+	ClOrdID          string  `json:"cl_ord_id,omitempty"`
+	OrderQty         float64 `json:"order_qty,omitempty"`
+	DisplayQty       float64 `json:"display_qty,omitempty"`
+	LimitPrice       float64 `json:"limit_price,omitempty"`
+	LimitPriceType   string  `json:"limit_price_type,omitempty"`
+	PostOnly         bool    `json:"post_only,omitempty"`
+	TriggerPrice     float64 `json:"trigger_price,omitempty"`
+	TriggerPriceType string  `json:"trigger_price_type,omitempty"`
+	Deadline         string  `json:"deadline,omitempty"`
+	Token            string  `json:"token"`
+}
+
+// AmendOrderResultV2 - result of `amend_order`
+type AmendOrderResultV2 struct {
+	AmendID  string   `json:"amend_id"`
+	OrderID  string   `json:"order_id,omitempty"`
+	ClOrdID  string   `json:"cl_ord_id,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// CancelOrderParamsV2 - params of `cancel_order`
+type CancelOrderParamsV2 struct {
+	OrderID      []string `json:"order_id,omitempty"`
+	ClOrdID      []string `json:"cl_ord_id,omitempty"`
+	OrderUserref []int64  `json:"order_userref,omitempty"`
+	Token        string   `json:"token"`
+}
+
+// CancelOrderResultV2 - result of `cancel_order`, one per cancelled order
+type CancelOrderResultV2 struct {
+	OrderID  string   `json:"order_id"`
+	ClOrdID  string   `json:"cl_ord_id,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// BatchOrderV2 - single order of `batch_add`
+type BatchOrderV2 struct {
+	OrderType    string           `json:"order_type"`
+	Side         string           `json:"side"`
+	OrderQty     float64          `json:"order_qty"`
+	LimitPrice   float64          `json:"limit_price,omitempty"`
+	TimeInForce  string           `json:"time_in_force,omitempty"`
+	Margin       bool             `json:"margin,omitempty"`
+	PostOnly     bool             `json:"post_only,omitempty"`
+	ReduceOnly   bool             `json:"reduce_only,omitempty"`
+	ExpireTime   string           `json:"expire_time,omitempty"`
+	ClOrdID      string           `json:"cl_ord_id,omitempty"`
+	OrderUserref int64            `json:"order_userref,omitempty"`
+	DisplayQty   float64          `json:"display_qty,omitempty"`
+	StpType      string           `json:"stp_type,omitempty"`
+	Triggers     *TriggerParamsV2 `json:"triggers,omitempty"`
+}
+
+// BatchAddParamsV2 - params of `batch_add`, 2 to 15 orders of one symbol
+type BatchAddParamsV2 struct {
+	Symbol   string         `json:"symbol"`
+	Orders   []BatchOrderV2 `json:"orders"`
+	Deadline string         `json:"deadline,omitempty"`
+	Validate bool           `json:"validate,omitempty"`
+	Token    string         `json:"token"`
+}
@@ -122,3 +122,29 @@ func SubscribeFuturesTicker(conn *websocket.Conn, pairs ...string) error {
 	}
 	return nil
 }
+
+// WithSubscriptionV2 - v2 subscription restored on every (re-)connect.
+// Private channels need params.Token.
+func WithSubscriptionV2(params SubscribeParamsV2) func(*Options) {
+	return func(o *Options) {
+		o.Subscribers = append(o.Subscribers, func(conn *websocket.Conn) error {
+			return SubscribeV2(conn, params)
+		})
+	}
+}
+
+// SubscribeV2 - writes v2 subscribe request without waiting for the response
+func SubscribeV2(conn *websocket.Conn, params SubscribeParamsV2) error {
+	bs, err := json.Marshal(RequestV2{
+		Method: MethodSubscribe,
+		Params: params,
+	})
+	if err != nil {
+		return err
+	}
+	if err = conn.WriteMessage(websocket.TextMessage, bs); err != nil {
+		zap.S().Errorf("订阅失败:%v %v", string(bs), err)
+		return err
+	}
+	return nil
+}
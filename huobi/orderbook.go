@@ -0,0 +1,486 @@
+package huobi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	SpotMBPWsURL = "wss://api.huobi.pro/feed" // 现货 MBP 增量深度推荐接入点
+	SwapWsURL    = "wss://api.hbdm.com/linear-swap-ws"
+
+	bookPendingLimit = 1024 // 等待快照期间最多缓存的增量条数
+)
+
+var errBookNotReady = errors.New("huobi: order book not ready")
+
+type bookSeqError struct{ expect, got int64 }
+
+func (e *bookSeqError) Error() string {
+	return fmt.Sprintf("huobi: book sequence broken, expect=%d got=%d", e.expect, e.got)
+}
+
+type BookLevel struct {
+	Price float64
+	Size  float64
+}
+
+/* ============================== 单个交易对 ============================== */
+
+// OrderBook 本地维护的单个交易对深度，所有方法并发安全；
+// 现货 Seq 为 seqNum，合约 Seq 为 version
+type OrderBook struct {
+	mu     sync.RWMutex
+	symbol string
+	bids   []BookLevel // 价格降序
+	asks   []BookLevel // 价格升序
+	seq    int64
+	ts     int64
+	valid  bool
+}
+
+func (b *OrderBook) Symbol() string { return b.symbol }
+
+// IsValid 快照已就绪且未发生序列中断
+func (b *OrderBook) IsValid() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.valid
+}
+
+func (b *OrderBook) Seq() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.seq
+}
+
+// Ts 最近一次更新的交易所时间戳(ms)
+func (b *OrderBook) Ts() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.ts
+}
+
+func (b *OrderBook) BestBid() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid || len(b.bids) == 0 {
+		return BookLevel{}, false
+	}
+	return b.bids[0], true
+}
+
+func (b *OrderBook) BestAsk() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid || len(b.asks) == 0 {
+		return BookLevel{}, false
+	}
+	return b.asks[0], true
+}
+
+// Depth 返回前 n 档的拷贝，n <= 0 返回全部
+func (b *OrderBook) Depth(n int) (bids, asks []BookLevel) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid {
+		return nil, nil
+	}
+	return topLevels(b.bids, n), topLevels(b.asks, n)
+}
+
+func topLevels(src []BookLevel, n int) []BookLevel {
+	if n <= 0 || n > len(src) {
+		n = len(src)
+	}
+	dst := make([]BookLevel, n)
+	copy(dst, src[:n])
+	return dst
+}
+
+func (b *OrderBook) invalidate() {
+	b.mu.Lock()
+	b.valid = false
+	b.mu.Unlock()
+}
+
+func (b *OrderBook) snapshot(bids, asks [][]float64, seq, ts int64) {
+	nb, na := toLevels(bids), toLevels(asks)
+	sort.Slice(nb, func(i, j int) bool { return nb[i].Price > nb[j].Price })
+	sort.Slice(na, func(i, j int) bool { return na[i].Price < na[j].Price })
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bids, b.asks = nb, na
+	b.seq, b.ts = seq, ts
+	b.valid = true
+}
+
+// update prev 为该增量期望的上一序号；seq <= 当前序号的旧增量直接忽略
+func (b *OrderBook) update(bids, asks [][]float64, prev, seq, ts int64) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.valid {
+		return false, errBookNotReady
+	}
+	if seq <= b.seq {
+		return false, nil
+	}
+	if prev != b.seq {
+		b.valid = false
+		return false, &bookSeqError{expect: b.seq, got: prev}
+	}
+	for _, a := range bids {
+		if len(a) >= 2 {
+			b.bids = applyLevel(b.bids, BookLevel{a[0], a[1]}, true)
+		}
+	}
+	for _, a := range asks {
+		if len(a) >= 2 {
+			b.asks = applyLevel(b.asks, BookLevel{a[0], a[1]}, false)
+		}
+	}
+	b.seq, b.ts = seq, ts
+	return true, nil
+}
+
+// applyLevel 插入/替换/删除(size=0) 一档，desc 表示价格降序
+func applyLevel(levels []BookLevel, l BookLevel, desc bool) []BookLevel {
+	i := sort.Search(len(levels), func(i int) bool {
+		if desc {
+			return levels[i].Price <= l.Price
+		}
+		return levels[i].Price >= l.Price
+	})
+	found := i < len(levels) && levels[i].Price == l.Price
+	switch {
+	case l.Size == 0:
+		if found {
+			levels = append(levels[:i], levels[i+1:]...)
+		}
+	case found:
+		levels[i] = l
+	default:
+		levels = append(levels, BookLevel{})
+		copy(levels[i+1:], levels[i:])
+		levels[i] = l
+	}
+	return levels
+}
+
+func toLevels(src [][]float64) []BookLevel {
+	levels := make([]BookLevel, 0, len(src))
+	for _, a := range src {
+		if len(a) >= 2 && a[1] != 0 {
+			levels = append(levels, BookLevel{a[0], a[1]})
+		}
+	}
+	return levels
+}
+
+/* ============================== 管理器 ============================== */
+
+type bookTick struct {
+	SeqNum     int64       `json:"seqNum"`
+	PrevSeqNum int64       `json:"prevSeqNum"`
+	Version    int64       `json:"version"`
+	Event      string      `json:"event"` // 合约：snapshot / update
+	Ts         int64       `json:"ts"`
+	Bids       [][]float64 `json:"bids"`
+	Asks       [][]float64 `json:"asks"`
+}
+
+type bookMessage struct {
+	Ch      string    `json:"ch"`
+	Rep     string    `json:"rep"`
+	Status  string    `json:"status"`
+	Ts      int64     `json:"ts"`
+	Tick    *bookTick `json:"tick"`
+	Data    *bookTick `json:"data"`
+	ErrCode string    `json:"err-code"`
+	ErrMsg  string    `json:"err-msg"`
+}
+
+// OrderBookManager 维护现货 mbp 或 U 本位合约 high_freq 增量深度：
+// 现货按 prevSeqNum/seqNum 衔接，缺口时通过 req 重新拉取快照；
+// 合约按 version 连续递增校验，缺口时重新订阅以获取新快照
+type OrderBookManager struct {
+	*WsClient
+	swap     bool
+	levels   int
+	mu       sync.RWMutex
+	books    map[string]*OrderBook
+	pending  map[string][]bookTick // 现货等待快照期间缓存的增量
+	onChange func(book *OrderBook)
+}
+
+// NewSpotOrderBook levels: 5 / 20 / 150 / 400，symbols 为小写交易对如 btcusdt；
+// onChange 在每次快照/增量成功应用后回调
+func NewSpotOrderBook(parent context.Context, levels int, onChange func(*OrderBook), symbols ...string) (*OrderBookManager, error) {
+	return newOrderBookManager(parent, SpotMBPWsURL, false, levels, onChange, symbols)
+}
+
+// NewSwapOrderBook size: 20 / 150，contractCodes 如 BTC-USDT
+func NewSwapOrderBook(parent context.Context, size int, onChange func(*OrderBook), contractCodes ...string) (*OrderBookManager, error) {
+	return newOrderBookManager(parent, SwapWsURL, true, size, onChange, contractCodes)
+}
+
+func newOrderBookManager(parent context.Context, wsURL string, swap bool, levels int, onChange func(*OrderBook), symbols []string) (*OrderBookManager, error) {
+	m := &OrderBookManager{
+		swap:     swap,
+		levels:   levels,
+		books:    make(map[string]*OrderBook),
+		pending:  make(map[string][]bookTick),
+		onChange: onChange,
+	}
+	for _, s := range symbols {
+		m.books[s] = &OrderBook{symbol: s}
+	}
+	var err error
+	m.WsClient, err = NewWsClient(parent, m.handle,
+		SetURL(wsURL),
+		SetSubscriber(m.resubscribeAll, nil),
+		SetWorkers(1), // 增量必须按顺序应用
+	)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Subscribe 追加交易对，重连后自动重新订阅
+func (m *OrderBookManager) Subscribe(symbols ...string) error {
+	for _, s := range symbols {
+		m.mu.Lock()
+		_, ok := m.books[s]
+		if !ok {
+			m.books[s] = &OrderBook{symbol: s}
+		}
+		m.mu.Unlock()
+		if ok {
+			continue
+		}
+		for _, msg := range m.subMessages(s) {
+			if err := m.safeWrite(websocket.TextMessage, msg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Book 返回交易对的本地深度，未订阅返回 nil
+func (m *OrderBookManager) Book(symbol string) *OrderBook {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.books[symbol]
+}
+
+func (m *OrderBookManager) BestBid(symbol string) (BookLevel, bool) {
+	if b := m.Book(symbol); b != nil {
+		return b.BestBid()
+	}
+	return BookLevel{}, false
+}
+
+func (m *OrderBookManager) BestAsk(symbol string) (BookLevel, bool) {
+	if b := m.Book(symbol); b != nil {
+		return b.BestAsk()
+	}
+	return BookLevel{}, false
+}
+
+func (m *OrderBookManager) Depth(symbol string, n int) (bids, asks []BookLevel) {
+	if b := m.Book(symbol); b != nil {
+		return b.Depth(n)
+	}
+	return nil, nil
+}
+
+func (m *OrderBookManager) topic(symbol string) string {
+	if m.swap {
+		return "market." + symbol + ".depth.size_" + strconv.Itoa(m.levels) + ".high_freq"
+	}
+	return "market." + symbol + ".mbp." + strconv.Itoa(m.levels)
+}
+
+// subMessages 现货订阅增量后立即 req 快照，合约首条推送即为快照
+func (m *OrderBookManager) subMessages(symbol string) [][]byte {
+	topic := m.topic(symbol)
+	if m.swap {
+		bs, _ := json.Marshal(map[string]string{"sub": topic, "data_type": "incremental", "id": symbol})
+		return [][]byte{bs}
+	}
+	sub, _ := json.Marshal(map[string]string{"sub": topic, "id": symbol})
+	return [][]byte{sub, m.snapshotRequest(symbol)}
+}
+
+func (m *OrderBookManager) snapshotRequest(symbol string) []byte {
+	bs, _ := json.Marshal(map[string]string{"req": m.topic(symbol), "id": symbol})
+	return bs
+}
+
+// resubscribeAll 作为 WsClient 的 subscriber，每次拨号后作废全部深度并重新订阅
+func (m *OrderBookManager) resubscribeAll(conn *websocket.Conn, _ ...string) error {
+	m.mu.Lock()
+	symbols := make([]string, 0, len(m.books))
+	for s, b := range m.books {
+		b.invalidate()
+		symbols = append(symbols, s)
+	}
+	m.pending = make(map[string][]bookTick)
+	m.mu.Unlock()
+
+	for _, s := range symbols {
+		for _, msg := range m.subMessages(s) {
+			if err := conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *OrderBookManager) handle(bs []byte) error {
+	var msg bookMessage
+	if err := json.Unmarshal(bs, &msg); err != nil {
+		return err
+	}
+	if msg.Status == "error" {
+		zap.S().Errorf("[huobi][book] err-code=%s err-msg=%s", msg.ErrCode, msg.ErrMsg)
+		return nil
+	}
+
+	switch {
+	case msg.Rep != "" && msg.Data != nil: // 现货 req 快照
+		return m.onSnapshot(symbolOf(msg.Rep), msg.Data)
+	case msg.Ch != "" && msg.Tick != nil:
+		if m.swap {
+			return m.onSwapTick(symbolOf(msg.Ch), msg.Tick)
+		}
+		return m.onSpotTick(symbolOf(msg.Ch), msg.Tick, msg.Ts)
+	}
+	return nil
+}
+
+// symbolOf market.$symbol.mbp.150 / market.$contract_code.depth.size_20.high_freq
+func symbolOf(ch string) string {
+	parts := strings.Split(ch, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+func (m *OrderBookManager) onSnapshot(symbol string, d *bookTick) error {
+	book := m.Book(symbol)
+	if book == nil {
+		return nil
+	}
+	book.snapshot(d.Bids, d.Asks, d.SeqNum, d.Ts)
+
+	m.mu.Lock()
+	buffered := m.pending[symbol]
+	delete(m.pending, symbol)
+	m.mu.Unlock()
+
+	for i := range buffered {
+		t := &buffered[i]
+		if _, err := book.update(t.Bids, t.Asks, t.PrevSeqNum, t.SeqNum, t.Ts); err != nil {
+			zap.S().Warnf("[huobi][book][%s] %s, request snapshot", symbol, err)
+			return m.resync(book)
+		}
+	}
+	m.notify(book)
+	return nil
+}
+
+func (m *OrderBookManager) onSpotTick(symbol string, t *bookTick, ts int64) error {
+	book := m.Book(symbol)
+	if book == nil {
+		return nil
+	}
+	if t.Ts == 0 {
+		t.Ts = ts
+	}
+	applied, err := book.update(t.Bids, t.Asks, t.PrevSeqNum, t.SeqNum, t.Ts)
+	if err == errBookNotReady { // 等待快照，先缓存
+		m.mu.Lock()
+		if q := m.pending[symbol]; len(q) < bookPendingLimit {
+			m.pending[symbol] = append(q, *t)
+		}
+		m.mu.Unlock()
+		return nil
+	}
+	if err != nil {
+		zap.S().Warnf("[huobi][book][%s] %s, request snapshot", symbol, err)
+		m.mu.Lock()
+		m.pending[symbol] = []bookTick{*t}
+		m.mu.Unlock()
+		return m.resync(book)
+	}
+	if applied {
+		m.notify(book)
+	}
+	return nil
+}
+
+func (m *OrderBookManager) onSwapTick(symbol string, t *bookTick) error {
+	book := m.Book(symbol)
+	if book == nil {
+		return nil
+	}
+	if t.Event == "snapshot" {
+		book.snapshot(t.Bids, t.Asks, t.Version, t.Ts)
+		m.notify(book)
+		return nil
+	}
+	applied, err := book.update(t.Bids, t.Asks, t.Version-1, t.Version, t.Ts)
+	if err == errBookNotReady {
+		return nil
+	}
+	if err != nil {
+		zap.S().Warnf("[huobi][book][%s] %s, resubscribe", symbol, err)
+		return m.resync(book)
+	}
+	if applied {
+		m.notify(book)
+	}
+	return nil
+}
+
+// resync 现货重新请求快照；合约退订后重新订阅，服务端会先推送快照
+func (m *OrderBookManager) resync(book *OrderBook) error {
+	book.invalidate()
+	if m.WsClient == nil { // 构造尚未返回，拨号后的订阅会重新拉取快照
+		return nil
+	}
+	if !m.swap {
+		return m.safeWrite(websocket.TextMessage, m.snapshotRequest(book.symbol))
+	}
+	unsub, _ := json.Marshal(map[string]string{"unsub": m.topic(book.symbol), "data_type": "incremental", "id": book.symbol})
+	if err := m.safeWrite(websocket.TextMessage, unsub); err != nil {
+		return err
+	}
+	for _, msg := range m.subMessages(book.symbol) {
+		if err := m.safeWrite(websocket.TextMessage, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *OrderBookManager) notify(book *OrderBook) {
+	if m.onChange != nil && book.IsValid() {
+		m.onChange(book)
+	}
+}
@@ -0,0 +1,154 @@
+package huobi
+
+import (
+	"reflect"
+	"testing"
+)
+
+const (
+	spotSnapshot = `{"rep":"market.btcusdt.mbp.150","status":"ok","data":{"seqNum":100,"bids":[[30000,1],[29999,2]],"asks":[[30001,1],[30002,3]]}}`
+	swapSnapshot = `{"ch":"market.BTC-USDT.depth.size_20.high_freq","ts":1,"tick":{"event":"snapshot","version":5,"bids":[[30000,1],[29999,2]],"asks":[[30001,1],[30002,3]]}}`
+)
+
+func spotTick(prev, seq string, bids string) string {
+	return `{"ch":"market.btcusdt.mbp.150","ts":1,"tick":{"prevSeqNum":` + prev + `,"seqNum":` + seq + `,"bids":` + bids + `,"asks":[]}}`
+}
+
+func swapTick(version string, bids string) string {
+	return `{"ch":"market.BTC-USDT.depth.size_20.high_freq","ts":1,"tick":{"event":"update","version":` + version + `,"bids":` + bids + `,"asks":[]}}`
+}
+
+func TestOrderBookManager_handle(t *testing.T) {
+	tests := []struct {
+		name       string
+		swap       bool
+		msgs       []string
+		wantValid  bool
+		wantSeq    int64
+		wantBids   []float64
+		wantNotify int
+	}{
+		{
+			name:       "spot snapshot",
+			msgs:       []string{spotSnapshot},
+			wantValid:  true,
+			wantSeq:    100,
+			wantBids:   []float64{30000, 29999},
+			wantNotify: 1,
+		},
+		{
+			name:       "spot delta",
+			msgs:       []string{spotSnapshot, spotTick("100", "101", "[[30000,0],[30000.5,4]]")},
+			wantValid:  true,
+			wantSeq:    101,
+			wantBids:   []float64{30000.5, 29999},
+			wantNotify: 2,
+		},
+		{
+			// 快照前到达的增量先缓存，快照后丢弃旧序号并按序应用
+			name: "spot delta buffered before snapshot",
+			msgs: []string{
+				spotTick("99", "100", "[[29998,1]]"),
+				spotTick("100", "101", "[[30000.5,4]]"),
+				spotSnapshot,
+			},
+			wantValid:  true,
+			wantSeq:    101,
+			wantBids:   []float64{30000.5, 30000, 29999},
+			wantNotify: 1,
+		},
+		{
+			name:       "spot seq gap",
+			msgs:       []string{spotSnapshot, spotTick("102", "103", "[[30000.5,4]]")},
+			wantSeq:    100,
+			wantNotify: 1,
+		},
+		{
+			// 缺口后缓存的增量由新快照衔接
+			name: "spot seq gap then snapshot",
+			msgs: []string{
+				spotSnapshot,
+				spotTick("102", "103", "[[30000.5,4]]"),
+				`{"rep":"market.btcusdt.mbp.150","status":"ok","data":{"seqNum":102,"bids":[[30000,1]],"asks":[[30001,1]]}}`,
+			},
+			wantValid:  true,
+			wantSeq:    103,
+			wantBids:   []float64{30000.5, 30000},
+			wantNotify: 2,
+		},
+		{
+			name:       "swap snapshot",
+			swap:       true,
+			msgs:       []string{swapSnapshot},
+			wantValid:  true,
+			wantSeq:    5,
+			wantBids:   []float64{30000, 29999},
+			wantNotify: 1,
+		},
+		{
+			name:       "swap delta",
+			swap:       true,
+			msgs:       []string{swapSnapshot, swapTick("6", "[[29999,0]]")},
+			wantValid:  true,
+			wantSeq:    6,
+			wantBids:   []float64{30000},
+			wantNotify: 2,
+		},
+		{
+			name:       "swap delta before snapshot",
+			swap:       true,
+			msgs:       []string{swapTick("6", "[[29999,0]]")},
+			wantNotify: 0,
+		},
+		{
+			name:       "swap version gap",
+			swap:       true,
+			msgs:       []string{swapSnapshot, swapTick("8", "[[29999,0]]")},
+			wantSeq:    5,
+			wantNotify: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			symbol := "btcusdt"
+			if tt.swap {
+				symbol = "BTC-USDT"
+			}
+			notified := 0
+			m := &OrderBookManager{
+				swap:     tt.swap,
+				levels:   150,
+				books:    map[string]*OrderBook{symbol: {symbol: symbol}},
+				pending:  make(map[string][]bookTick),
+				onChange: func(*OrderBook) { notified++ },
+			}
+			if tt.swap {
+				m.levels = 20
+			}
+			for _, msg := range tt.msgs {
+				if err := m.handle([]byte(msg)); err != nil {
+					t.Fatalf("handle(%s) error = %v", msg, err)
+				}
+			}
+
+			book := m.Book(symbol)
+			if book.IsValid() != tt.wantValid {
+				t.Errorf("IsValid() = %v, want %v", book.IsValid(), tt.wantValid)
+			}
+			if book.Seq() != tt.wantSeq {
+				t.Errorf("Seq() = %d, want %d", book.Seq(), tt.wantSeq)
+			}
+			bids, _ := book.Depth(0)
+			var px []float64
+			for _, l := range bids {
+				px = append(px, l.Price)
+			}
+			if !reflect.DeepEqual(px, tt.wantBids) {
+				t.Errorf("bids = %v, want %v", px, tt.wantBids)
+			}
+			if notified != tt.wantNotify {
+				t.Errorf("onChange called %d times, want %d", notified, tt.wantNotify)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package huobi
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	SpotRestHost = "api.huobi.pro"
+	SwapRestHost = "api.hbdm.com"
+
+	restTimeout = 10 * time.Second
+)
+
+/* ---------- 错误 ---------- */
+
+// APIError 火币返回 status=error 时的业务错误；
+// 现货为 err-code/err-msg（字符串），U 本位合约为 err_code/err_msg（数字）
+type APIError struct {
+	Status string
+	Code   string
+	Msg    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("huobi: err-code=%s err-msg=%s", e.Code, e.Msg)
+}
+
+type restResponse struct {
+	Status      string          `json:"status"`
+	Data        json.RawMessage `json:"data"`
+	ErrCode     string          `json:"err-code"`
+	ErrMsg      string          `json:"err-msg"`
+	SwapErrCode json.Number     `json:"err_code"`
+	SwapErrMsg  string          `json:"err_msg"`
+	Ts          int64           `json:"ts"`
+	Code        int             `json:"code"` // v2 接口使用 code/message
+	Message     string          `json:"message"`
+}
+
+func (r *restResponse) err() error {
+	if r.Status == "ok" || (r.Status == "" && r.Code == 200) {
+		return nil
+	}
+	e := &APIError{Status: r.Status, Code: r.ErrCode, Msg: r.ErrMsg}
+	if e.Code == "" && r.SwapErrCode != "" {
+		e.Code, e.Msg = r.SwapErrCode.String(), r.SwapErrMsg
+	}
+	if e.Code == "" && r.Code != 0 {
+		e.Code, e.Msg = fmt.Sprint(r.Code), r.Message
+	}
+	return e
+}
+
+/* ---------- 可选项 ---------- */
+
+type RestOptions struct {
+	spotHost   string
+	swapHost   string
+	httpClient *http.Client
+}
+
+func SetSpotHost(host string) func(*RestOptions) { return func(o *RestOptions) { o.spotHost = host } }
+func SetSwapHost(host string) func(*RestOptions) { return func(o *RestOptions) { o.swapHost = host } }
+func SetHttpClient(c *http.Client) func(*RestOptions) {
+	return func(o *RestOptions) { o.httpClient = c }
+}
+
+/* ---------- 客户端结构 ---------- */
+
+// RestClient 火币现货与 U 本位合约 REST 客户端，签名方式为 HmacSHA256 v2
+type RestClient struct {
+	RestOptions
+	accessKey string
+	secretKey string
+}
+
+func NewRestClient(accessKey, secretKey string, opts ...func(*RestOptions)) *RestClient {
+	c := &RestClient{accessKey: accessKey, secretKey: secretKey}
+	for _, o := range opts {
+		o(&c.RestOptions)
+	}
+	if c.spotHost == "" {
+		c.spotHost = SpotRestHost
+	}
+	if c.swapHost == "" {
+		c.swapHost = SwapRestHost
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: restTimeout}
+	}
+	return c
+}
+
+/* ============================ 请求与签名 ============================ */
+
+// do 发送请求并把 data 解析进 result；signed 时在 query 中附加签名参数，POST 参数放在 JSON body
+func (c *RestClient) do(ctx context.Context, host, method, path string, query url.Values, body any, signed bool, result any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	if signed {
+		c.sign(host, method, path, query)
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	u := "https://" + host + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var ret restResponse
+	if err = json.Unmarshal(bs, &ret); err != nil {
+		zap.S().Errorf("[huobi][rest] %s %s status=%d body=%s", method, path, resp.StatusCode, string(bs))
+		return err
+	}
+	if err = ret.err(); err != nil {
+		return err
+	}
+	if result != nil && len(ret.Data) > 0 && string(ret.Data) != "null" {
+		return json.Unmarshal(ret.Data, result)
+	}
+	return nil
+}
+
+// sign 签名 v2：METHOD\nhost\npath\n按键名排序并编码后的 query
+func (c *RestClient) sign(host, method, path string, query url.Values) {
+	query.Set("AccessKeyId", c.accessKey)
+	query.Set("SignatureMethod", "HmacSHA256")
+	query.Set("SignatureVersion", "2")
+	query.Set("Timestamp", time.Now().UTC().Format("2006-01-02T15:04:05"))
+	query.Del("Signature")
+	query.Set("Signature", signature(c.secretKey, method, host, path, query))
+}
+
+// signature 签名 v2 / v2.1 共用的原文：METHOD\nhost\npath\nquery，url.Values.Encode 已按键名排序
+func signature(secret, method, host, path string, query url.Values) string {
+	prehash := strings.Join([]string{method, strings.ToLower(host), path, query.Encode()}, "\n")
+	return hmacSHA256(secret, prehash)
+}
+
+func hmacSHA256(secret, prehash string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(prehash))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,217 @@
+package huobi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+/* ---------- 现货类型 ---------- */
+
+type Symbol struct {
+	Symbol          string  `json:"symbol"`
+	BaseCurrency    string  `json:"base-currency"`
+	QuoteCurrency   string  `json:"quote-currency"`
+	State           string  `json:"state"` // online / offline / suspend / pre-online
+	PricePrecision  int     `json:"price-precision"`
+	AmountPrecision int     `json:"amount-precision"`
+	ValuePrecision  int     `json:"value-precision"`
+	SymbolPartition string  `json:"symbol-partition"`
+	MinOrderAmt     float64 `json:"min-order-amt"`
+	MaxOrderAmt     float64 `json:"max-order-amt"`
+	MinOrderValue   float64 `json:"min-order-value"`
+	LimitOrderMin   float64 `json:"limit-order-min-order-amt"`
+	LimitOrderMax   float64 `json:"limit-order-max-order-amt"`
+	SellMarketMin   float64 `json:"sell-market-min-order-amt"`
+	SellMarketMax   float64 `json:"sell-market-max-order-amt"`
+	BuyMarketMax    float64 `json:"buy-market-max-order-value"`
+	ApiTrading      string  `json:"api-trading"` // enabled / disabled
+}
+
+type Account struct {
+	Id      int64  `json:"id"`
+	Type    string `json:"type"` // spot / margin / super-margin / otc / point
+	Subtype string `json:"subtype"`
+	State   string `json:"state"` // working / lock
+}
+
+type AccountBalance struct {
+	Id    int64     `json:"id"`
+	Type  string    `json:"type"`
+	State string    `json:"state"`
+	List  []Balance `json:"list"`
+}
+
+type Balance struct {
+	Currency  string `json:"currency"`
+	Type      string `json:"type"` // trade / frozen
+	Balance   string `json:"balance"`
+	Available string `json:"available,omitempty"`
+	SeqNum    string `json:"seq-num,omitempty"`
+}
+
+// PlaceOrderReq Type: buy-market / sell-market / buy-limit / sell-limit / buy-ioc / buy-limit-maker ...
+type PlaceOrderReq struct {
+	AccountId     string `json:"account-id"`
+	Symbol        string `json:"symbol"`
+	Type          string `json:"type"`
+	Amount        string `json:"amount"`
+	Price         string `json:"price,omitempty"`
+	Source        string `json:"source,omitempty"` // spot-api / margin-api / super-margin-api
+	ClientOrderId string `json:"client-order-id,omitempty"`
+	StopPrice     string `json:"stop-price,omitempty"`
+	Operator      string `json:"operator,omitempty"` // gte / lte
+}
+
+type SpotOrder struct {
+	Id               int64  `json:"id"`
+	ClientOrderId    string `json:"client-order-id"`
+	AccountId        int64  `json:"account-id"`
+	Symbol           string `json:"symbol"`
+	Type             string `json:"type"`
+	Source           string `json:"source"`
+	State            string `json:"state"` // created / submitted / partial-filled / filled / canceled ...
+	Price            string `json:"price"`
+	Amount           string `json:"amount"`
+	FilledAmount     string `json:"filled-amount"`
+	FilledCashAmount string `json:"filled-cash-amount"`
+	FilledFees       string `json:"filled-fees"`
+	StopPrice        string `json:"stop-price"`
+	Operator         string `json:"operator"`
+	CreatedAt        int64  `json:"created-at"`
+}
+
+type MatchResult struct {
+	Id                int64  `json:"id"`
+	OrderId           int64  `json:"order-id"`
+	MatchId           int64  `json:"match-id"`
+	TradeId           int64  `json:"trade-id"`
+	Symbol            string `json:"symbol"`
+	Type              string `json:"type"`
+	Source            string `json:"source"`
+	Price             string `json:"price"`
+	FilledAmount      string `json:"filled-amount"`
+	FilledFees        string `json:"filled-fees"`
+	FeeCurrency       string `json:"fee-currency"`
+	FeeDeductCurrency string `json:"fee-deduct-currency"`
+	FilledPoints      string `json:"filled-points"`
+	Role              string `json:"role"` // maker / taker
+	CreatedAt         int64  `json:"created-at"`
+}
+
+// OpenOrdersReq Side: buy / sell，空为全部；From 为上一页最后一条订单号
+type OpenOrdersReq struct {
+	AccountId string
+	Symbol    string
+	Side      string
+	From      string
+	Direct    string // prev / next
+	Size      int
+}
+
+// MatchResultsReq 时间为毫秒时间戳，查询窗口最大 48 小时
+type MatchResultsReq struct {
+	Symbol    string
+	Types     string // 逗号分隔的订单类型
+	StartTime int64
+	EndTime   int64
+	From      string
+	Direct    string
+	Size      int
+}
+
+/* ============================== 行情 ============================== */
+
+func (c *RestClient) GetSymbols(ctx context.Context) (result []Symbol, err error) {
+	err = c.do(ctx, c.spotHost, http.MethodGet, "/v1/common/symbols", nil, nil, false, &result)
+	return
+}
+
+/* ============================== 账户 ============================== */
+
+func (c *RestClient) GetAccounts(ctx context.Context) (result []Account, err error) {
+	err = c.do(ctx, c.spotHost, http.MethodGet, "/v1/account/accounts", nil, nil, true, &result)
+	return
+}
+
+func (c *RestClient) GetAccountBalance(ctx context.Context, accountId int64) (result AccountBalance, err error) {
+	path := "/v1/account/accounts/" + strconv.FormatInt(accountId, 10) + "/balance"
+	err = c.do(ctx, c.spotHost, http.MethodGet, path, nil, nil, true, &result)
+	return
+}
+
+/* ============================== 交易 ============================== */
+
+// PlaceOrder 返回订单号
+func (c *RestClient) PlaceOrder(ctx context.Context, req PlaceOrderReq) (orderId string, err error) {
+	if req.Source == "" {
+		req.Source = "spot-api"
+	}
+	err = c.do(ctx, c.spotHost, http.MethodPost, "/v1/order/orders/place", nil, req, true, &orderId)
+	return
+}
+
+func (c *RestClient) CancelOrder(ctx context.Context, orderId string) (result string, err error) {
+	path := "/v1/order/orders/" + orderId + "/submitcancel"
+	err = c.do(ctx, c.spotHost, http.MethodPost, path, nil, nil, true, &result)
+	return
+}
+
+// CancelOrderByClientId 返回撤单状态码，见火币文档 submitCancelClientOrder
+func (c *RestClient) CancelOrderByClientId(ctx context.Context, clientOrderId string) (result int, err error) {
+	body := map[string]string{"client-order-id": clientOrderId}
+	err = c.do(ctx, c.spotHost, http.MethodPost, "/v1/order/orders/submitCancelClientOrder", nil, body, true, &result)
+	return
+}
+
+func (c *RestClient) GetOpenOrders(ctx context.Context, req OpenOrdersReq) (result []SpotOrder, err error) {
+	q := url.Values{}
+	setIf(q, "account-id", req.AccountId)
+	setIf(q, "symbol", req.Symbol)
+	setIf(q, "side", req.Side)
+	setIf(q, "from", req.From)
+	setIf(q, "direct", req.Direct)
+	if req.Size > 0 {
+		q.Set("size", strconv.Itoa(req.Size))
+	}
+	err = c.do(ctx, c.spotHost, http.MethodGet, "/v1/order/openOrders", q, nil, true, &result)
+	return
+}
+
+func (c *RestClient) GetOrder(ctx context.Context, orderId string) (result SpotOrder, err error) {
+	err = c.do(ctx, c.spotHost, http.MethodGet, "/v1/order/orders/"+orderId, nil, nil, true, &result)
+	return
+}
+
+func (c *RestClient) GetMatchResults(ctx context.Context, req MatchResultsReq) (result []MatchResult, err error) {
+	q := url.Values{}
+	setIf(q, "symbol", req.Symbol)
+	setIf(q, "types", req.Types)
+	setIf(q, "from", req.From)
+	setIf(q, "direct", req.Direct)
+	if req.StartTime > 0 {
+		q.Set("start-time", strconv.FormatInt(req.StartTime, 10))
+	}
+	if req.EndTime > 0 {
+		q.Set("end-time", strconv.FormatInt(req.EndTime, 10))
+	}
+	if req.Size > 0 {
+		q.Set("size", strconv.Itoa(req.Size))
+	}
+	err = c.do(ctx, c.spotHost, http.MethodGet, "/v1/order/matchresults", q, nil, true, &result)
+	return
+}
+
+// GetOrderMatchResults 单个订单的成交明细
+func (c *RestClient) GetOrderMatchResults(ctx context.Context, orderId string) (result []MatchResult, err error) {
+	path := "/v1/order/orders/" + orderId + "/matchresults"
+	err = c.do(ctx, c.spotHost, http.MethodGet, path, nil, nil, true, &result)
+	return
+}
+
+func setIf(q url.Values, k, v string) {
+	if v != "" {
+		q.Set(k, v)
+	}
+}
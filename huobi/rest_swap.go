@@ -0,0 +1,230 @@
+package huobi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+/* ---------- U 本位合约类型（全仓） ---------- */
+
+type SwapContract struct {
+	Symbol            string  `json:"symbol"`
+	ContractCode      string  `json:"contract_code"` // BTC-USDT
+	ContractSize      float64 `json:"contract_size"`
+	PriceTick         float64 `json:"price_tick"`
+	SettlementDate    string  `json:"settlement_date"`
+	CreateDate        string  `json:"create_date"`
+	ContractStatus    int     `json:"contract_status"` // 1 上市
+	SupportMarginMode string  `json:"support_margin_mode"`
+	BusinessType      string  `json:"business_type"` // swap / futures / all
+	Pair              string  `json:"pair"`
+	ContractType      string  `json:"contract_type"`
+}
+
+type SwapCrossAccount struct {
+	MarginMode        string  `json:"margin_mode"`
+	MarginAccount     string  `json:"margin_account"`
+	MarginAsset       string  `json:"margin_asset"`
+	MarginBalance     float64 `json:"margin_balance"`
+	MarginStatic      float64 `json:"margin_static"`
+	MarginPosition    float64 `json:"margin_position"`
+	MarginFrozen      float64 `json:"margin_frozen"`
+	ProfitReal        float64 `json:"profit_real"`
+	ProfitUnreal      float64 `json:"profit_unreal"`
+	WithdrawAvailable float64 `json:"withdraw_available"`
+	RiskRate          float64 `json:"risk_rate"`
+}
+
+type SwapPosition struct {
+	Symbol         string  `json:"symbol"`
+	ContractCode   string  `json:"contract_code"`
+	Volume         float64 `json:"volume"`
+	Available      float64 `json:"available"`
+	Frozen         float64 `json:"frozen"`
+	CostOpen       float64 `json:"cost_open"`
+	CostHold       float64 `json:"cost_hold"`
+	ProfitUnreal   float64 `json:"profit_unreal"`
+	ProfitRate     float64 `json:"profit_rate"`
+	Profit         float64 `json:"profit"`
+	PositionMargin float64 `json:"position_margin"`
+	LeverRate      int     `json:"lever_rate"`
+	Direction      string  `json:"direction"` // buy / sell
+	LastPrice      float64 `json:"last_price"`
+	MarginMode     string  `json:"margin_mode"`
+	MarginAccount  string  `json:"margin_account"`
+	PositionMode   string  `json:"position_mode"`
+}
+
+// SwapOrderReq OrderPriceType: limit / opponent / post_only / optimal_5 / ioc / fok ...
+// 单向持仓模式下 Offset 可不填
+type SwapOrderReq struct {
+	ContractCode   string  `json:"contract_code"`
+	ClientOrderId  int64   `json:"client_order_id,omitempty"`
+	Price          float64 `json:"price,omitempty"`
+	Volume         int64   `json:"volume"`
+	Direction      string  `json:"direction"`        // buy / sell
+	Offset         string  `json:"offset,omitempty"` // open / close / both
+	LeverRate      int     `json:"lever_rate"`
+	OrderPriceType string  `json:"order_price_type"`
+	ReduceOnly     int     `json:"reduce_only,omitempty"`
+}
+
+type SwapOrderResult struct {
+	OrderId       int64  `json:"order_id"`
+	OrderIdStr    string `json:"order_id_str"`
+	ClientOrderId int64  `json:"client_order_id"`
+}
+
+// SwapCancelResult Successes 为逗号分隔的订单号
+type SwapCancelResult struct {
+	Errors []struct {
+		OrderId string `json:"order_id"`
+		ErrCode int    `json:"err_code"`
+		ErrMsg  string `json:"err_msg"`
+	} `json:"errors"`
+	Successes string `json:"successes"`
+}
+
+type SwapOrder struct {
+	Symbol         string  `json:"symbol"`
+	ContractCode   string  `json:"contract_code"`
+	Volume         float64 `json:"volume"`
+	Price          float64 `json:"price"`
+	OrderPriceType string  `json:"order_price_type"`
+	OrderType      int     `json:"order_type"`
+	Direction      string  `json:"direction"`
+	Offset         string  `json:"offset"`
+	LeverRate      int     `json:"lever_rate"`
+	OrderId        int64   `json:"order_id"`
+	OrderIdStr     string  `json:"order_id_str"`
+	ClientOrderId  int64   `json:"client_order_id"`
+	CreatedAt      int64   `json:"created_at"`
+	TradeVolume    float64 `json:"trade_volume"`
+	TradeTurnover  float64 `json:"trade_turnover"`
+	Fee            float64 `json:"fee"`
+	TradeAvgPrice  float64 `json:"trade_avg_price"`
+	MarginFrozen   float64 `json:"margin_frozen"`
+	Profit         float64 `json:"profit"`
+	Status         int     `json:"status"` // 3 未成交 4 部分成交 5 部分成交已撤 6 全部成交 7 已撤
+	MarginAccount  string  `json:"margin_account"`
+	ReduceOnly     int     `json:"reduce_only"`
+}
+
+type SwapOpenOrders struct {
+	Orders      []SwapOrder `json:"orders"`
+	TotalPage   int         `json:"total_page"`
+	CurrentPage int         `json:"current_page"`
+	TotalSize   int         `json:"total_size"`
+}
+
+type SwapMatchResult struct {
+	Id               string  `json:"id"`
+	MatchId          int64   `json:"match_id"`
+	OrderId          int64   `json:"order_id"`
+	OrderIdStr       string  `json:"order_id_str"`
+	ContractCode     string  `json:"contract_code"`
+	Direction        string  `json:"direction"`
+	Offset           string  `json:"offset"`
+	TradeVolume      float64 `json:"trade_volume"`
+	TradePrice       float64 `json:"trade_price"`
+	TradeTurnover    float64 `json:"trade_turnover"`
+	TradeFee         float64 `json:"trade_fee"`
+	FeeAsset         string  `json:"fee_asset"`
+	RealProfit       float64 `json:"real_profit"`
+	Role             string  `json:"role"`
+	CreateDate       int64   `json:"create_date"`
+	OffsetProfitloss float64 `json:"offset_profitloss"`
+}
+
+type SwapMatchResults struct {
+	Trades      []SwapMatchResult `json:"trades"`
+	TotalPage   int               `json:"total_page"`
+	CurrentPage int               `json:"current_page"`
+	TotalSize   int               `json:"total_size"`
+}
+
+// SwapMatchResultsReq TradeType: 0 全部；CreateDate 为查询天数，最大 90
+type SwapMatchResultsReq struct {
+	ContractCode string `json:"contract_code,omitempty"`
+	Pair         string `json:"pair,omitempty"`
+	TradeType    int    `json:"trade_type"`
+	CreateDate   int    `json:"create_date"`
+	PageIndex    int    `json:"page_index,omitempty"`
+	PageSize     int    `json:"page_size,omitempty"`
+}
+
+/* ============================== 行情 ============================== */
+
+// GetSwapContracts contractCode 为空返回全部合约
+func (c *RestClient) GetSwapContracts(ctx context.Context, contractCode string) (result []SwapContract, err error) {
+	q := url.Values{}
+	setIf(q, "contract_code", contractCode)
+	err = c.do(ctx, c.swapHost, http.MethodGet, "/linear-swap-api/v1/swap_contract_info", q, nil, false, &result)
+	return
+}
+
+/* ============================== 账户 ============================== */
+
+// GetSwapCrossAccount marginAccount 为空返回全部全仓账户，如 USDT
+func (c *RestClient) GetSwapCrossAccount(ctx context.Context, marginAccount string) (result []SwapCrossAccount, err error) {
+	body := map[string]string{}
+	if marginAccount != "" {
+		body["margin_account"] = marginAccount
+	}
+	err = c.do(ctx, c.swapHost, http.MethodPost, "/linear-swap-api/v1/swap_cross_account_info", nil, body, true, &result)
+	return
+}
+
+func (c *RestClient) GetSwapCrossPositions(ctx context.Context, contractCode string) (result []SwapPosition, err error) {
+	body := map[string]string{}
+	if contractCode != "" {
+		body["contract_code"] = contractCode
+	}
+	err = c.do(ctx, c.swapHost, http.MethodPost, "/linear-swap-api/v1/swap_cross_position_info", nil, body, true, &result)
+	return
+}
+
+/* ============================== 交易 ============================== */
+
+func (c *RestClient) SwapPlaceOrder(ctx context.Context, req SwapOrderReq) (result SwapOrderResult, err error) {
+	err = c.do(ctx, c.swapHost, http.MethodPost, "/linear-swap-api/v1/swap_cross_order", nil, req, true, &result)
+	return
+}
+
+// SwapCancelOrder orderIds / clientOrderIds 为逗号分隔，二选一
+func (c *RestClient) SwapCancelOrder(ctx context.Context, contractCode, orderIds, clientOrderIds string) (result SwapCancelResult, err error) {
+	body := map[string]string{"contract_code": contractCode}
+	if orderIds != "" {
+		body["order_id"] = orderIds
+	}
+	if clientOrderIds != "" {
+		body["client_order_id"] = clientOrderIds
+	}
+	err = c.do(ctx, c.swapHost, http.MethodPost, "/linear-swap-api/v1/swap_cross_cancel", nil, body, true, &result)
+	return
+}
+
+func (c *RestClient) SwapCancelAll(ctx context.Context, contractCode string) (result SwapCancelResult, err error) {
+	body := map[string]string{"contract_code": contractCode}
+	err = c.do(ctx, c.swapHost, http.MethodPost, "/linear-swap-api/v1/swap_cross_cancelall", nil, body, true, &result)
+	return
+}
+
+// GetSwapOpenOrders pageIndex 从 1 开始，pageSize 最大 50
+func (c *RestClient) GetSwapOpenOrders(ctx context.Context, contractCode string, pageIndex, pageSize int) (result SwapOpenOrders, err error) {
+	body := map[string]any{"contract_code": contractCode}
+	if pageIndex > 0 {
+		body["page_index"] = pageIndex
+	}
+	if pageSize > 0 {
+		body["page_size"] = pageSize
+	}
+	err = c.do(ctx, c.swapHost, http.MethodPost, "/linear-swap-api/v1/swap_cross_openorders", nil, body, true, &result)
+	return
+}
+
+func (c *RestClient) GetSwapMatchResults(ctx context.Context, req SwapMatchResultsReq) (result SwapMatchResults, err error) {
+	err = c.do(ctx, c.swapHost, http.MethodPost, "/linear-swap-api/v1/swap_cross_matchresults", nil, req, true, &result)
+	return
+}
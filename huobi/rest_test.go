@@ -0,0 +1,190 @@
+package huobi
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestHmacSHA256(t *testing.T) {
+	// RFC 4231 test case 2
+	if got, want := hmacSHA256("Jefe", "what do ya want for nothing?"), "W9zBRr9gdU5qBCQmCJV1x1oAPwidJzmDnexYuWTsOEM="; got != want {
+		t.Errorf("hmacSHA256() = %s, want %s", got, want)
+	}
+}
+
+func TestSignature(t *testing.T) {
+	const secret = "b0xxxxxx-c6xxxxxx-94xxxxxx-dxxxx"
+	tests := []struct {
+		name         string
+		method, host string
+		path         string
+		query        url.Values
+		wantPrehash  string // 火币文档中的签名原文示例
+	}{
+		{
+			name:   "rest v2",
+			method: http.MethodGet,
+			host:   "api.huobi.pro",
+			path:   "/v1/order/orders",
+			query: url.Values{
+				"order-id":         {"1234567890"},
+				"AccessKeyId":      {"e2xxxxxx-99xxxxxx-84xxxxxx-7xxxx"},
+				"SignatureMethod":  {"HmacSHA256"},
+				"SignatureVersion": {"2"},
+				"Timestamp":        {"2017-05-11T15:19:30"},
+			},
+			wantPrehash: "GET\napi.huobi.pro\n/v1/order/orders\n" +
+				"AccessKeyId=e2xxxxxx-99xxxxxx-84xxxxxx-7xxxx&SignatureMethod=HmacSHA256&SignatureVersion=2&Timestamp=2017-05-11T15%3A19%3A30&order-id=1234567890",
+		},
+		{
+			name:   "ws v2.1",
+			method: http.MethodGet,
+			host:   "api.huobi.pro",
+			path:   "/ws/v2",
+			query: url.Values{
+				"accessKey":        {"0664b695-rfhfg5fgh-a5f78g14-ae9f1"},
+				"signatureMethod":  {"HmacSHA256"},
+				"signatureVersion": {"2.1"},
+				"timestamp":        {"2019-09-01T18:16:16"},
+			},
+			wantPrehash: "GET\napi.huobi.pro\n/ws/v2\n" +
+				"accessKey=0664b695-rfhfg5fgh-a5f78g14-ae9f1&signatureMethod=HmacSHA256&signatureVersion=2.1&timestamp=2019-09-01T18%3A16%3A16",
+		},
+		{
+			name:   "upper case host",
+			method: http.MethodPost,
+			host:   "API.HBDM.COM",
+			path:   "/linear-swap-api/v1/swap_cross_order",
+			query: url.Values{
+				"AccessKeyId":      {"e2xxxxxx-99xxxxxx-84xxxxxx-7xxxx"},
+				"SignatureMethod":  {"HmacSHA256"},
+				"SignatureVersion": {"2"},
+				"Timestamp":        {"2017-05-11T15:19:30"},
+			},
+			wantPrehash: "POST\napi.hbdm.com\n/linear-swap-api/v1/swap_cross_order\n" +
+				"AccessKeyId=e2xxxxxx-99xxxxxx-84xxxxxx-7xxxx&SignatureMethod=HmacSHA256&SignatureVersion=2&Timestamp=2017-05-11T15%3A19%3A30",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := signature(secret, tt.method, tt.host, tt.path, tt.query), hmacSHA256(secret, tt.wantPrehash); got != want {
+				t.Errorf("signature() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+// verifyV2 按文档格式重算请求的签名
+func verifyV2(r *http.Request, secret string) error {
+	q := r.URL.Query()
+	got := q.Get("Signature")
+	q.Del("Signature")
+	prehash := r.Method + "\n" + r.Host + "\n" + r.URL.Path + "\n" + q.Encode()
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(prehash))
+	if want := base64.StdEncoding.EncodeToString(mac.Sum(nil)); got != want {
+		return fmt.Errorf("Signature = %s, want %s", got, want)
+	}
+	if q.Get("AccessKeyId") != "key" || q.Get("SignatureMethod") != "HmacSHA256" || q.Get("SignatureVersion") != "2" || q.Get("Timestamp") == "" {
+		return fmt.Errorf("signature params = %v", q)
+	}
+	return nil
+}
+
+func TestRestClient_do(t *testing.T) {
+	const secret = "secret"
+	tests := []struct {
+		name     string
+		call     func(c *RestClient) (any, error)
+		respond  string
+		method   string
+		path     string
+		wantBody string
+		signed   bool
+		want     any
+		wantErr  error
+	}{
+		{
+			name:    "public",
+			call:    func(c *RestClient) (any, error) { return c.GetSymbols(context.Background()) },
+			respond: `{"status":"ok","data":[]}`,
+			method:  http.MethodGet,
+			path:    "/v1/common/symbols",
+			want:    []Symbol{},
+		},
+		{
+			name: "signed get with query",
+			call: func(c *RestClient) (any, error) {
+				return c.GetOpenOrders(context.Background(), OpenOrdersReq{AccountId: "1", Symbol: "btcusdt", Size: 10})
+			},
+			respond: `{"status":"ok","data":[]}`,
+			method:  http.MethodGet,
+			path:    "/v1/order/openOrders",
+			signed:  true,
+			want:    []SpotOrder{},
+		},
+		{
+			name: "signed post",
+			call: func(c *RestClient) (any, error) {
+				return c.CancelOrderByClientId(context.Background(), "c1")
+			},
+			respond:  `{"status":"ok","data":7}`,
+			method:   http.MethodPost,
+			path:     "/v1/order/orders/submitCancelClientOrder",
+			wantBody: `{"client-order-id":"c1"}`,
+			signed:   true,
+			want:     7,
+		},
+		{
+			name:    "spot error",
+			call:    func(c *RestClient) (any, error) { return c.GetAccounts(context.Background()) },
+			respond: `{"status":"error","err-code":"api-signature-not-valid","err-msg":"Signature not valid"}`,
+			method:  http.MethodGet,
+			path:    "/v1/account/accounts",
+			signed:  true,
+			want:    []Account(nil),
+			wantErr: &APIError{Status: "error", Code: "api-signature-not-valid", Msg: "Signature not valid"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reqErr error
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer fmt.Fprint(w, tt.respond)
+				body, _ := io.ReadAll(r.Body)
+				switch {
+				case r.Method != tt.method || r.URL.Path != tt.path || string(body) != tt.wantBody:
+					reqErr = fmt.Errorf("request = %s %s %s, want %s %s %s", r.Method, r.URL.Path, body, tt.method, tt.path, tt.wantBody)
+				case tt.signed:
+					reqErr = verifyV2(r, secret)
+				case r.URL.Query().Has("Signature"):
+					reqErr = errors.New("public request carries Signature")
+				}
+			}))
+			defer server.Close()
+
+			host := server.Listener.Addr().String()
+			c := NewRestClient("key", secret, SetSpotHost(host), SetHttpClient(server.Client()))
+			got, err := tt.call(c)
+			if reqErr != nil {
+				t.Fatal(reqErr)
+			}
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("result = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
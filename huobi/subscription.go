@@ -0,0 +1,163 @@
+package huobi
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const subscribeTimeout = 5 * time.Second
+
+var (
+	ErrSubscribeTimeout = errors.New("huobi: subscribe timeout")
+	ErrClientClosed     = errors.New("huobi: ws client closed")
+
+	idTag = []byte(`"id":`)
+)
+
+// subAck 服务端对 sub/unsub 的响应：
+// {"id":"1","status":"ok","subbed":"market.btcusdt.detail","ts":...}
+// {"id":"1","status":"error","err-code":"bad-request","err-msg":"invalid topic ...","ts":...}
+type subAck struct {
+	Id       string `json:"id"`
+	Status   string `json:"status"`
+	Subbed   string `json:"subbed"`
+	Unsubbed string `json:"unsubbed"`
+	ErrCode  string `json:"err-code"`
+	ErrMsg   string `json:"err-msg"`
+	Ts       int64  `json:"ts"`
+}
+
+// Subscribe 在运行中的连接上订阅主题并等待 subbed 确认；
+// 只有确认成功的主题进入订阅表，重连后自动重放，status:error 时返回 *APIError。
+// 同一主题正在等待确认时，后续调用等待同一个确认结果
+func (c *WsClient) Subscribe(topic string) error {
+	c.subsMu.Lock()
+	for _, t := range c.subs {
+		if t == topic {
+			c.subsMu.Unlock()
+			return nil
+		}
+	}
+	if w, ok := c.pending[topic]; ok {
+		c.subsMu.Unlock()
+		<-w.done
+		return w.err
+	}
+	w := &subWait{done: make(chan struct{})}
+	c.pending[topic] = w
+	c.subsMu.Unlock()
+
+	w.err = c.request("sub", topic)
+	c.subsMu.Lock()
+	if c.pending[topic] == w { // 确认前未被退订
+		delete(c.pending, topic)
+		if w.err == nil {
+			c.subs = append(c.subs, topic)
+		}
+	}
+	c.subsMu.Unlock()
+	close(w.done)
+	return w.err
+}
+
+// subWait 一次订阅请求的确认结果，done 关闭后 err 可读
+type subWait struct {
+	done chan struct{}
+	err  error
+}
+
+// Unsubscribe 退订并从订阅表移除，等待 unsubbed 确认
+func (c *WsClient) Unsubscribe(topic string) error {
+	c.forget(topic)
+	return c.request("unsub", topic)
+}
+
+// Subscriptions 当前订阅表中的主题
+func (c *WsClient) Subscriptions() []string {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+	return append([]string(nil), c.subs...)
+}
+
+func (c *WsClient) forget(topic string) {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+	delete(c.pending, topic)
+	for i, t := range c.subs {
+		if t == topic {
+			c.subs = append(c.subs[:i], c.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// request 发送 sub/unsub 并按 id 等待确认
+func (c *WsClient) request(op, topic string) error {
+	id := strconv.FormatInt(c.ackSeq.Add(1), 10)
+	bs, err := json.Marshal(map[string]string{op: topic, "id": id})
+	if err != nil {
+		return err
+	}
+
+	ch := make(chan subAck, 1)
+	c.subsMu.Lock()
+	c.acks[id] = ch
+	c.subsMu.Unlock()
+	defer func() {
+		c.subsMu.Lock()
+		delete(c.acks, id)
+		c.subsMu.Unlock()
+	}()
+
+	if err = c.safeWrite(websocket.TextMessage, bs); err != nil {
+		return err
+	}
+	select {
+	case ack := <-ch:
+		if ack.Status != "ok" {
+			return &APIError{Status: ack.Status, Code: ack.ErrCode, Msg: ack.ErrMsg}
+		}
+		return nil
+	case <-time.After(subscribeTimeout):
+		return ErrSubscribeTimeout
+	case <-c.closeCh:
+		return ErrClientClosed
+	}
+}
+
+func (c *WsClient) resolveAck(bs []byte) {
+	var ack subAck
+	if json.Unmarshal(bs, &ack) != nil || ack.Id == "" || ack.Status == "" {
+		return
+	}
+	c.subsMu.Lock()
+	ch, ok := c.acks[ack.Id]
+	delete(c.acks, ack.Id)
+	c.subsMu.Unlock()
+	if ok {
+		ch <- ack
+	}
+}
+
+// replaySubscriptions 在读循环启动前重放订阅表，确认由读循环照常分发；
+// 保活协程已在运行，写入需持有 writeMu。不走 safeWrite，写失败由 reDial 自行重试
+func (c *WsClient) replaySubscriptions() error {
+	for _, topic := range c.Subscriptions() {
+		bs, err := json.Marshal(map[string]string{"sub": topic, "id": strconv.FormatInt(c.ackSeq.Add(1), 10)})
+		if err != nil {
+			return err
+		}
+		c.writeMu.Lock()
+		_ = c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		err = c.conn.WriteMessage(websocket.TextMessage, bs)
+		c.writeMu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
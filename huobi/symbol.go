@@ -0,0 +1,38 @@
+package huobi
+
+import "strings"
+
+// quoteCurrencies 按后缀匹配，以其他计价币结尾的计价币必须排在前面（HUSD、TUSD 先于 USD），
+// 其余顺序无关
+var quoteCurrencies = []string{"USDT", "USDC", "USDD", "HUSD", "TUSD", "USD", "BTC", "ETH", "TRX", "HT"}
+
+// SplitSymbol 拆分 BTCUSDT / btcusdt / BTC-USDT / BTC_USDT / BTC/USDT 为大写的 base 与 quote
+func SplitSymbol(symbol string) (base, quote string, ok bool) {
+	s := strings.ToUpper(strings.TrimSpace(symbol))
+	for _, sep := range []string{"-", "_", "/"} {
+		if i := strings.Index(s, sep); i > 0 && i < len(s)-1 {
+			return s[:i], s[i+1:], true
+		}
+	}
+	for _, q := range quoteCurrencies {
+		if strings.HasSuffix(s, q) && len(s) > len(q) {
+			return s[:len(s)-len(q)], q, true
+		}
+	}
+	return s, "", false
+}
+
+// SpotSymbol 现货交易对：btcusdt
+func SpotSymbol(symbol string) string {
+	base, quote, _ := SplitSymbol(symbol)
+	return strings.ToLower(base + quote)
+}
+
+// SwapContractCode U 本位合约代码：BTC-USDT；无法识别计价币时原样转为大写
+func SwapContractCode(symbol string) string {
+	base, quote, ok := SplitSymbol(symbol)
+	if !ok {
+		return base
+	}
+	return base + "-" + quote
+}
@@ -0,0 +1,45 @@
+package huobi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitSymbol(t *testing.T) {
+	tests := []struct {
+		symbol      string
+		base, quote string
+		ok          bool
+	}{
+		{"btcusdt", "BTC", "USDT", true},
+		{"btchusd", "BTC", "HUSD", true},
+		{"btctusd", "BTC", "TUSD", true},
+		{"btcusd", "BTC", "USD", true},
+		{"ethbtc", "ETH", "BTC", true},
+		{"xrpht", "XRP", "HT", true},
+		{"trxeth", "TRX", "ETH", true},
+		{"BTC-USDT", "BTC", "USDT", true},
+		{"eth_usdc", "ETH", "USDC", true},
+		{"LTC/HUSD", "LTC", "HUSD", true},
+		{"usdt", "USDT", "", false},
+		{"abcxyz", "ABCXYZ", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symbol, func(t *testing.T) {
+			base, quote, ok := SplitSymbol(tt.symbol)
+			if base != tt.base || quote != tt.quote || ok != tt.ok {
+				t.Errorf("SplitSymbol(%q) = %q, %q, %v, want %q, %q, %v", tt.symbol, base, quote, ok, tt.base, tt.quote, tt.ok)
+			}
+		})
+	}
+}
+
+func TestQuoteCurrenciesOrder(t *testing.T) {
+	for i, q := range quoteCurrencies {
+		for _, later := range quoteCurrencies[i+1:] {
+			if later != q && strings.HasSuffix(later, q) {
+				t.Errorf("%s must come before its suffix %s", later, q)
+			}
+		}
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"io"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -72,12 +71,14 @@ type Options struct {
 	url           string
 	subscriber    func(*websocket.Conn, ...string) error // 重连成功后重新订阅
 	subscribeArgs []string
+	plainText     bool // /ws/v2 等推送未压缩的端点
+	workers       int  // 业务处理协程数，1 表示按收到顺序处理
 }
 
 func SubscribeMarket(conn *websocket.Conn, symbols ...string) error {
 	for _, symbol := range symbols {
 		bs, err := json.Marshal(map[string]any{
-			"sub": "market." + strings.Replace(symbol, "USDT", "-USDT", 1) + ".detail",
+			"sub": "market." + SwapContractCode(symbol) + ".detail",
 		})
 		if err != nil {
 			return err
@@ -91,7 +92,7 @@ func SubscribeMarket(conn *websocket.Conn, symbols ...string) error {
 func SubscribeSpotMarket(conn *websocket.Conn, symbols ...string) error {
 	for _, symbol := range symbols {
 		bs, err := json.Marshal(map[string]any{
-			"sub": "market." + strings.ToLower(symbol) + ".detail",
+			"sub": "market." + SpotSymbol(symbol) + ".detail",
 		})
 		if err != nil {
 			return err
@@ -106,6 +107,8 @@ func SetURL(url string) func(*Options) { return func(o *Options) { o.url = url }
 func SetSubscriber(f func(*websocket.Conn, ...string) error, a []string) func(*Options) {
 	return func(o *Options) { o.subscriber, o.subscribeArgs = f, a }
 }
+func SetPlainText() func(*Options)    { return func(o *Options) { o.plainText = true } }
+func SetWorkers(n int) func(*Options) { return func(o *Options) { o.workers = n } }
 func SetSpot() func(o *Options) {
 	return SetURL("wss://api.huobi.pro/ws")
 }
@@ -128,6 +131,12 @@ type WsClient struct {
 	pingCancel context.CancelFunc
 	closeCh    chan struct{}
 	isRedial   int32 // 0 = 空闲，1 = 正在重连
+
+	subsMu  sync.Mutex
+	subs    []string               // 已确认的动态订阅主题，按订阅顺序在重连后重放
+	pending map[string]*subWait    // 已发送、等待 subbed 确认的主题
+	acks    map[string]chan subAck // 等待 subbed/unsubbed 确认的请求 id
+	ackSeq  atomic.Int64
 }
 
 /* ---------- 构造函数 ---------- */
@@ -144,7 +153,9 @@ func NewWsClient(parent context.Context, handle func([]byte) error, opts ...func
 			HandshakeTimeout:  10 * time.Second,
 			EnableCompression: false, // 建议关闭，避免 1003 Unsupported Data
 		},
-		handle: handle,
+		handle:  handle,
+		pending: make(map[string]*subWait),
+		acks:    make(map[string]chan subAck),
 	}
 	c.ctx, c.cancel = context.WithCancel(parent)
 
@@ -154,6 +165,9 @@ func NewWsClient(parent context.Context, handle func([]byte) error, opts ...func
 	if c.url == "" {
 		c.url = "wss://api.hbdm.com/linear-swap-ws"
 	}
+	if c.workers <= 0 {
+		c.workers = 32
+	}
 
 	// 首次拨号
 	if err := c.reDial(); err != nil {
@@ -190,7 +204,7 @@ func (c *WsClient) readLoop() {
 	defer cancel() // readLoop 退出时终止所有 worker
 	// 使用轻量级 worker pool，解耦 socket IO 与业务处理
 	handleCh := make(chan []byte, 4096)
-	for i := 0; i < 32; i++ {
+	for i := 0; i < c.workers; i++ {
 		go func() {
 			for {
 				select {
@@ -229,12 +243,14 @@ func (c *WsClient) readLoop() {
 			return
 		}
 
-		// gunzip（火币所有业务数据均压缩）
-		if unzipped, e := ParseGzip(bs); e == nil {
-			bs = unzipped
-		} else {
-			zap.S().Warn("gzip 解压失败:", e)
-			continue
+		// gunzip（火币除 /ws/v2 外的业务数据均压缩）
+		if !c.plainText {
+			if unzipped, e := ParseGzip(bs); e == nil {
+				bs = unzipped
+			} else {
+				zap.S().Warn("gzip 解压失败:", e)
+				continue
+			}
 		}
 
 		// 处理服务器心跳 ping
@@ -250,6 +266,11 @@ func (c *WsClient) readLoop() {
 			continue
 		}
 
+		// 订阅确认先交给等待方，再照常进入业务处理
+		if bytes.Contains(bs, idTag) {
+			c.resolveAck(bs)
+		}
+
 		// 保证 socket 读不被阻塞；若 backlog 满则丢弃最旧消息
 		select {
 		case <-c.closeCh:
@@ -331,11 +352,29 @@ func (c *WsClient) reDial() error {
 			if err := c.subscriber(c.conn, c.subscribeArgs...); err != nil {
 				zap.S().Error("重订阅失败:", err)
 				_ = c.conn.Close()
-				continue
+				if _, ok := err.(*APIError); ok { // 鉴权被拒绝等业务错误，重试无意义
+					return err
+				}
+				select {
+				case <-time.After(backoff):
+					continue
+				case <-c.ctx.Done():
+					return c.ctx.Err()
+				}
 			}
 
 			zap.S().Infof("订阅成功")
 		}
+		if err := c.replaySubscriptions(); err != nil {
+			zap.S().Error("重放动态订阅失败:", err)
+			_ = c.conn.Close()
+			select {
+			case <-time.After(backoff):
+				continue
+			case <-c.ctx.Done():
+				return c.ctx.Err()
+			}
+		}
 
 		// ★ 重连成功后重新启动读循环
 		go c.readLoop()
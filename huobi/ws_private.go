@@ -0,0 +1,532 @@
+package huobi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	SpotPrivateWsURL = "wss://api.huobi.pro/ws/v2"
+	SwapPrivateWsURL = "wss://api.hbdm.com/linear-swap-notification"
+
+	authTimeout = 10 * time.Second
+)
+
+// 现货 /ws/v2 私有主题
+const (
+	TopicOrders        = "orders#*"
+	TopicTradeClearing = "trade.clearing#*"
+	TopicAccounts      = "accounts.update#" // 需追加 mode：0 仅余额变动 / 1 可用余额变动 / 2 两者
+)
+
+// U 本位合约 /linear-swap-notification 私有主题，后缀为小写合约代码或 *
+const (
+	TopicSwapOrders         = "orders.*"
+	TopicSwapOrdersCross    = "orders_cross.*"
+	TopicSwapPositions      = "positions.*"
+	TopicSwapPositionsCross = "positions_cross.*"
+
+	swapTopicOrdersPrefix    = "orders"
+	swapTopicPositionsPrefix = "positions"
+)
+
+/* ---------- 现货推送类型 ---------- */
+
+// OrderUpdate orders#${symbol}，字段随 EventType（creation / trade / cancellation / deletion）不同而取舍
+type OrderUpdate struct {
+	EventType       string `json:"eventType"`
+	Symbol          string `json:"symbol"`
+	AccountId       int64  `json:"accountId"`
+	OrderId         int64  `json:"orderId"`
+	ClientOrderId   string `json:"clientOrderId"`
+	OrderSide       string `json:"orderSide"`
+	OrderPrice      string `json:"orderPrice"`
+	OrderSize       string `json:"orderSize"`
+	OrderValue      string `json:"orderValue"`
+	Type            string `json:"type"`
+	OrderSource     string `json:"orderSource"`
+	OrderStatus     string `json:"orderStatus"`
+	OrderCreateTime int64  `json:"orderCreateTime"`
+	LastActTime     int64  `json:"lastActTime"`
+	TradePrice      string `json:"tradePrice"`
+	TradeVolume     string `json:"tradeVolume"`
+	TradeId         int64  `json:"tradeId"`
+	TradeTime       int64  `json:"tradeTime"`
+	Aggressor       bool   `json:"aggressor"`
+	RemainAmt       string `json:"remainAmt"`
+	ExecAmt         string `json:"execAmt"`
+}
+
+// TradeClearing trade.clearing#${symbol}#${mode}
+type TradeClearing struct {
+	EventType       string `json:"eventType"`
+	Symbol          string `json:"symbol"`
+	OrderId         int64  `json:"orderId"`
+	ClientOrderId   string `json:"clientOrderId"`
+	AccountId       int64  `json:"accountId"`
+	Source          string `json:"source"`
+	OrderSide       string `json:"orderSide"`
+	OrderType       string `json:"orderType"`
+	OrderPrice      string `json:"orderPrice"`
+	OrderSize       string `json:"orderSize"`
+	OrderValue      string `json:"orderValue"`
+	OrderStatus     string `json:"orderStatus"`
+	OrderCreateTime int64  `json:"orderCreateTime"`
+	TradePrice      string `json:"tradePrice"`
+	TradeVolume     string `json:"tradeVolume"`
+	TradeId         int64  `json:"tradeId"`
+	TradeTime       int64  `json:"tradeTime"`
+	Aggressor       bool   `json:"aggressor"`
+	TransactFee     string `json:"transactFee"`
+	FeeCurrency     string `json:"feeCurrency"`
+	FeeDeduct       string `json:"feeDeduct"`
+	FeeDeductType   string `json:"feeDeductType"`
+}
+
+// AccountUpdate accounts.update#${mode}
+type AccountUpdate struct {
+	Currency    string `json:"currency"`
+	AccountId   int64  `json:"accountId"`
+	Balance     string `json:"balance"`
+	Available   string `json:"available"`
+	ChangeType  string `json:"changeType"`
+	AccountType string `json:"accountType"` // trade / frozen / loan / interest
+	ChangeTime  int64  `json:"changeTime"`
+	SeqNum      int64  `json:"seqNum"`
+}
+
+/* ---------- 合约推送类型 ---------- */
+
+type SwapTrade struct {
+	Id            string  `json:"id"`
+	TradeId       int64   `json:"trade_id"`
+	TradeVolume   float64 `json:"trade_volume"`
+	TradePrice    float64 `json:"trade_price"`
+	TradeFee      float64 `json:"trade_fee"`
+	TradeTurnover float64 `json:"trade_turnover"`
+	CreatedAt     int64   `json:"created_at"`
+	Role          string  `json:"role"`
+	FeeAsset      string  `json:"fee_asset"`
+}
+
+// SwapOrderNotify orders.$contract_code / orders_cross.$contract_code
+type SwapOrderNotify struct {
+	Topic          string      `json:"topic"`
+	Ts             int64       `json:"ts"`
+	Symbol         string      `json:"symbol"`
+	ContractCode   string      `json:"contract_code"`
+	Volume         float64     `json:"volume"`
+	Price          float64     `json:"price"`
+	OrderPriceType string      `json:"order_price_type"`
+	Direction      string      `json:"direction"`
+	Offset         string      `json:"offset"`
+	Status         int         `json:"status"`
+	LeverRate      int         `json:"lever_rate"`
+	OrderId        int64       `json:"order_id"`
+	OrderIdStr     string      `json:"order_id_str"`
+	ClientOrderId  int64       `json:"client_order_id"`
+	OrderSource    string      `json:"order_source"`
+	OrderType      int         `json:"order_type"`
+	CreatedAt      int64       `json:"created_at"`
+	TradeVolume    float64     `json:"trade_volume"`
+	TradeTurnover  float64     `json:"trade_turnover"`
+	Fee            float64     `json:"fee"`
+	TradeAvgPrice  float64     `json:"trade_avg_price"`
+	MarginFrozen   float64     `json:"margin_frozen"`
+	Profit         float64     `json:"profit"`
+	Trade          []SwapTrade `json:"trade"`
+	CanceledSource string      `json:"canceled_source"`
+	MarginMode     string      `json:"margin_mode"`
+	MarginAccount  string      `json:"margin_account"`
+	ReduceOnly     int         `json:"reduce_only"`
+}
+
+// SwapPositionNotify positions.$contract_code / positions_cross.$contract_code
+type SwapPositionNotify struct {
+	Topic string         `json:"topic"`
+	Ts    int64          `json:"ts"`
+	Event string         `json:"event"` // init / order.match / settlement / snapshot ...
+	Data  []SwapPosition `json:"data"`
+}
+
+/* ---------- 客户端 ---------- */
+
+// PrivateHandlers 私有推送回调，未设置回调的消息交给 Raw
+type PrivateHandlers struct {
+	Orders        func(OrderUpdate)
+	TradeClearing func(TradeClearing)
+	Accounts      func(AccountUpdate)
+	SwapOrders    func(SwapOrderNotify)
+	SwapPositions func(SwapPositionNotify)
+	Raw           func([]byte) error
+}
+
+// PrivateWsClient 私有推送客户端，每次拨号（含重连）都会重新鉴权并订阅全部主题
+type PrivateWsClient struct {
+	*WsClient
+	accessKey string
+	secretKey string
+	swap      bool
+	host      string
+	path      string
+	hand      PrivateHandlers
+
+	mu     sync.Mutex
+	topics []string
+}
+
+// NewSpotPrivateWsClient 连接 /ws/v2，topics 如 TopicOrders / TopicTradeClearing / AccountsTopic(1)
+func NewSpotPrivateWsClient(parent context.Context, accessKey, secretKey string, hand PrivateHandlers, topics ...string) (*PrivateWsClient, error) {
+	return newPrivateWsClient(parent, SpotPrivateWsURL, false, accessKey, secretKey, hand, topics)
+}
+
+// NewSwapPrivateWsClient 连接 /linear-swap-notification，topics 如 TopicSwapOrdersCross / TopicSwapPositionsCross
+func NewSwapPrivateWsClient(parent context.Context, accessKey, secretKey string, hand PrivateHandlers, topics ...string) (*PrivateWsClient, error) {
+	return newPrivateWsClient(parent, SwapPrivateWsURL, true, accessKey, secretKey, hand, topics)
+}
+
+func newPrivateWsClient(parent context.Context, wsURL string, swap bool, accessKey, secretKey string, hand PrivateHandlers, topics []string) (*PrivateWsClient, error) {
+	u, err := url.Parse(wsURL)
+	if err != nil {
+		return nil, err
+	}
+	p := &PrivateWsClient{
+		accessKey: accessKey,
+		secretKey: secretKey,
+		swap:      swap,
+		host:      u.Host,
+		path:      u.Path,
+		hand:      hand,
+	}
+	p.addTopics(topics)
+	opts := []func(*Options){
+		SetURL(wsURL),
+		SetSubscriber(p.login, nil),
+		SetWorkers(1), // 订单状态需按推送顺序处理
+	}
+	if !swap {
+		opts = append(opts, SetPlainText())
+	}
+	if p.WsClient, err = NewWsClient(parent, p.handle, opts...); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Subscribe 追加订阅主题，重连后自动重新订阅
+func (p *PrivateWsClient) Subscribe(topics ...string) error {
+	p.mu.Lock()
+	fresh := p.addTopics(topics)
+	p.mu.Unlock()
+	for _, t := range fresh {
+		bs, err := p.subMessage(t)
+		if err != nil {
+			return err
+		}
+		if err = p.safeWrite(websocket.TextMessage, bs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addTopics 追加未订阅过的主题并返回它们，已有主题不重复发送也不重复重放；调用方持有 mu
+func (p *PrivateWsClient) addTopics(topics []string) []string {
+	fresh := make([]string, 0, len(topics))
+	for _, t := range topics {
+		if !slices.Contains(p.topics, t) {
+			p.topics = append(p.topics, t)
+			fresh = append(fresh, t)
+		}
+	}
+	return fresh
+}
+
+// Unsubscribe 退订主题并从重连订阅列表中移除
+func (p *PrivateWsClient) Unsubscribe(topics ...string) error {
+	p.mu.Lock()
+	kept := p.topics[:0]
+	for _, t := range p.topics {
+		if !slices.Contains(topics, t) {
+			kept = append(kept, t)
+		}
+	}
+	p.topics = kept
+	p.mu.Unlock()
+
+	for _, t := range topics {
+		var msg map[string]string
+		if p.swap {
+			msg = map[string]string{"op": "unsub", "cid": strconv.FormatInt(time.Now().UnixNano(), 10), "topic": t}
+		} else {
+			msg = map[string]string{"action": "unsub", "ch": t}
+		}
+		bs, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		if err = p.safeWrite(websocket.TextMessage, bs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/* ============================== 鉴权 ============================== */
+
+// login 作为 WsClient 的 subscriber 在读循环启动前执行：同步等待鉴权结果再发送订阅
+func (p *PrivateWsClient) login(conn *websocket.Conn, _ ...string) error {
+	bs, err := json.Marshal(p.authMessage())
+	if err != nil {
+		return err
+	}
+	if err = conn.WriteMessage(websocket.TextMessage, bs); err != nil {
+		return err
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(authTimeout))
+	defer func() { _ = conn.SetReadDeadline(time.Now().Add(readTimeout)) }()
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if p.swap {
+			if msg, err = ParseGzip(msg); err != nil {
+				return err
+			}
+		}
+		done, err := p.authResult(conn, msg)
+		if err != nil {
+			return err
+		}
+		if done {
+			break
+		}
+	}
+	zap.S().Infof("[huobi][private] %s auth ok", p.path)
+
+	p.mu.Lock()
+	topics := append([]string(nil), p.topics...)
+	p.mu.Unlock()
+	for _, t := range topics {
+		bs, err := p.subMessage(t)
+		if err != nil {
+			return err
+		}
+		if err = conn.WriteMessage(websocket.TextMessage, bs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authMessage 现货 v2 使用签名版本 2.1，合约通知使用签名版本 2
+func (p *PrivateWsClient) authMessage() map[string]any {
+	ts := time.Now().UTC().Format("2006-01-02T15:04:05")
+	q := url.Values{}
+	if p.swap {
+		q.Set("AccessKeyId", p.accessKey)
+		q.Set("SignatureMethod", "HmacSHA256")
+		q.Set("SignatureVersion", "2")
+		q.Set("Timestamp", ts)
+	} else {
+		q.Set("accessKey", p.accessKey)
+		q.Set("signatureMethod", "HmacSHA256")
+		q.Set("signatureVersion", "2.1")
+		q.Set("timestamp", ts)
+	}
+	sig := signature(p.secretKey, http.MethodGet, p.host, p.path, q)
+
+	if p.swap {
+		return map[string]any{
+			"op":               "auth",
+			"type":             "api",
+			"AccessKeyId":      p.accessKey,
+			"SignatureMethod":  "HmacSHA256",
+			"SignatureVersion": "2",
+			"Timestamp":        ts,
+			"Signature":        sig,
+		}
+	}
+	return map[string]any{
+		"action": "req",
+		"ch":     "auth",
+		"params": map[string]string{
+			"authType":         "api",
+			"accessKey":        p.accessKey,
+			"signatureMethod":  "HmacSHA256",
+			"signatureVersion": "2.1",
+			"timestamp":        ts,
+			"signature":        sig,
+		},
+	}
+}
+
+// authResult 鉴权完成前服务端可能先发来 ping，需要照常回复
+func (p *PrivateWsClient) authResult(conn *websocket.Conn, msg []byte) (bool, error) {
+	var m privateMessage
+	if err := json.Unmarshal(msg, &m); err != nil {
+		return false, err
+	}
+	if pong := m.pong(); pong != nil {
+		return false, conn.WriteMessage(websocket.TextMessage, pong)
+	}
+	switch {
+	case !p.swap && m.Action == "req" && m.Ch == "auth":
+		if m.Code != 200 {
+			return false, &APIError{Code: strconv.Itoa(m.Code), Msg: m.Message}
+		}
+		return true, nil
+	case p.swap && m.Op == "auth":
+		if m.ErrCode.String() != "0" {
+			return false, &APIError{Code: m.ErrCode.String(), Msg: m.ErrMsg}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p *PrivateWsClient) subMessage(topic string) ([]byte, error) {
+	if p.swap {
+		return json.Marshal(map[string]string{"op": "sub", "cid": strconv.FormatInt(time.Now().UnixNano(), 10), "topic": topic})
+	}
+	return json.Marshal(map[string]string{"action": "sub", "ch": topic})
+}
+
+/* ============================== 推送 ============================== */
+
+// privateMessage 兼容 /ws/v2（action/ch/code）与合约通知（op/topic/err-code）两种报文
+type privateMessage struct {
+	Action  string          `json:"action"`
+	Ch      string          `json:"ch"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Op      string          `json:"op"`
+	Topic   string          `json:"topic"`
+	Ts      int64           `json:"ts"`
+	ErrCode json.Number     `json:"err-code"`
+	ErrMsg  string          `json:"err-msg"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// pong 心跳报文返回对应的回复，否则返回 nil
+func (m *privateMessage) pong() []byte {
+	switch {
+	case m.Action == "ping":
+		bs, _ := json.Marshal(map[string]any{"action": "pong", "data": m.Data})
+		return bs
+	case m.Op == "ping":
+		bs, _ := json.Marshal(map[string]any{"op": "pong", "ts": m.Ts})
+		return bs
+	}
+	return nil
+}
+
+func (p *PrivateWsClient) handle(bs []byte) error {
+	var m privateMessage
+	if err := json.Unmarshal(bs, &m); err != nil {
+		zap.S().Warnf("[huobi][private] decode err: %s, raw: %s", err, string(bs))
+		return err
+	}
+	if pong := m.pong(); pong != nil {
+		if p.WsClient == nil {
+			return nil
+		}
+		return p.safeWrite(websocket.TextMessage, pong)
+	}
+
+	var err error
+	handled := false
+	switch {
+	case m.Action == "push":
+		handled, err = p.spotPush(m)
+	case m.Op == "notify":
+		handled, err = p.swapNotify(m, bs)
+	case m.Action == "sub" && m.Code != 200:
+		zap.S().Errorf("[huobi][private] sub %s err: code=%d message=%s", m.Ch, m.Code, m.Message)
+	case m.Op == "sub" && m.ErrCode.String() != "0":
+		zap.S().Errorf("[huobi][private] sub %s err: err-code=%s err-msg=%s", m.Topic, m.ErrCode, m.ErrMsg)
+	case m.Op == "close" || m.Op == "error":
+		err = errors.New("huobi: private ws " + m.Op + ": " + string(bs))
+	}
+	if err != nil {
+		zap.S().Warnf("[huobi][private] handle err: %s", err)
+	}
+	if handled {
+		return err
+	}
+	return p.raw(bs)
+}
+
+func (p *PrivateWsClient) spotPush(m privateMessage) (bool, error) {
+	switch {
+	case strings.HasPrefix(m.Ch, "orders#") && p.hand.Orders != nil:
+		var v OrderUpdate
+		if err := json.Unmarshal(m.Data, &v); err != nil {
+			return true, err
+		}
+		p.hand.Orders(v)
+	case strings.HasPrefix(m.Ch, "trade.clearing#") && p.hand.TradeClearing != nil:
+		var v TradeClearing
+		if err := json.Unmarshal(m.Data, &v); err != nil {
+			return true, err
+		}
+		p.hand.TradeClearing(v)
+	case strings.HasPrefix(m.Ch, "accounts.update#") && p.hand.Accounts != nil:
+		if len(m.Data) == 0 || string(m.Data) == "{}" { // 订阅成功后的首条空推送
+			return true, nil
+		}
+		var v AccountUpdate
+		if err := json.Unmarshal(m.Data, &v); err != nil {
+			return true, err
+		}
+		p.hand.Accounts(v)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// swapNotify 合约订单推送字段位于顶层，持仓推送位于 data
+func (p *PrivateWsClient) swapNotify(m privateMessage, bs []byte) (bool, error) {
+	switch {
+	case strings.HasPrefix(m.Topic, swapTopicOrdersPrefix) && p.hand.SwapOrders != nil:
+		var v SwapOrderNotify
+		if err := json.Unmarshal(bs, &v); err != nil {
+			return true, err
+		}
+		p.hand.SwapOrders(v)
+	case strings.HasPrefix(m.Topic, swapTopicPositionsPrefix) && p.hand.SwapPositions != nil:
+		var v SwapPositionNotify
+		if err := json.Unmarshal(bs, &v); err != nil {
+			return true, err
+		}
+		p.hand.SwapPositions(v)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+func (p *PrivateWsClient) raw(bs []byte) error {
+	if p.hand.Raw != nil {
+		return p.hand.Raw(bs)
+	}
+	return nil
+}
+
+// AccountsTopic mode: 0 仅余额变动 / 1 可用余额变动 / 2 两者
+func AccountsTopic(mode int) string { return fmt.Sprintf("%s%d", TopicAccounts, mode) }
@@ -0,0 +1,244 @@
+package huobi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeHuobiPrivate 校验鉴权签名后按 reject 应答，鉴权成功后记录订阅主题；合约端点的报文经 gzip 压缩
+type fakeHuobiPrivate struct {
+	*httptest.Server
+	swap    bool
+	mu      sync.Mutex
+	conn    *websocket.Conn
+	authErr error
+	topics  []string
+}
+
+func newFakeHuobiPrivate(t *testing.T, swap, reject bool) *fakeHuobiPrivate {
+	f := &fakeHuobiPrivate{swap: swap}
+	upgrader := websocket.Upgrader{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		f.mu.Lock()
+		f.conn = c
+		f.mu.Unlock()
+
+		_, data, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+		authErr := f.verifyAuth(r, data)
+		f.mu.Lock()
+		f.authErr = authErr
+		f.mu.Unlock()
+		switch {
+		case swap && reject:
+			f.push(t, `{"op":"auth","type":"api","err-code":2003,"err-msg":"Authentication failed."}`)
+			return
+		case swap:
+			f.push(t, `{"op":"auth","type":"api","err-code":0,"data":{"user-id":"1"}}`)
+		case reject:
+			f.push(t, `{"action":"req","code":2002,"ch":"auth","message":"invalid.auth.state"}`)
+			return
+		default:
+			f.push(t, `{"action":"req","code":200,"ch":"auth","data":{}}`)
+		}
+
+		for {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			var sub struct {
+				Action string `json:"action"`
+				Ch     string `json:"ch"`
+				Op     string `json:"op"`
+				Topic  string `json:"topic"`
+			}
+			if json.Unmarshal(data, &sub) != nil {
+				continue
+			}
+			f.mu.Lock()
+			switch {
+			case sub.Action == "sub":
+				f.topics = append(f.topics, sub.Ch)
+			case sub.Op == "sub":
+				f.topics = append(f.topics, sub.Topic)
+			}
+			f.mu.Unlock()
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// verifyAuth 按文档格式重算鉴权报文中的签名：现货 v2.1 参数小写开头，合约 v2 参数大写开头
+func (f *fakeHuobiPrivate) verifyAuth(r *http.Request, data []byte) error {
+	var msg struct {
+		Action string            `json:"action"`
+		Ch     string            `json:"ch"`
+		Params map[string]string `json:"params"`
+		Op     string            `json:"op"`
+		Type   string            `json:"type"`
+	}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+	params := msg.Params
+	keys := []string{"accessKey", "signatureMethod", "signatureVersion", "timestamp", "signature"}
+	if f.swap {
+		_ = json.Unmarshal(data, &params)
+		keys = []string{"AccessKeyId", "SignatureMethod", "SignatureVersion", "Timestamp", "Signature"}
+		if msg.Op != "auth" || msg.Type != "api" {
+			return fmt.Errorf("auth message = %s", data)
+		}
+	} else if msg.Action != "req" || msg.Ch != "auth" || params["authType"] != "api" {
+		return fmt.Errorf("auth message = %s", data)
+	}
+	q := url.Values{}
+	for _, k := range keys[:4] {
+		q.Set(k, params[k])
+	}
+	if q.Get(keys[0]) != "key" || q.Get(keys[1]) != "HmacSHA256" || q.Get(keys[3]) == "" {
+		return fmt.Errorf("auth params = %v", params)
+	}
+	if want := hmacSHA256("secret", "GET\n"+r.Host+"\n"+r.URL.Path+"\n"+q.Encode()); params[keys[4]] != want {
+		return fmt.Errorf("signature = %s, want %s", params[keys[4]], want)
+	}
+	return nil
+}
+
+func (f *fakeHuobiPrivate) push(t *testing.T, msg string) {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.swap {
+		_ = f.conn.WriteMessage(websocket.TextMessage, []byte(msg))
+		return
+	}
+	var b bytes.Buffer
+	zw := gzip.NewWriter(&b)
+	zw.Write([]byte(msg))
+	zw.Close()
+	_ = f.conn.WriteMessage(websocket.BinaryMessage, b.Bytes())
+}
+
+func (f *fakeHuobiPrivate) auth() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.authErr
+}
+
+func (f *fakeHuobiPrivate) subscribed() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.topics...)
+}
+
+func waitFor(timeout time.Duration, cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestPrivateWsClient_auth(t *testing.T) {
+	tests := []struct {
+		name    string
+		swap    bool
+		reject  bool
+		path    string
+		topic   string
+		push    string
+		wantErr error
+	}{
+		{
+			name:  "spot v2.1",
+			path:  "/ws/v2",
+			topic: TopicOrders,
+			push:  `{"action":"push","ch":"orders#btcusdt","data":{"eventType":"creation","symbol":"btcusdt","orderId":1}}`,
+		},
+		{
+			name:  "swap v2",
+			swap:  true,
+			path:  "/linear-swap-notification",
+			topic: TopicSwapOrdersCross,
+			push:  `{"op":"notify","topic":"orders_cross.btc-usdt","contract_code":"BTC-USDT","order_id":1}`,
+		},
+		{
+			name:    "spot rejected",
+			reject:  true,
+			path:    "/ws/v2",
+			topic:   TopicOrders,
+			wantErr: &APIError{Code: "2002", Msg: "invalid.auth.state"},
+		},
+		{
+			name:    "swap rejected",
+			swap:    true,
+			reject:  true,
+			path:    "/linear-swap-notification",
+			topic:   TopicSwapOrdersCross,
+			wantErr: &APIError{Code: "2003", Msg: "Authentication failed."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeHuobiPrivate(t, tt.swap, tt.reject)
+			orders := make(chan int64, 1)
+			hand := PrivateHandlers{
+				Orders:     func(o OrderUpdate) { orders <- o.OrderId },
+				SwapOrders: func(o SwapOrderNotify) { orders <- o.OrderId },
+			}
+			wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + tt.path
+			p, err := newPrivateWsClient(context.Background(), wsURL, tt.swap, "key", "secret", hand, []string{tt.topic})
+			if authErr := server.auth(); authErr != nil {
+				t.Fatal(authErr)
+			}
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("newPrivateWsClient() error = %#v, want %#v", err, tt.wantErr)
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				return
+			}
+			defer p.Close()
+
+			want := []string{tt.topic}
+			if !waitFor(time.Second, func() bool { return reflect.DeepEqual(server.subscribed(), want) }) {
+				t.Fatalf("topics = %v, want %v", server.subscribed(), want)
+			}
+			server.push(t, tt.push)
+			select {
+			case id := <-orders:
+				if id != 1 {
+					t.Errorf("order id = %d, want 1", id)
+				}
+			case <-time.After(time.Second):
+				t.Error("order handler not called")
+			}
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package okx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// 增量深度频道
+const (
+	ChannelBooks        = "books"          // 400 档，100ms
+	ChannelBooksL2Tbt   = "books-l2-tbt"   // 400 档，逐笔
+	ChannelBooks50L2Tbt = "books50-l2-tbt" // 50 档，逐笔
+)
+
+const (
+	bookChecksumDepth    = 25
+	bookActionSnapshot   = "snapshot"
+	bookActionUpdate     = "update"
+	bookResubscribeAlarm = 3 // 连续重订阅超过此次数记录错误日志
+)
+
+var errBookNotReady = errors.New("okx: order book not ready")
+
+type bookSeqError struct{ expect, got int64 }
+
+func (e *bookSeqError) Error() string {
+	return fmt.Sprintf("okx: book sequence broken, expect prevSeqId=%d got=%d", e.expect, e.got)
+}
+
+type bookChecksumError struct{ expect, got int32 }
+
+func (e *bookChecksumError) Error() string {
+	return fmt.Sprintf("okx: book checksum mismatch, expect=%d got=%d", e.expect, e.got)
+}
+
+// BookLevel 一档深度，保留原始字符串用于 checksum
+type BookLevel struct {
+	Px     string
+	Sz     string
+	Orders string
+	Price  float64
+	Size   float64
+}
+
+func parseBookLevel(a []string) (l BookLevel, err error) {
+	if len(a) < 2 {
+		return l, strconv.ErrSyntax
+	}
+	l.Px, l.Sz = a[0], a[1]
+	if len(a) > 3 {
+		l.Orders = a[3]
+	}
+	if l.Price, err = strconv.ParseFloat(l.Px, 64); err != nil {
+		return
+	}
+	l.Size, err = strconv.ParseFloat(l.Sz, 64)
+	return
+}
+
+/* ============================== 单个交易对 ============================== */
+
+// LocalOrderBook 本地维护的单个交易对深度，所有方法并发安全
+type LocalOrderBook struct {
+	mu     sync.RWMutex
+	instId string
+	bids   []BookLevel // 价格降序
+	asks   []BookLevel // 价格升序
+	seqId  int64
+	ts     int64
+	valid  bool
+}
+
+func (b *LocalOrderBook) InstId() string { return b.instId }
+
+// IsValid 快照已就绪且未发生序列/校验中断
+func (b *LocalOrderBook) IsValid() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.valid
+}
+
+func (b *LocalOrderBook) SeqId() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.seqId
+}
+
+// Ts 最近一次更新的交易所时间戳(ms)
+func (b *LocalOrderBook) Ts() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.ts
+}
+
+func (b *LocalOrderBook) BestBid() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid || len(b.bids) == 0 {
+		return BookLevel{}, false
+	}
+	return b.bids[0], true
+}
+
+func (b *LocalOrderBook) BestAsk() (BookLevel, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid || len(b.asks) == 0 {
+		return BookLevel{}, false
+	}
+	return b.asks[0], true
+}
+
+// Depth 返回前 n 档的拷贝，n <= 0 返回全部
+func (b *LocalOrderBook) Depth(n int) (bids, asks []BookLevel) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.valid {
+		return nil, nil
+	}
+	return topLevels(b.bids, n), topLevels(b.asks, n)
+}
+
+func topLevels(src []BookLevel, n int) []BookLevel {
+	if n <= 0 || n > len(src) {
+		n = len(src)
+	}
+	dst := make([]BookLevel, n)
+	copy(dst, src[:n])
+	return dst
+}
+
+func (b *LocalOrderBook) invalidate() {
+	b.mu.Lock()
+	b.valid = false
+	b.mu.Unlock()
+}
+
+func (b *LocalOrderBook) snapshot(d OrderBook) error {
+	bids, err := parseLevels(d.Bids)
+	if err != nil {
+		return err
+	}
+	asks, err := parseLevels(d.Asks)
+	if err != nil {
+		return err
+	}
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bids, b.asks = bids, asks
+	b.seqId = d.SeqId
+	b.ts, _ = strconv.ParseInt(d.Ts, 10, 64)
+	b.valid = true
+	return b.verify(d.Checksum)
+}
+
+func (b *LocalOrderBook) update(d OrderBook) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.valid {
+		return errBookNotReady
+	}
+	if d.PrevSeqId != b.seqId {
+		b.valid = false
+		return &bookSeqError{expect: b.seqId, got: d.PrevSeqId}
+	}
+	for _, a := range d.Bids {
+		l, err := parseBookLevel(a)
+		if err != nil {
+			b.valid = false
+			return err
+		}
+		b.bids = applyLevel(b.bids, l, true)
+	}
+	for _, a := range d.Asks {
+		l, err := parseBookLevel(a)
+		if err != nil {
+			b.valid = false
+			return err
+		}
+		b.asks = applyLevel(b.asks, l, false)
+	}
+	b.seqId = d.SeqId
+	b.ts, _ = strconv.ParseInt(d.Ts, 10, 64)
+	if err := b.verify(d.Checksum); err != nil {
+		b.valid = false
+		return err
+	}
+	return nil
+}
+
+// verify 调用方需持有写锁
+func (b *LocalOrderBook) verify(expect int32) error {
+	if got := bookChecksum(b.bids, b.asks); got != expect {
+		b.valid = false
+		return &bookChecksumError{expect: expect, got: got}
+	}
+	return nil
+}
+
+// applyLevel 插入/替换/删除(sz=0) 一档，desc 表示价格降序
+func applyLevel(levels []BookLevel, l BookLevel, desc bool) []BookLevel {
+	i := sort.Search(len(levels), func(i int) bool {
+		if desc {
+			return levels[i].Price <= l.Price
+		}
+		return levels[i].Price >= l.Price
+	})
+	found := i < len(levels) && levels[i].Price == l.Price
+	switch {
+	case l.Size == 0:
+		if found {
+			levels = append(levels[:i], levels[i+1:]...)
+		}
+	case found:
+		levels[i] = l
+	default:
+		levels = append(levels, BookLevel{})
+		copy(levels[i+1:], levels[i:])
+		levels[i] = l
+	}
+	return levels
+}
+
+func parseLevels(src [][]string) ([]BookLevel, error) {
+	levels := make([]BookLevel, 0, len(src))
+	for _, a := range src {
+		l, err := parseBookLevel(a)
+		if err != nil {
+			return nil, err
+		}
+		if l.Size != 0 {
+			levels = append(levels, l)
+		}
+	}
+	return levels, nil
+}
+
+// bookChecksum OKX 深度校验：前 25 档 bid/ask 交替拼接 "px:sz"，取 CRC32 的有符号值
+func bookChecksum(bids, asks []BookLevel) int32 {
+	parts := make([]string, 0, bookChecksumDepth*4)
+	for i := 0; i < bookChecksumDepth; i++ {
+		if i < len(bids) {
+			parts = append(parts, bids[i].Px, bids[i].Sz)
+		}
+		if i < len(asks) {
+			parts = append(parts, asks[i].Px, asks[i].Sz)
+		}
+	}
+	return int32(crc32.ChecksumIEEE([]byte(strings.Join(parts, ":"))))
+}
+
+/* ============================== 管理器 ============================== */
+
+// OKExV5OrderBook 基于 books / books-l2-tbt 维护本地深度，
+// 序列中断或校验失败时自动重新订阅对应交易对以获取新快照
+type OKExV5OrderBook struct {
+	pub      *OKExV5WsPublic
+	channel  string
+	mu       sync.RWMutex
+	books    map[string]*LocalOrderBook
+	failures map[string]int
+	onChange func(book *LocalOrderBook)
+}
+
+// NewOKExV5OrderBook channel 为空时默认 books；onChange 在每次快照/增量成功应用后回调
+func NewOKExV5OrderBook(cfg *APIConfig, channel string, onChange func(book *LocalOrderBook)) *OKExV5OrderBook {
+	if channel == "" {
+		channel = ChannelBooks
+	}
+	m := &OKExV5OrderBook{
+		channel:  channel,
+		books:    make(map[string]*LocalOrderBook),
+		failures: make(map[string]int),
+		onChange: onChange,
+	}
+	m.pub = NewOKExV5WsPublic(cfg, m.handle, func(err error) {
+		if err == nil { // 重连后等待新的快照
+			m.invalidateAll()
+		}
+	})
+	return m
+}
+
+// Subscribe 订阅一个或多个交易对的增量深度
+func (m *OKExV5OrderBook) Subscribe(instIds ...string) error {
+	args := make([]WsArg, 0, len(instIds))
+	m.mu.Lock()
+	for _, id := range instIds {
+		if _, ok := m.books[id]; !ok {
+			m.books[id] = &LocalOrderBook{instId: id}
+		}
+		args = append(args, WsArg{Channel: m.channel, InstId: id})
+	}
+	m.mu.Unlock()
+	return m.pub.Subscribe(map[string]any{"op": "subscribe", "args": args})
+}
+
+// Book 返回交易对的本地深度，未订阅返回 nil
+func (m *OKExV5OrderBook) Book(instId string) *LocalOrderBook {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.books[instId]
+}
+
+func (m *OKExV5OrderBook) BestBid(instId string) (BookLevel, bool) {
+	if b := m.Book(instId); b != nil {
+		return b.BestBid()
+	}
+	return BookLevel{}, false
+}
+
+func (m *OKExV5OrderBook) BestAsk(instId string) (BookLevel, bool) {
+	if b := m.Book(instId); b != nil {
+		return b.BestAsk()
+	}
+	return BookLevel{}, false
+}
+
+func (m *OKExV5OrderBook) Depth(instId string, n int) (bids, asks []BookLevel) {
+	if b := m.Book(instId); b != nil {
+		return b.Depth(n)
+	}
+	return nil, nil
+}
+
+func (m *OKExV5OrderBook) invalidateAll() {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, b := range m.books {
+		b.invalidate()
+	}
+}
+
+func (m *OKExV5OrderBook) handle(bs []byte) error {
+	if string(bs) == "pong" {
+		return nil
+	}
+	var msg WsMessage
+	if err := json.Unmarshal(bs, &msg); err != nil {
+		return err
+	}
+	if msg.Event == "error" {
+		zap.S().Errorf("[okx][book] code=%s msg=%s", msg.Code, msg.Msg)
+		return nil
+	}
+	if msg.Arg == nil || msg.Arg.Channel != m.channel || len(msg.Data) == 0 {
+		return nil
+	}
+
+	book := m.Book(msg.Arg.InstId)
+	if book == nil {
+		return nil
+	}
+
+	var data []OrderBook
+	if err := json.Unmarshal(msg.Data, &data); err != nil {
+		return err
+	}
+	for _, d := range data {
+		var err error
+		switch msg.Action {
+		case bookActionSnapshot:
+			err = book.snapshot(d)
+		case bookActionUpdate:
+			err = book.update(d)
+		default:
+			continue
+		}
+		if err == errBookNotReady { // 等待重新订阅后的快照
+			return nil
+		}
+		if err != nil {
+			zap.S().Warnf("[okx][book][%s] %s, resubscribe", book.instId, err)
+			m.resubscribe(book.instId)
+			return nil
+		}
+	}
+
+	m.mu.Lock()
+	delete(m.failures, book.instId)
+	m.mu.Unlock()
+	if m.onChange != nil {
+		m.onChange(book)
+	}
+	return nil
+}
+
+// resubscribe 先退订再订阅，服务端会重新推送快照
+func (m *OKExV5OrderBook) resubscribe(instId string) {
+	m.mu.Lock()
+	m.failures[instId]++
+	n := m.failures[instId]
+	m.mu.Unlock()
+	if n > bookResubscribeAlarm {
+		zap.S().Errorf("[okx][book][%s] resubscribed %d times in a row", instId, n)
+	}
+
+	arg := WsArg{Channel: m.channel, InstId: instId}
+	if err := m.pub.WsConn.Unsubscribe(arg); err != nil {
+		zap.S().Error(err)
+		return
+	}
+	if err := m.pub.WsConn.Subscribe(map[string]any{"op": "subscribe", "args": []WsArg{arg}}); err != nil {
+		zap.S().Error(err)
+	}
+}
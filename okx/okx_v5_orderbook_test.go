@@ -0,0 +1,256 @@
+package okx
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func levels(a ...[]string) []BookLevel {
+	ls, err := parseLevels(a)
+	if err != nil {
+		panic(err)
+	}
+	return ls
+}
+
+// 示例取自 OKX 文档“深度校验”一节
+func TestBookChecksum(t *testing.T) {
+	tests := []struct {
+		name string
+		bids []BookLevel
+		asks []BookLevel
+		want int32 // crc32 of the joined string in name
+	}{
+		{
+			name: "3366.1:7:3366.8:9:3366:6:3368:8",
+			bids: levels([]string{"3366.1", "7", "0", "3"}, []string{"3366", "6", "3", "4"}),
+			asks: levels([]string{"3366.8", "9", "10", "3"}, []string{"3368", "8", "3", "4"}),
+			want: -1881014294,
+		},
+		{
+			name: "3366.1:7:3366.8:9:3368:8:3372:8",
+			bids: levels([]string{"3366.1", "7", "0", "3"}),
+			asks: levels([]string{"3366.8", "9", "10", "3"}, []string{"3368", "8", "3", "4"}, []string{"3372", "8", "0", "1"}),
+			want: 831078360,
+		},
+		{
+			name: "3366.1:7:3366.8:9:3366:6:3368:8:3365:5",
+			bids: levels([]string{"3366.1", "7"}, []string{"3366", "6"}, []string{"3365", "5"}),
+			asks: levels([]string{"3366.8", "9"}, []string{"3368", "8"}),
+			want: -1838148868,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bookChecksum(tt.bids, tt.asks); got != tt.want {
+				t.Errorf("bookChecksum() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+var (
+	bookSnapshot = OrderBook{
+		Bids:     [][]string{{"3366.1", "7", "0", "3"}, {"3366", "6", "3", "4"}},
+		Asks:     [][]string{{"3366.8", "9", "10", "3"}, {"3368", "8", "3", "4"}},
+		Ts:       "1597026383085",
+		Checksum: -1881014294,
+		SeqId:    10,
+	}
+	// 删除 bid 3366、新增 ask 3372 后得到文档第二个示例
+	bookDelta = OrderBook{
+		Bids:      [][]string{{"3366", "0", "0", "0"}},
+		Asks:      [][]string{{"3372", "8", "0", "1"}},
+		Ts:        "1597026383086",
+		Checksum:  831078360,
+		PrevSeqId: 10,
+		SeqId:     11,
+	}
+)
+
+func TestLocalOrderBook(t *testing.T) {
+	gap := bookDelta
+	gap.PrevSeqId = 9
+	badSum := bookDelta
+	badSum.Checksum = 1
+
+	tests := []struct {
+		name      string
+		snapshot  bool
+		updates   []OrderBook
+		wantErr   error
+		wantValid bool
+		wantSeq   int64
+		wantBids  []string
+		wantAsks  []string
+	}{
+		{
+			name:      "snapshot",
+			snapshot:  true,
+			wantValid: true,
+			wantSeq:   10,
+			wantBids:  []string{"3366.1", "3366"},
+			wantAsks:  []string{"3366.8", "3368"},
+		},
+		{
+			name:      "delta",
+			snapshot:  true,
+			updates:   []OrderBook{bookDelta},
+			wantValid: true,
+			wantSeq:   11,
+			wantBids:  []string{"3366.1"},
+			wantAsks:  []string{"3366.8", "3368", "3372"},
+		},
+		{
+			name:    "delta before snapshot",
+			updates: []OrderBook{bookDelta},
+			wantErr: errBookNotReady,
+		},
+		{
+			name:     "seq gap",
+			snapshot: true,
+			updates:  []OrderBook{gap},
+			wantErr:  &bookSeqError{expect: 10, got: 9},
+			wantSeq:  10,
+		},
+		{
+			name:     "checksum mismatch",
+			snapshot: true,
+			updates:  []OrderBook{badSum},
+			wantErr:  &bookChecksumError{expect: 1, got: 831078360},
+			wantSeq:  11,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &LocalOrderBook{instId: "BTC-USDT"}
+			if tt.snapshot {
+				if err := b.snapshot(bookSnapshot); err != nil {
+					t.Fatalf("snapshot() error = %v", err)
+				}
+			}
+			var err error
+			for _, u := range tt.updates {
+				if err = b.update(u); err != nil {
+					break
+				}
+			}
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("update() error = %v, want %v", err, tt.wantErr)
+			}
+			if b.IsValid() != tt.wantValid {
+				t.Errorf("IsValid() = %v, want %v", b.IsValid(), tt.wantValid)
+			}
+			if b.SeqId() != tt.wantSeq {
+				t.Errorf("SeqId() = %d, want %d", b.SeqId(), tt.wantSeq)
+			}
+			bids, asks := b.Depth(0)
+			if got := prices(bids); !reflect.DeepEqual(got, tt.wantBids) {
+				t.Errorf("bids = %v, want %v", got, tt.wantBids)
+			}
+			if got := prices(asks); !reflect.DeepEqual(got, tt.wantAsks) {
+				t.Errorf("asks = %v, want %v", got, tt.wantAsks)
+			}
+		})
+	}
+}
+
+func prices(ls []BookLevel) []string {
+	var px []string
+	for _, l := range ls {
+		px = append(px, l.Px)
+	}
+	return px
+}
+
+// push 向最近订阅 instId 的连接推送一条 books 消息
+func (f *fakeOKX) push(t *testing.T, instId, action string, d OrderBook) {
+	t.Helper()
+	bs, err := json.Marshal(map[string]any{
+		"arg":    WsArg{Channel: ChannelBooks, InstId: instId},
+		"action": action,
+		"data":   []OrderBook{d},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.mu.Lock()
+	c := f.conns[f.lastSub(instId).conn]
+	f.mu.Unlock()
+	c.write(bs)
+}
+
+// ops instId 收到的 subscribe/unsubscribe 序列
+func (f *fakeOKX) ops(instId string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var ops []string
+	for _, s := range f.subs {
+		if s.arg.InstId == instId {
+			ops = append(ops, s.op)
+		}
+	}
+	return ops
+}
+
+// lastSub 调用方需持有 f.mu
+func (f *fakeOKX) lastSub(instId string) fakeSub {
+	var sub fakeSub
+	for _, s := range f.subs {
+		if s.arg.InstId == instId {
+			sub = s
+		}
+	}
+	return sub
+}
+
+func TestOKExV5OrderBook_seqGapResubscribes(t *testing.T) {
+	server := newFakeOKX(t)
+	changed := make(chan int64, 8)
+	m := NewOKExV5OrderBook(&APIConfig{Endpoint: server.url()}, ChannelBooks, func(b *LocalOrderBook) {
+		changed <- b.SeqId()
+	})
+	if err := m.Subscribe("BTC-USDT"); err != nil {
+		t.Fatal(err)
+	}
+	defer m.pub.WsConn.CloseWs()
+	if !waitFor(t, time.Second, func() bool { return len(server.ops("BTC-USDT")) == 1 }) {
+		t.Fatal("subscribe not received")
+	}
+
+	server.push(t, "BTC-USDT", bookActionSnapshot, bookSnapshot)
+	server.push(t, "BTC-USDT", bookActionUpdate, bookDelta)
+	for _, want := range []int64{10, 11} {
+		select {
+		case got := <-changed:
+			if got != want {
+				t.Fatalf("onChange seqId = %d, want %d", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("onChange seqId %d not called", want)
+		}
+	}
+
+	gap := bookDelta
+	gap.PrevSeqId, gap.SeqId = 12, 13
+	server.push(t, "BTC-USDT", bookActionUpdate, gap)
+	want := []string{"subscribe", "unsubscribe", "subscribe"}
+	if !waitFor(t, time.Second, func() bool { return reflect.DeepEqual(server.ops("BTC-USDT"), want) }) {
+		t.Fatalf("ops = %v, want %v", server.ops("BTC-USDT"), want)
+	}
+	book := m.Book("BTC-USDT")
+	if book.IsValid() {
+		t.Error("book still valid after seq gap")
+	}
+	if _, ok := m.BestBid("BTC-USDT"); ok {
+		t.Error("BestBid() ok on invalid book")
+	}
+
+	// 重新订阅后服务端推送新快照，深度恢复
+	server.push(t, "BTC-USDT", bookActionSnapshot, bookSnapshot)
+	if !waitFor(t, time.Second, book.IsValid) {
+		t.Fatal("book not valid after new snapshot")
+	}
+}
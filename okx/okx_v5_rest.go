@@ -0,0 +1,321 @@
+package okx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const OKExV5RestEndpoint = "https://www.okx.com"
+
+// APIError OKX 返回的业务错误，批量/交易类接口会附带首个失败订单的 sCode/sMsg
+type APIError struct {
+	Code  string `json:"code"`
+	Msg   string `json:"msg"`
+	SCode string `json:"sCode,omitempty"`
+	SMsg  string `json:"sMsg,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	if e.SCode != "" && e.SCode != e.Code {
+		return fmt.Sprintf("okx: code=%s msg=%s sCode=%s sMsg=%s", e.Code, e.Msg, e.SCode, e.SMsg)
+	}
+	return fmt.Sprintf("okx: code=%s msg=%s", e.Code, e.Msg)
+}
+
+type restResponse struct {
+	Code string          `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+type OKExV5Rest struct {
+	cfg *APIConfig
+}
+
+func NewOKExV5Rest(cfg *APIConfig) *OKExV5Rest {
+	if cfg == nil {
+		cfg = &APIConfig{}
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = OKExV5RestEndpoint
+	}
+	if cfg.HttpClient == nil {
+		cfg.HttpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &OKExV5Rest{cfg: cfg}
+}
+
+/* ============================== 行情 ============================== */
+
+// GetInstruments instType: SPOT/MARGIN/SWAP/FUTURES/OPTION
+func (c *OKExV5Rest) GetInstruments(instType, instFamily, instId string) (result []Instrument, err error) {
+	q := url.Values{}
+	q.Set("instType", instType)
+	setIf(q, "instFamily", instFamily)
+	setIf(q, "instId", instId)
+	err = c.do(http.MethodGet, "/api/v5/public/instruments", q, nil, false, &result)
+	return
+}
+
+func (c *OKExV5Rest) GetTickers(instType, instFamily string) (result []Ticker, err error) {
+	q := url.Values{}
+	q.Set("instType", instType)
+	setIf(q, "instFamily", instFamily)
+	err = c.do(http.MethodGet, "/api/v5/market/tickers", q, nil, false, &result)
+	return
+}
+
+func (c *OKExV5Rest) GetTicker(instId string) (result Ticker, err error) {
+	var ret []Ticker
+	if err = c.do(http.MethodGet, "/api/v5/market/ticker", url.Values{"instId": {instId}}, nil, false, &ret); err != nil {
+		return
+	}
+	if len(ret) > 0 {
+		result = ret[0]
+	}
+	return
+}
+
+// GetBooks sz: 深度档位，最大 400，0 表示默认 1 档
+func (c *OKExV5Rest) GetBooks(instId string, sz int) (result OrderBook, err error) {
+	q := url.Values{"instId": {instId}}
+	if sz > 0 {
+		q.Set("sz", strconv.Itoa(sz))
+	}
+	var ret []OrderBook
+	if err = c.do(http.MethodGet, "/api/v5/market/books", q, nil, false, &ret); err != nil {
+		return
+	}
+	if len(ret) > 0 {
+		result = ret[0]
+	}
+	return
+}
+
+// GetCandles bar: 1m/3m/5m/15m/30m/1H/2H/4H/6H/12H/1D/1W/1M ...
+// after/before 为毫秒时间戳分页，0 表示不限
+func (c *OKExV5Rest) GetCandles(instId, bar string, after, before int64, limit int) (result []Candle, err error) {
+	q := url.Values{"instId": {instId}}
+	setIf(q, "bar", bar)
+	if after > 0 {
+		q.Set("after", strconv.FormatInt(after, 10))
+	}
+	if before > 0 {
+		q.Set("before", strconv.FormatInt(before, 10))
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	err = c.do(http.MethodGet, "/api/v5/market/candles", q, nil, false, &result)
+	return
+}
+
+/* ============================== 交易 ============================== */
+
+func (c *OKExV5Rest) PlaceOrder(req PlaceOrderReq) (OrderResult, error) {
+	return c.orderOne("/api/v5/trade/order", req)
+}
+
+func (c *OKExV5Rest) AmendOrder(req AmendOrderReq) (OrderResult, error) {
+	return c.orderOne("/api/v5/trade/amend-order", req)
+}
+
+func (c *OKExV5Rest) CancelOrder(req CancelOrderReq) (OrderResult, error) {
+	return c.orderOne("/api/v5/trade/cancel-order", req)
+}
+
+// BatchOrders 最多 20 笔；部分失败时同时返回全部结果与 *APIError
+func (c *OKExV5Rest) BatchOrders(reqs []PlaceOrderReq) ([]OrderResult, error) {
+	return c.orderBatch("/api/v5/trade/batch-orders", reqs)
+}
+
+func (c *OKExV5Rest) BatchAmendOrders(reqs []AmendOrderReq) ([]OrderResult, error) {
+	return c.orderBatch("/api/v5/trade/amend-batch-orders", reqs)
+}
+
+func (c *OKExV5Rest) BatchCancelOrders(reqs []CancelOrderReq) ([]OrderResult, error) {
+	return c.orderBatch("/api/v5/trade/cancel-batch-orders", reqs)
+}
+
+// GetOrder ordId 与 clOrdId 二选一
+func (c *OKExV5Rest) GetOrder(instId, ordId, clOrdId string) (result Order, err error) {
+	q := url.Values{"instId": {instId}}
+	setIf(q, "ordId", ordId)
+	setIf(q, "clOrdId", clOrdId)
+	var ret []Order
+	if err = c.do(http.MethodGet, "/api/v5/trade/order", q, nil, true, &ret); err != nil {
+		return
+	}
+	if len(ret) > 0 {
+		result = ret[0]
+	}
+	return
+}
+
+func (c *OKExV5Rest) GetPendingOrders(req OrderHistoryReq) (result []Order, err error) {
+	err = c.do(http.MethodGet, "/api/v5/trade/orders-pending", req.values(), nil, true, &result)
+	return
+}
+
+// GetOrderHistory 近七天的历史订单，InstType 必填
+func (c *OKExV5Rest) GetOrderHistory(req OrderHistoryReq) (result []Order, err error) {
+	err = c.do(http.MethodGet, "/api/v5/trade/orders-history", req.values(), nil, true, &result)
+	return
+}
+
+func (c *OKExV5Rest) orderOne(path string, req any) (result OrderResult, err error) {
+	ret, err := c.orderBatch(path, req)
+	if len(ret) > 0 {
+		result = ret[0]
+		if err == nil {
+			err = result.Err()
+		}
+	}
+	return
+}
+
+func (c *OKExV5Rest) orderBatch(path string, body any) (result []OrderResult, err error) {
+	err = c.do(http.MethodPost, path, nil, body, true, &result)
+	if e, ok := err.(*APIError); ok {
+		err = orderError(e.Code, e.Msg, result)
+	}
+	return
+}
+
+// orderError 交易类请求失败时，附带首个失败订单的 sCode/sMsg
+func orderError(code, msg string, results []OrderResult) error {
+	e := &APIError{Code: code, Msg: msg}
+	for _, r := range results {
+		if r.SCode != "" && r.SCode != "0" {
+			e.SCode, e.SMsg = r.SCode, r.SMsg
+			break
+		}
+	}
+	return e
+}
+
+func (r OrderHistoryReq) values() url.Values {
+	q := url.Values{}
+	setIf(q, "instType", r.InstType)
+	setIf(q, "instId", r.InstId)
+	setIf(q, "ordType", r.OrdType)
+	setIf(q, "state", r.State)
+	setIf(q, "after", r.After)
+	setIf(q, "before", r.Before)
+	if r.Limit > 0 {
+		q.Set("limit", strconv.Itoa(r.Limit))
+	}
+	return q
+}
+
+/* ============================== 账户 ============================== */
+
+// GetBalance ccy 多个币种用逗号分隔，空为全部
+func (c *OKExV5Rest) GetBalance(ccy string) (result AccountBalance, err error) {
+	q := url.Values{}
+	setIf(q, "ccy", ccy)
+	var ret []AccountBalance
+	if err = c.do(http.MethodGet, "/api/v5/account/balance", q, nil, true, &ret); err != nil {
+		return
+	}
+	if len(ret) > 0 {
+		result = ret[0]
+	}
+	return
+}
+
+func (c *OKExV5Rest) GetPositions(instType, instId string) (result []Position, err error) {
+	q := url.Values{}
+	setIf(q, "instType", instType)
+	setIf(q, "instId", instId)
+	err = c.do(http.MethodGet, "/api/v5/account/positions", q, nil, true, &result)
+	return
+}
+
+func (c *OKExV5Rest) SetLeverage(req SetLeverageReq) (result []Leverage, err error) {
+	err = c.do(http.MethodPost, "/api/v5/account/set-leverage", nil, req, true, &result)
+	return
+}
+
+// GetLeverage mgnMode: cross / isolated
+func (c *OKExV5Rest) GetLeverage(instId, mgnMode string) (result []Leverage, err error) {
+	q := url.Values{"instId": {instId}, "mgnMode": {mgnMode}}
+	err = c.do(http.MethodGet, "/api/v5/account/leverage-info", q, nil, true, &result)
+	return
+}
+
+/* ============================== 请求与签名 ============================== */
+
+func (c *OKExV5Rest) do(method, path string, query url.Values, body any, signed bool, result any) error {
+	requestPath := path
+	if len(query) > 0 {
+		requestPath += "?" + query.Encode()
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.cfg.Endpoint+requestPath, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if c.cfg.Simulated {
+		req.Header.Set("x-simulated-trading", "1")
+	}
+	if signed {
+		ts := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+		req.Header.Set("OK-ACCESS-KEY", c.cfg.ApiKey)
+		req.Header.Set("OK-ACCESS-PASSPHRASE", c.cfg.ApiPassphrase)
+		req.Header.Set("OK-ACCESS-TIMESTAMP", ts)
+		req.Header.Set("OK-ACCESS-SIGN", sign(c.cfg.ApiSecretKey, ts+method+requestPath+string(payload)))
+	}
+
+	resp, err := c.cfg.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var ret restResponse
+	if err = json.Unmarshal(bs, &ret); err != nil {
+		zap.S().Errorf("[okx][rest] %s %s status=%d body=%s", method, path, resp.StatusCode, string(bs))
+		return err
+	}
+	if ret.Code != "0" {
+		// 交易类接口失败时 data 仍携带逐笔 sCode/sMsg；data 格式不符时忽略，不掩盖 APIError
+		if len(ret.Data) > 0 && result != nil {
+			_ = json.Unmarshal(ret.Data, result)
+		}
+		return &APIError{Code: ret.Code, Msg: ret.Msg}
+	}
+	if len(ret.Data) > 0 && result != nil {
+		return json.Unmarshal(ret.Data, result)
+	}
+	return nil
+}
+
+func setIf(q url.Values, k, v string) {
+	if v != "" {
+		q.Set(k, v)
+	}
+}
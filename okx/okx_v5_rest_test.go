@@ -0,0 +1,156 @@
+package okx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// sign 即 Base64(HmacSHA256(prehash, SecretKey))，用 RFC 4231 的 HMAC-SHA256 测试向量校验
+func TestSign(t *testing.T) {
+	tests := []struct {
+		secret, prehash, want string
+	}{
+		{"Jefe", "what do ya want for nothing?", "W9zBRr9gdU5qBCQmCJV1x1oAPwidJzmDnexYuWTsOEM="},
+		{"key", "The quick brown fox jumps over the lazy dog", "97yD9DBThCSxMpjmqm+xQ+9NWaFJRhdZl0edvC0aPNg="},
+	}
+	for _, tt := range tests {
+		if got := sign(tt.secret, tt.prehash); got != tt.want {
+			t.Errorf("sign(%q, %q) = %s, want %s", tt.secret, tt.prehash, got, tt.want)
+		}
+	}
+}
+
+func TestOKExV5Rest_do(t *testing.T) {
+	const secret = "secret"
+	tests := []struct {
+		name    string
+		call    func(c *OKExV5Rest) (any, error)
+		respond string
+		method  string
+		path    string // 含 query 的 requestPath
+		body    string
+		signed  bool
+		want    any
+		wantErr error
+	}{
+		{
+			name: "public get",
+			call: func(c *OKExV5Rest) (any, error) {
+				return c.GetTicker("BTC-USDT")
+			},
+			respond: `{"code":"0","msg":"","data":[{"instId":"BTC-USDT","last":"30000"}]}`,
+			method:  http.MethodGet,
+			path:    "/api/v5/market/ticker?instId=BTC-USDT",
+			want:    Ticker{InstId: "BTC-USDT", Last: "30000"},
+		},
+		{
+			name: "signed get with query",
+			call: func(c *OKExV5Rest) (any, error) {
+				return c.GetPositions("SWAP", "BTC-USDT-SWAP")
+			},
+			respond: `{"code":"0","msg":"","data":[]}`,
+			method:  http.MethodGet,
+			path:    "/api/v5/account/positions?instId=BTC-USDT-SWAP&instType=SWAP",
+			signed:  true,
+			want:    []Position{},
+		},
+		{
+			name: "signed post",
+			call: func(c *OKExV5Rest) (any, error) {
+				return c.PlaceOrder(PlaceOrderReq{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "limit", Sz: "1", Px: "30000"})
+			},
+			respond: `{"code":"0","msg":"","data":[{"ordId":"1","clOrdId":"","sCode":"0","sMsg":""}]}`,
+			method:  http.MethodPost,
+			path:    "/api/v5/trade/order",
+			body:    `{"instId":"BTC-USDT","tdMode":"cash","side":"buy","ordType":"limit","sz":"1","px":"30000"}`,
+			signed:  true,
+			want:    OrderResult{OrdId: "1", SCode: "0"},
+		},
+		{
+			name: "order sCode propagated",
+			call: func(c *OKExV5Rest) (any, error) {
+				return c.PlaceOrder(PlaceOrderReq{InstId: "BTC-USDT", TdMode: "cash", Side: "buy", OrdType: "market", Sz: "1"})
+			},
+			respond: `{"code":"1","msg":"Operation failed.","data":[{"ordId":"","sCode":"51008","sMsg":"Insufficient balance"}]}`,
+			method:  http.MethodPost,
+			path:    "/api/v5/trade/order",
+			body:    `{"instId":"BTC-USDT","tdMode":"cash","side":"buy","ordType":"market","sz":"1"}`,
+			signed:  true,
+			want:    OrderResult{SCode: "51008", SMsg: "Insufficient balance"},
+			wantErr: &APIError{Code: "1", Msg: "Operation failed.", SCode: "51008", SMsg: "Insufficient balance"},
+		},
+		{
+			name: "error with object data",
+			call: func(c *OKExV5Rest) (any, error) {
+				return c.GetPositions("SWAP", "")
+			},
+			respond: `{"code":"50113","msg":"Invalid Sign","data":{}}`,
+			method:  http.MethodGet,
+			path:    "/api/v5/account/positions?instType=SWAP",
+			signed:  true,
+			want:    []Position(nil),
+			wantErr: &APIError{Code: "50113", Msg: "Invalid Sign"},
+		},
+		{
+			name: "error with string data",
+			call: func(c *OKExV5Rest) (any, error) {
+				return c.GetTicker("BAD")
+			},
+			respond: `{"code":"51001","msg":"Instrument ID does not exist","data":""}`,
+			method:  http.MethodGet,
+			path:    "/api/v5/market/ticker?instId=BAD",
+			want:    Ticker{},
+			wantErr: &APIError{Code: "51001", Msg: "Instrument ID does not exist"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotErr error
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer fmt.Fprint(w, tt.respond)
+				body, _ := io.ReadAll(r.Body)
+				if r.Method != tt.method || r.URL.RequestURI() != tt.path || string(body) != tt.body {
+					gotErr = fmt.Errorf("request = %s %s %s, want %s %s %s", r.Method, r.URL.RequestURI(), body, tt.method, tt.path, tt.body)
+					return
+				}
+				ts := r.Header.Get("OK-ACCESS-TIMESTAMP")
+				if !tt.signed {
+					if ts != "" || r.Header.Get("OK-ACCESS-SIGN") != "" {
+						gotErr = errors.New("public request carries signature headers")
+					}
+					return
+				}
+				mac := hmac.New(sha256.New, []byte(secret))
+				mac.Write([]byte(ts + tt.method + tt.path + tt.body))
+				want := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+				if got := r.Header.Get("OK-ACCESS-SIGN"); got != want {
+					gotErr = fmt.Errorf("OK-ACCESS-SIGN = %s, want %s", got, want)
+				}
+				if r.Header.Get("OK-ACCESS-KEY") != "key" || r.Header.Get("OK-ACCESS-PASSPHRASE") != "pass" {
+					gotErr = errors.New("missing key or passphrase header")
+				}
+			}))
+			defer server.Close()
+
+			c := NewOKExV5Rest(&APIConfig{Endpoint: server.URL, ApiKey: "key", ApiSecretKey: secret, ApiPassphrase: "pass"})
+			got, err := tt.call(c)
+			if gotErr != nil {
+				t.Fatal(gotErr)
+			}
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("error = %#v, want %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("result = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
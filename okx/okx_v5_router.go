@@ -0,0 +1,167 @@
+package okx
+
+import (
+	"encoding/json"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// 公共频道
+const (
+	ChannelTickers      = "tickers"
+	ChannelTrades       = "trades"
+	ChannelCandlePrefix = "candle" // candle1m / candle1H / candle1D ...
+	ChannelBooks5       = "books5"
+	ChannelBboTbt       = "bbo-tbt"
+	ChannelFundingRate  = "funding-rate"
+	ChannelMarkPrice    = "mark-price"
+	ChannelOpenInterest = "open-interest"
+	ChannelIndexTickers = "index-tickers"
+)
+
+type routeKey struct {
+	channel string
+	instId  string // 空表示该频道全部交易对
+}
+
+// OKExV5Router 按 channel + instId 把推送解码成具体结构再分发
+type OKExV5Router struct {
+	mu       sync.RWMutex
+	routes   map[routeKey]func(arg WsArg, data json.RawMessage) error
+	onEvent  func(msg WsMessage)
+	fallback func([]byte) error
+}
+
+// NewOKExV5Router fallback 处理未注册频道的原始消息，可为 nil
+func NewOKExV5Router(fallback func([]byte) error) *OKExV5Router {
+	return &OKExV5Router{
+		routes:   make(map[routeKey]func(WsArg, json.RawMessage) error),
+		fallback: fallback,
+	}
+}
+
+// NewOKExV5WsPublicRouter 使用路由器作为消息处理函数，Subscribe 会等待服务端确认
+func NewOKExV5WsPublicRouter(cfg *APIConfig, r *OKExV5Router, connected func(err error)) *OKExV5WsPublic {
+	pub := NewOKExV5WsPublic(cfg, r.Handle, connected)
+	pub.router = r
+	return pub
+}
+
+/* ---------- 注册 ---------- */
+
+// route 注册 channel + instId 的类型化回调，instId 为空匹配全部交易对
+func route[T any](r *OKExV5Router, channel, instId string, f func(WsArg, []T)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[routeKey{channel, instId}] = func(arg WsArg, raw json.RawMessage) error {
+		var data []T
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return err
+		}
+		f(arg, data)
+		return nil
+	}
+}
+
+func (r *OKExV5Router) OnTickers(instId string, f func(WsArg, []Ticker)) {
+	route(r, ChannelTickers, instId, f)
+}
+
+func (r *OKExV5Router) OnTrades(instId string, f func(WsArg, []Trade)) {
+	route(r, ChannelTrades, instId, f)
+}
+
+// OnCandles bar: 1m/3m/5m/15m/30m/1H/2H/4H/1D ...
+func (r *OKExV5Router) OnCandles(bar, instId string, f func(WsArg, []Candle)) {
+	route(r, ChannelCandlePrefix+bar, instId, f)
+}
+
+func (r *OKExV5Router) OnBooks5(instId string, f func(WsArg, []OrderBook)) {
+	route(r, ChannelBooks5, instId, f)
+}
+
+func (r *OKExV5Router) OnBboTbt(instId string, f func(WsArg, []OrderBook)) {
+	route(r, ChannelBboTbt, instId, f)
+}
+
+func (r *OKExV5Router) OnFundingRate(instId string, f func(WsArg, []FundingRate)) {
+	route(r, ChannelFundingRate, instId, f)
+}
+
+func (r *OKExV5Router) OnMarkPrice(instId string, f func(WsArg, []MarkPrice)) {
+	route(r, ChannelMarkPrice, instId, f)
+}
+
+func (r *OKExV5Router) OnOpenInterest(instId string, f func(WsArg, []OpenInterest)) {
+	route(r, ChannelOpenInterest, instId, f)
+}
+
+func (r *OKExV5Router) OnIndexTickers(instId string, f func(WsArg, []IndexTicker)) {
+	route(r, ChannelIndexTickers, instId, f)
+}
+
+// OnEvent 接收所有 subscribe/unsubscribe/error 等事件
+func (r *OKExV5Router) OnEvent(f func(msg WsMessage)) {
+	r.mu.Lock()
+	r.onEvent = f
+	r.mu.Unlock()
+}
+
+// Remove 注销 channel + instId 的回调
+func (r *OKExV5Router) Remove(channel, instId string) {
+	r.mu.Lock()
+	delete(r.routes, routeKey{channel, instId})
+	r.mu.Unlock()
+}
+
+/* ---------- 分发 ---------- */
+
+func (r *OKExV5Router) Handle(bs []byte) error {
+	if string(bs) == "pong" {
+		return nil
+	}
+	var msg WsMessage
+	if err := json.Unmarshal(bs, &msg); err != nil {
+		zap.S().Warnf("[okx][router] decode err: %s, raw: %s", err, string(bs))
+		return err
+	}
+
+	if msg.Event != "" {
+		r.handleEvent(msg)
+		return nil
+	}
+	if msg.Arg == nil {
+		return r.raw(bs)
+	}
+
+	r.mu.RLock()
+	h, ok := r.routes[routeKey{msg.Arg.Channel, msg.Arg.InstId}]
+	if !ok {
+		h, ok = r.routes[routeKey{msg.Arg.Channel, ""}]
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return r.raw(bs)
+	}
+	return h(*msg.Arg, msg.Data)
+}
+
+func (r *OKExV5Router) handleEvent(msg WsMessage) {
+	if msg.Event == "error" {
+		zap.S().Errorf("[okx][router] id=%s code=%s msg=%s", msg.Id, msg.Code, msg.Msg)
+	}
+	r.mu.RLock()
+	f := r.onEvent
+	r.mu.RUnlock()
+	if f != nil {
+		f(msg)
+	}
+}
+
+func (r *OKExV5Router) raw(bs []byte) error {
+	if r.fallback != nil {
+		return r.fallback(bs)
+	}
+	return nil
+}
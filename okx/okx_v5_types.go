@@ -0,0 +1,454 @@
+package okx
+
+import "encoding/json"
+
+// WsArg 订阅参数，同时也是推送消息中的 arg 字段
+type WsArg struct {
+	Channel    string `json:"channel"`
+	InstType   string `json:"instType,omitempty"`
+	InstFamily string `json:"instFamily,omitempty"`
+	InstId     string `json:"instId,omitempty"`
+	Ccy        string `json:"ccy,omitempty"`
+	Uid        string `json:"uid,omitempty"`
+}
+
+// WsMessage OKX v5 WS 下行消息的统一外壳（事件 / 推送）
+type WsMessage struct {
+	Id      string          `json:"id,omitempty"` // 请求携带 id 时原样返回
+	Op      string          `json:"op,omitempty"` // 交易类请求的响应
+	Event   string          `json:"event,omitempty"`
+	Code    string          `json:"code,omitempty"`
+	Msg     string          `json:"msg,omitempty"`
+	ConnId  string          `json:"connId,omitempty"`
+	Arg     *WsArg          `json:"arg,omitempty"`
+	Action  string          `json:"action,omitempty"` // books: snapshot / update
+	Data    json.RawMessage `json:"data,omitempty"`
+	InTime  string          `json:"inTime,omitempty"`
+	OutTime string          `json:"outTime,omitempty"`
+}
+
+/* ---------- 账户 ---------- */
+
+type AccountBalance struct {
+	UTime       string                 `json:"uTime"`
+	TotalEq     string                 `json:"totalEq"`
+	IsoEq       string                 `json:"isoEq"`
+	AdjEq       string                 `json:"adjEq"`
+	OrdFroz     string                 `json:"ordFroz"`
+	Imr         string                 `json:"imr"`
+	Mmr         string                 `json:"mmr"`
+	BorrowFroz  string                 `json:"borrowFroz"`
+	MgnRatio    string                 `json:"mgnRatio"`
+	NotionalUsd string                 `json:"notionalUsd"`
+	Upl         string                 `json:"upl"`
+	Details     []AccountBalanceDetail `json:"details"`
+}
+
+type AccountBalanceDetail struct {
+	Ccy           string `json:"ccy"`
+	Eq            string `json:"eq"`
+	CashBal       string `json:"cashBal"`
+	UTime         string `json:"uTime"`
+	IsoEq         string `json:"isoEq"`
+	AvailEq       string `json:"availEq"`
+	DisEq         string `json:"disEq"`
+	FixedBal      string `json:"fixedBal"`
+	AvailBal      string `json:"availBal"`
+	FrozenBal     string `json:"frozenBal"`
+	OrdFrozen     string `json:"ordFrozen"`
+	Liab          string `json:"liab"`
+	Upl           string `json:"upl"`
+	UplLiab       string `json:"uplLiab"`
+	CrossLiab     string `json:"crossLiab"`
+	IsoLiab       string `json:"isoLiab"`
+	MgnRatio      string `json:"mgnRatio"`
+	Interest      string `json:"interest"`
+	Twap          string `json:"twap"`
+	MaxLoan       string `json:"maxLoan"`
+	EqUsd         string `json:"eqUsd"`
+	BorrowFroz    string `json:"borrowFroz"`
+	NotionalLever string `json:"notionalLever"`
+	StgyEq        string `json:"stgyEq"`
+	IsoUpl        string `json:"isoUpl"`
+	SpotInUseAmt  string `json:"spotInUseAmt"`
+	CoinUsdPrice  string `json:"coinUsdPrice"`
+	Imr           string `json:"imr"`
+	Mmr           string `json:"mmr"`
+}
+
+/* ---------- 持仓 ---------- */
+
+type Position struct {
+	InstType       string `json:"instType"`
+	InstId         string `json:"instId"`
+	MgnMode        string `json:"mgnMode"`
+	PosId          string `json:"posId"`
+	PosSide        string `json:"posSide"`
+	Pos            string `json:"pos"`
+	BaseBal        string `json:"baseBal"`
+	QuoteBal       string `json:"quoteBal"`
+	PosCcy         string `json:"posCcy"`
+	AvailPos       string `json:"availPos"`
+	AvgPx          string `json:"avgPx"`
+	MarkPx         string `json:"markPx"`
+	Upl            string `json:"upl"`
+	UplRatio       string `json:"uplRatio"`
+	UplLastPx      string `json:"uplLastPx"`
+	UplRatioLastPx string `json:"uplRatioLastPx"`
+	Lever          string `json:"lever"`
+	LiqPx          string `json:"liqPx"`
+	Imr            string `json:"imr"`
+	Margin         string `json:"margin"`
+	MgnRatio       string `json:"mgnRatio"`
+	Mmr            string `json:"mmr"`
+	Liab           string `json:"liab"`
+	LiabCcy        string `json:"liabCcy"`
+	Interest       string `json:"interest"`
+	TradeId        string `json:"tradeId"`
+	NotionalUsd    string `json:"notionalUsd"`
+	OptVal         string `json:"optVal"`
+	Adl            string `json:"adl"`
+	Ccy            string `json:"ccy"`
+	Last           string `json:"last"`
+	IdxPx          string `json:"idxPx"`
+	UsdPx          string `json:"usdPx"`
+	BePx           string `json:"bePx"`
+	DeltaBS        string `json:"deltaBS"`
+	DeltaPA        string `json:"deltaPA"`
+	GammaBS        string `json:"gammaBS"`
+	GammaPA        string `json:"gammaPA"`
+	ThetaBS        string `json:"thetaBS"`
+	ThetaPA        string `json:"thetaPA"`
+	VegaBS         string `json:"vegaBS"`
+	VegaPA         string `json:"vegaPA"`
+	SpotInUseAmt   string `json:"spotInUseAmt"`
+	SpotInUseCcy   string `json:"spotInUseCcy"`
+	RealizedPnl    string `json:"realizedPnl"`
+	Pnl            string `json:"pnl"`
+	Fee            string `json:"fee"`
+	FundingFee     string `json:"fundingFee"`
+	LiqPenalty     string `json:"liqPenalty"`
+	CTime          string `json:"cTime"`
+	UTime          string `json:"uTime"`
+	PTime          string `json:"pTime"` // 仅 WS 推送
+}
+
+/* ---------- 订单 ---------- */
+
+type Order struct {
+	InstType        string `json:"instType"`
+	InstId          string `json:"instId"`
+	TgtCcy          string `json:"tgtCcy"`
+	Ccy             string `json:"ccy"`
+	OrdId           string `json:"ordId"`
+	ClOrdId         string `json:"clOrdId"`
+	Tag             string `json:"tag"`
+	Px              string `json:"px"`
+	PxUsd           string `json:"pxUsd"`
+	PxVol           string `json:"pxVol"`
+	PxType          string `json:"pxType"`
+	Sz              string `json:"sz"`
+	NotionalUsd     string `json:"notionalUsd"`
+	OrdType         string `json:"ordType"`
+	Side            string `json:"side"`
+	PosSide         string `json:"posSide"`
+	TdMode          string `json:"tdMode"`
+	AccFillSz       string `json:"accFillSz"`
+	FillPx          string `json:"fillPx"`
+	TradeId         string `json:"tradeId"`
+	FillSz          string `json:"fillSz"`
+	FillTime        string `json:"fillTime"`
+	FillFee         string `json:"fillFee"`    // 仅 WS 推送
+	FillFeeCcy      string `json:"fillFeeCcy"` // 仅 WS 推送
+	FillPnl         string `json:"fillPnl"`
+	ExecType        string `json:"execType"`
+	AvgPx           string `json:"avgPx"`
+	State           string `json:"state"`
+	Lever           string `json:"lever"`
+	TpTriggerPx     string `json:"tpTriggerPx"`
+	TpTriggerPxType string `json:"tpTriggerPxType"`
+	TpOrdPx         string `json:"tpOrdPx"`
+	SlTriggerPx     string `json:"slTriggerPx"`
+	SlTriggerPxType string `json:"slTriggerPxType"`
+	SlOrdPx         string `json:"slOrdPx"`
+	FeeCcy          string `json:"feeCcy"`
+	Fee             string `json:"fee"`
+	RebateCcy       string `json:"rebateCcy"`
+	Rebate          string `json:"rebate"`
+	Pnl             string `json:"pnl"`
+	Source          string `json:"source"`
+	Category        string `json:"category"`
+	ReduceOnly      string `json:"reduceOnly"`
+	CancelSource    string `json:"cancelSource"`
+	AmendSource     string `json:"amendSource"`
+	AmendResult     string `json:"amendResult"` // 仅 WS 推送
+	ReqId           string `json:"reqId"`       // 仅 WS 推送
+	Code            string `json:"code"`        // 仅 WS 推送
+	Msg             string `json:"msg"`         // 仅 WS 推送
+	AlgoId          string `json:"algoId"`
+	AlgoClOrdId     string `json:"algoClOrdId"`
+	CTime           string `json:"cTime"`
+	UTime           string `json:"uTime"`
+}
+
+/* ---------- 账户余额和持仓 ---------- */
+
+type BalanceAndPosition struct {
+	PTime     string                    `json:"pTime"`
+	EventType string                    `json:"eventType"`
+	BalData   []BalanceAndPositionBal   `json:"balData"`
+	PosData   []BalanceAndPositionPos   `json:"posData"`
+	Trades    []BalanceAndPositionTrade `json:"trades"`
+}
+
+type BalanceAndPositionBal struct {
+	Ccy     string `json:"ccy"`
+	CashBal string `json:"cashBal"`
+	UTime   string `json:"uTime"`
+}
+
+type BalanceAndPositionPos struct {
+	PosId    string `json:"posId"`
+	TradeId  string `json:"tradeId"`
+	InstId   string `json:"instId"`
+	InstType string `json:"instType"`
+	MgnMode  string `json:"mgnMode"`
+	PosSide  string `json:"posSide"`
+	Pos      string `json:"pos"`
+	Ccy      string `json:"ccy"`
+	PosCcy   string `json:"posCcy"`
+	AvgPx    string `json:"avgPx"`
+	UTime    string `json:"uTime"`
+}
+
+type BalanceAndPositionTrade struct {
+	InstId  string `json:"instId"`
+	TradeId string `json:"tradeId"`
+}
+
+/* ---------- 行情 ---------- */
+
+type Instrument struct {
+	InstType     string `json:"instType"`
+	InstId       string `json:"instId"`
+	Uly          string `json:"uly"`
+	InstFamily   string `json:"instFamily"`
+	BaseCcy      string `json:"baseCcy"`
+	QuoteCcy     string `json:"quoteCcy"`
+	SettleCcy    string `json:"settleCcy"`
+	CtVal        string `json:"ctVal"`
+	CtMult       string `json:"ctMult"`
+	CtValCcy     string `json:"ctValCcy"`
+	OptType      string `json:"optType"`
+	Stk          string `json:"stk"`
+	ListTime     string `json:"listTime"`
+	ExpTime      string `json:"expTime"`
+	Lever        string `json:"lever"`
+	TickSz       string `json:"tickSz"`
+	LotSz        string `json:"lotSz"`
+	MinSz        string `json:"minSz"`
+	CtType       string `json:"ctType"`
+	State        string `json:"state"`
+	MaxLmtSz     string `json:"maxLmtSz"`
+	MaxMktSz     string `json:"maxMktSz"`
+	MaxLmtAmt    string `json:"maxLmtAmt"`
+	MaxMktAmt    string `json:"maxMktAmt"`
+	RuleType     string `json:"ruleType"`
+	MaxIcebergSz string `json:"maxIcebergSz"`
+	MaxTwapSz    string `json:"maxTwapSz"`
+	MaxTriggerSz string `json:"maxTriggerSz"`
+	MaxStopSz    string `json:"maxStopSz"`
+}
+
+type Ticker struct {
+	InstType  string `json:"instType"`
+	InstId    string `json:"instId"`
+	Last      string `json:"last"`
+	LastSz    string `json:"lastSz"`
+	AskPx     string `json:"askPx"`
+	AskSz     string `json:"askSz"`
+	BidPx     string `json:"bidPx"`
+	BidSz     string `json:"bidSz"`
+	Open24h   string `json:"open24h"`
+	High24h   string `json:"high24h"`
+	Low24h    string `json:"low24h"`
+	VolCcy24h string `json:"volCcy24h"`
+	Vol24h    string `json:"vol24h"`
+	SodUtc0   string `json:"sodUtc0"`
+	SodUtc8   string `json:"sodUtc8"`
+	Ts        string `json:"ts"`
+}
+
+// OrderBook 深度，档位格式 [价格, 数量, 已弃用, 订单数]
+type OrderBook struct {
+	Asks      [][]string `json:"asks"`
+	Bids      [][]string `json:"bids"`
+	Ts        string     `json:"ts"`
+	Checksum  int32      `json:"checksum,omitempty"`  // 仅 WS 推送
+	PrevSeqId int64      `json:"prevSeqId,omitempty"` // 仅 WS 推送
+	SeqId     int64      `json:"seqId,omitempty"`     // 仅 WS 推送
+}
+
+// Candle K 线，OKX 以数组形式返回
+type Candle struct {
+	Ts          string
+	Open        string
+	High        string
+	Low         string
+	Close       string
+	Vol         string
+	VolCcy      string
+	VolCcyQuote string
+	Confirm     string // 0: 未完结 1: 已完结
+}
+
+func (c *Candle) UnmarshalJSON(bs []byte) error {
+	var a []string
+	if err := json.Unmarshal(bs, &a); err != nil {
+		return err
+	}
+	fields := []*string{&c.Ts, &c.Open, &c.High, &c.Low, &c.Close, &c.Vol, &c.VolCcy, &c.VolCcyQuote, &c.Confirm}
+	for i := 0; i < len(a) && i < len(fields); i++ {
+		*fields[i] = a[i]
+	}
+	return nil
+}
+
+/* ---------- 交易 ---------- */
+
+type PlaceOrderReq struct {
+	InstId     string `json:"instId"`
+	TdMode     string `json:"tdMode"` // cash / cross / isolated
+	Ccy        string `json:"ccy,omitempty"`
+	ClOrdId    string `json:"clOrdId,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+	Side       string `json:"side"`
+	PosSide    string `json:"posSide,omitempty"`
+	OrdType    string `json:"ordType"` // market / limit / post_only / fok / ioc
+	Sz         string `json:"sz"`
+	Px         string `json:"px,omitempty"`
+	ReduceOnly bool   `json:"reduceOnly,omitempty"`
+	TgtCcy     string `json:"tgtCcy,omitempty"`
+}
+
+type AmendOrderReq struct {
+	InstId    string `json:"instId"`
+	OrdId     string `json:"ordId,omitempty"`
+	ClOrdId   string `json:"clOrdId,omitempty"`
+	CxlOnFail bool   `json:"cxlOnFail,omitempty"`
+	ReqId     string `json:"reqId,omitempty"`
+	NewSz     string `json:"newSz,omitempty"`
+	NewPx     string `json:"newPx,omitempty"`
+}
+
+type CancelOrderReq struct {
+	InstId  string `json:"instId"`
+	OrdId   string `json:"ordId,omitempty"`
+	ClOrdId string `json:"clOrdId,omitempty"`
+}
+
+// OrderResult 下单 / 改单 / 撤单的单笔结果
+type OrderResult struct {
+	OrdId   string `json:"ordId"`
+	ClOrdId string `json:"clOrdId"`
+	Tag     string `json:"tag"`
+	ReqId   string `json:"reqId"`
+	Ts      string `json:"ts"`
+	SCode   string `json:"sCode"`
+	SMsg    string `json:"sMsg"`
+}
+
+// Err 单笔结果失败时返回 *APIError
+func (r OrderResult) Err() error {
+	if r.SCode == "" || r.SCode == "0" {
+		return nil
+	}
+	return &APIError{Code: r.SCode, Msg: r.SMsg, SCode: r.SCode, SMsg: r.SMsg}
+}
+
+type OrderHistoryReq struct {
+	InstType string
+	InstId   string
+	OrdType  string
+	State    string
+	After    string // 分页：早于该 ordId
+	Before   string // 分页：晚于该 ordId
+	Limit    int
+}
+
+/* ---------- 账户 ---------- */
+
+type SetLeverageReq struct {
+	InstId  string `json:"instId,omitempty"`
+	Ccy     string `json:"ccy,omitempty"`
+	Lever   string `json:"lever"`
+	MgnMode string `json:"mgnMode"`
+	PosSide string `json:"posSide,omitempty"`
+}
+
+type Leverage struct {
+	InstId  string `json:"instId"`
+	Ccy     string `json:"ccy"`
+	Lever   string `json:"lever"`
+	MgnMode string `json:"mgnMode"`
+	PosSide string `json:"posSide"`
+}
+
+/* ---------- 公共频道 ---------- */
+
+type Trade struct {
+	InstId  string `json:"instId"`
+	TradeId string `json:"tradeId"`
+	Px      string `json:"px"`
+	Sz      string `json:"sz"`
+	Side    string `json:"side"`
+	Count   string `json:"count"`
+	Ts      string `json:"ts"`
+}
+
+type FundingRate struct {
+	InstType        string `json:"instType"`
+	InstId          string `json:"instId"`
+	Method          string `json:"method"`
+	FormulaType     string `json:"formulaType"`
+	FundingRate     string `json:"fundingRate"`
+	NextFundingRate string `json:"nextFundingRate"`
+	FundingTime     string `json:"fundingTime"`
+	NextFundingTime string `json:"nextFundingTime"`
+	MinFundingRate  string `json:"minFundingRate"`
+	MaxFundingRate  string `json:"maxFundingRate"`
+	SettState       string `json:"settState"`
+	SettFundingRate string `json:"settFundingRate"`
+	Premium         string `json:"premium"`
+	Sprd            string `json:"sprd"`
+	ImpactValue     string `json:"impactValue"`
+	InterestRate    string `json:"interestRate"`
+	Ts              string `json:"ts"`
+}
+
+type MarkPrice struct {
+	InstType string `json:"instType"`
+	InstId   string `json:"instId"`
+	MarkPx   string `json:"markPx"`
+	Ts       string `json:"ts"`
+}
+
+type OpenInterest struct {
+	InstType string `json:"instType"`
+	InstId   string `json:"instId"`
+	Oi       string `json:"oi"`
+	OiCcy    string `json:"oiCcy"`
+	OiUsd    string `json:"oiUsd"`
+	Ts       string `json:"ts"`
+}
+
+type IndexTicker struct {
+	InstId  string `json:"instId"`
+	IdxPx   string `json:"idxPx"`
+	Open24h string `json:"open24h"`
+	High24h string `json:"high24h"`
+	Low24h  string `json:"low24h"`
+	SodUtc0 string `json:"sodUtc0"`
+	SodUtc8 string `json:"sodUtc8"`
+	Ts      string `json:"ts"`
+}
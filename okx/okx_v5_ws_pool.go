@@ -0,0 +1,548 @@
+package okx
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultPoolMaxPerConn = 200              // 单连接订阅频道上限，避免触发 slow consumer 断连
+	rebalanceDelay        = 3 * time.Second  // 重连后等待 WsConn 自身重放完成再做均衡
+	poolStaleTimeout      = 60 * time.Second // 15s 心跳，连续 4 次未收到任何消息（含 pong）即判定失效
+)
+
+var (
+	ErrPoolFull   = errors.New("okx: ws pool subscriptions exceed capacity")
+	ErrShardStale = errors.New("okx: ws pool shard stale")
+)
+
+// OKExV5PoolHealth 单条连接的健康状况
+type OKExV5PoolHealth struct {
+	Shard         int
+	Connected     bool
+	Closed        bool // 已判定失效（超时无消息或重连失败），等待均衡时替换并迁移订阅
+	Subscriptions int
+	Reconnects    int64
+	LastMessage   time.Time
+	LastError     error
+}
+
+type poolShard struct {
+	idx        int
+	pub        *OKExV5WsPublic
+	subs       atomic.Int64
+	connected  atomic.Bool
+	everUp     atomic.Bool
+	dialed     atomic.Bool // WsConn 已建立，可以判断是否失效
+	closed     atomic.Bool
+	reconnects atomic.Int64
+	lastMsg    atomic.Int64
+	errLock    sync.Mutex
+	lastErr    error
+}
+
+func (s *poolShard) setErr(err error) {
+	s.errLock.Lock()
+	s.lastErr = err
+	s.errLock.Unlock()
+}
+
+// connect 按需建连，拨号失败时下次调用会重新尝试
+func (s *poolShard) connect() error {
+	if s.closed.Load() {
+		return ErrWsClosed
+	}
+	s.pub.ConnectWs()
+	if s.pub.WsConn == nil {
+		s.pub.once = new(sync.Once)
+		return errors.New("okx: public ws dial failed")
+	}
+	if !s.dialed.Load() {
+		s.lastMsg.Store(time.Now().UnixNano())
+		s.dialed.Store(true)
+	}
+	return nil
+}
+
+// stale 已建连且有订阅的连接超过 timeout 未收到任何消息
+func (s *poolShard) stale(timeout time.Duration) bool {
+	if s.closed.Load() || !s.dialed.Load() || s.subs.Load() == 0 {
+		return false
+	}
+	return time.Since(time.Unix(0, s.lastMsg.Load())) > timeout
+}
+
+// fail 标记连接失效，连接本身由 Rebalance 关闭并替换
+func (s *poolShard) fail(err error) {
+	if s.closed.Swap(true) {
+		return
+	}
+	zap.S().Errorf("[okx][pool] shard %d closed: %s", s.idx, err)
+	s.connected.Store(false)
+	s.setErr(err)
+}
+
+// OKExV5WsPublicPool 把公共频道订阅分散到多条连接上，
+// 所有连接的推送串行汇入同一个回调，接口与 OKExV5WsPublic 一致
+type OKExV5WsPublicPool struct {
+	cfg        *APIConfig
+	size       int
+	maxPerConn int
+	hand       func([]byte) error
+	connected  func(shard int, err error)
+
+	mu          sync.Mutex // 保护 owner、args 与各连接的订阅计数
+	balanceLock sync.Mutex // 串行化 Rebalance
+	owner       map[string]int
+	args        map[string]WsArg
+	shardLock   sync.RWMutex
+	shards      []*poolShard
+
+	handLock    sync.Mutex
+	rebalancing atomic.Bool
+
+	staleTimeout time.Duration
+	ackTimeout   time.Duration // 每批订阅等待确认的时间
+	watchOnce    sync.Once
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+// NewOKExV5WsPublicPool size 为连接数，maxPerConn 为单连接频道上限（<=0 取默认值）；
+// connected 回调携带连接序号，可为 nil
+func NewOKExV5WsPublicPool(cfg *APIConfig, size, maxPerConn int, hand func([]byte) error, connected func(shard int, err error)) *OKExV5WsPublicPool {
+	if size <= 0 {
+		size = 1
+	}
+	if maxPerConn <= 0 {
+		maxPerConn = DefaultPoolMaxPerConn
+	}
+	p := &OKExV5WsPublicPool{
+		cfg:        cfg,
+		size:       size,
+		maxPerConn: maxPerConn,
+		hand:       hand,
+		connected:  connected,
+		owner:      make(map[string]int),
+		args:       make(map[string]WsArg),
+		shards:     make([]*poolShard, size),
+
+		staleTimeout: poolStaleTimeout,
+		ackTimeout:   subscribeTimeout,
+		done:         make(chan struct{}),
+	}
+	for i := range p.shards {
+		p.shards[i] = p.newShard(i)
+	}
+	return p
+}
+
+func (p *OKExV5WsPublicPool) newShard(idx int) *poolShard {
+	s := &poolShard{idx: idx}
+	s.pub = NewOKExV5WsPublic(p.cfg, func(bs []byte) error {
+		if p.hand == nil {
+			return nil
+		}
+		p.handLock.Lock()
+		defer p.handLock.Unlock()
+		return p.hand(bs)
+	}, func(err error) {
+		if err != nil {
+			s.connected.Store(false)
+			s.setErr(err)
+		} else {
+			s.connected.Store(true)
+			if s.everUp.Swap(true) {
+				s.reconnects.Add(1)
+				time.AfterFunc(rebalanceDelay, p.rebalanceAsync)
+			}
+		}
+		if p.connected != nil {
+			p.connected(idx, err)
+		}
+	})
+	// pong 不会交给 hand，失效判断需要看到全部消息
+	s.pub.ProtoHandleFunc(func(bs []byte) error {
+		s.lastMsg.Store(time.Now().UnixNano())
+		return s.pub.handle(bs)
+	})
+	s.pub.ErrorHandleFunc(func(err error) {
+		s.fail(err)
+		go p.rebalanceAsync()
+	})
+	return s
+}
+
+// watch 定期检查各连接是否失效，有失效连接时触发均衡替换
+func (p *OKExV5WsPublicPool) watch() {
+	t := time.NewTicker(p.staleTimeout / 4)
+	defer t.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-t.C:
+		}
+		closed := false
+		p.shardLock.RLock()
+		for _, s := range p.shards {
+			if s.stale(p.staleTimeout) {
+				s.fail(ErrShardStale)
+			}
+			closed = closed || s.closed.Load()
+		}
+		p.shardLock.RUnlock()
+		if closed {
+			go p.rebalanceAsync()
+		}
+	}
+}
+
+func (p *OKExV5WsPublicPool) shard(idx int) *poolShard {
+	p.shardLock.RLock()
+	defer p.shardLock.RUnlock()
+	return p.shards[idx]
+}
+
+// Subscribe 新频道分配到订阅最少的连接，并等待各连接的服务端确认
+func (p *OKExV5WsPublicPool) Subscribe(args ...WsArg) error {
+	p.watchOnce.Do(func() { go p.watch() })
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	groups := make(map[int][]WsArg)
+	var err error
+	for _, a := range args {
+		k := a.key()
+		if _, ok := p.owner[k]; ok {
+			continue
+		}
+		idx := p.pick(-1)
+		if idx < 0 {
+			err = ErrPoolFull
+			break
+		}
+		p.assign(k, a, idx)
+		groups[idx] = append(groups[idx], a)
+	}
+
+	for idx, as := range groups {
+		if e := p.subscribeOn(idx, as); e != nil {
+			zap.S().Errorf("[okx][pool] shard %d subscribe err: %s", idx, e)
+			if err == nil {
+				err = e
+			}
+		}
+	}
+	return err
+}
+
+// Unsubscribe 从所属连接退订并移出重放集合
+func (p *OKExV5WsPublicPool) Unsubscribe(args ...WsArg) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	groups := make(map[int][]WsArg)
+	for _, a := range args {
+		k := a.key()
+		idx, ok := p.owner[k]
+		if !ok {
+			continue
+		}
+		p.release(k, idx)
+		groups[idx] = append(groups[idx], a)
+	}
+
+	var err error
+	for idx, as := range groups {
+		s := p.shard(idx)
+		if s.closed.Load() || s.pub.WsConn == nil {
+			continue
+		}
+		if e := s.pub.WsConn.UnsubscribeWait(p.ackTimeout, as...); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// Subscriptions 全部连接上的订阅
+func (p *OKExV5WsPublicPool) Subscriptions() []WsArg {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	args := make([]WsArg, 0, len(p.args))
+	for _, a := range p.args {
+		args = append(args, a)
+	}
+	return args
+}
+
+// Health 各连接的状态快照
+func (p *OKExV5WsPublicPool) Health() []OKExV5PoolHealth {
+	p.shardLock.RLock()
+	defer p.shardLock.RUnlock()
+	ret := make([]OKExV5PoolHealth, len(p.shards))
+	for i, s := range p.shards {
+		h := OKExV5PoolHealth{
+			Shard:         i,
+			Connected:     s.connected.Load(),
+			Closed:        s.closed.Load(),
+			Subscriptions: int(s.subs.Load()),
+			Reconnects:    s.reconnects.Load(),
+		}
+		if ts := s.lastMsg.Load(); ts > 0 {
+			h.LastMessage = time.Unix(0, ts)
+		}
+		s.errLock.Lock()
+		h.LastError = s.lastErr
+		s.errLock.Unlock()
+		ret[i] = h
+	}
+	return ret
+}
+
+// migration 一组从 from 迁到 to 的频道，from 为 nil 表示原连接已失效
+type migration struct {
+	from, to       *poolShard
+	fromIdx, toIdx int
+	args           []WsArg
+}
+
+// Rebalance 替换已关闭的连接，并把订阅从负载高的连接迁移到负载低的连接；
+// 迁移方案在 mu 内计算，订阅与退订在锁外进行
+func (p *OKExV5WsPublicPool) Rebalance() {
+	p.balanceLock.Lock()
+	defer p.balanceLock.Unlock()
+
+	p.mu.Lock()
+	migrations := append(p.replaceClosed(), p.planMoves()...)
+	p.mu.Unlock()
+
+	for _, m := range migrations {
+		p.migrate(m)
+	}
+}
+
+// replaceClosed 调用方持有 mu；已关闭的连接换新连接，原订阅全部分配到其他连接
+func (p *OKExV5WsPublicPool) replaceClosed() []migration {
+	var orphans []WsArg
+	for i := 0; i < p.size; i++ {
+		old := p.shard(i)
+		if !old.closed.Load() {
+			continue
+		}
+		if old.dialed.Load() {
+			go old.pub.WsConn.CloseWs()
+		}
+		for _, k := range p.keysOn(i) {
+			orphans = append(orphans, p.args[k])
+			p.release(k, i)
+		}
+		p.shardLock.Lock()
+		p.shards[i] = p.newShard(i)
+		p.shardLock.Unlock()
+	}
+
+	groups := make(map[int][]WsArg)
+	for _, a := range orphans {
+		idx := p.pick(-1)
+		if idx < 0 {
+			zap.S().Errorf("[okx][pool] drop %s %s: %s", a.Channel, a.InstId, ErrPoolFull)
+			continue
+		}
+		p.assign(a.key(), a, idx)
+		groups[idx] = append(groups[idx], a)
+	}
+	migrations := make([]migration, 0, len(groups))
+	for idx, as := range groups {
+		migrations = append(migrations, migration{fromIdx: -1, to: p.shard(idx), toIdx: idx, args: as})
+	}
+	return migrations
+}
+
+// planMoves 调用方持有 mu；超过平均值的连接把多出的频道分配到最空闲的连接
+func (p *OKExV5WsPublicPool) planMoves() []migration {
+	target := (len(p.owner) + p.size - 1) / p.size
+	type move struct{ from, to int }
+	moves := make(map[move][]WsArg)
+	for _, k := range p.keysOn(-1) {
+		from := p.owner[k]
+		if int(p.shard(from).subs.Load()) <= target {
+			continue
+		}
+		to := p.pick(from)
+		if to < 0 || int(p.shard(to).subs.Load()) >= target {
+			continue
+		}
+		a := p.args[k]
+		p.release(k, from)
+		p.assign(k, a, to)
+		moves[move{from, to}] = append(moves[move{from, to}], a)
+	}
+	migrations := make([]migration, 0, len(moves))
+	for m, as := range moves {
+		migrations = append(migrations, migration{
+			from: p.shard(m.from), to: p.shard(m.to),
+			fromIdx: m.from, toIdx: m.to, args: as,
+		})
+	}
+	return migrations
+}
+
+// keysOn 调用方持有 mu；idx 连接上的频道，idx < 0 返回全部
+func (p *OKExV5WsPublicPool) keysOn(idx int) []string {
+	keys := make([]string, 0, len(p.owner))
+	for k, i := range p.owner {
+		if idx < 0 || i == idx {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// migrate 在新连接上订阅，确认成功的频道才从原连接退订；
+// 失败的频道退回原连接，原连接已失效时未建连或被拒绝的频道从池中移除
+func (p *OKExV5WsPublicPool) migrate(m migration) {
+	dropped, unconfirmed, err := p.send(m.to, m.args)
+	if err != nil {
+		zap.S().Errorf("[okx][pool] shard %d -> %d migrate err: %s", m.fromIdx, m.toIdx, err)
+	}
+	failed := make(map[string]bool, len(dropped)+len(unconfirmed))
+	for _, a := range dropped {
+		failed[a.key()] = true
+	}
+	if m.from != nil { // 原连接仍可用时，未确认的频道也退回
+		for _, a := range unconfirmed {
+			failed[a.key()] = true
+		}
+	}
+
+	var moved, undoTo, undoFrom []WsArg
+	p.mu.Lock()
+	fromAlive := m.from != nil && p.shard(m.fromIdx) == m.from && !m.from.closed.Load()
+	for _, a := range m.args {
+		k := a.key()
+		owner, ok := p.owner[k]
+		switch {
+		case !ok || owner != m.toIdx: // 迁移期间已被退订或重新分配
+			undoTo = append(undoTo, a)
+			if m.from != nil && (!ok || owner != m.fromIdx) {
+				undoFrom = append(undoFrom, a)
+			}
+		case !failed[k]:
+			moved = append(moved, a)
+		case fromAlive:
+			p.release(k, m.toIdx)
+			p.assign(k, a, m.fromIdx)
+			undoTo = append(undoTo, a)
+		default:
+			p.release(k, m.toIdx)
+			zap.S().Errorf("[okx][pool] drop %s %s: migrate failed", a.Channel, a.InstId)
+		}
+	}
+	p.mu.Unlock()
+
+	unsubscribe(m.to, undoTo)
+	if m.from != nil {
+		unsubscribe(m.from, append(moved, undoFrom...))
+	}
+	zap.S().Infof("[okx][pool] moved %d channels shard %d -> %d", len(moved), m.fromIdx, m.toIdx)
+}
+
+// unsubscribe 不等待确认地退订并移出该连接的重放集合
+func unsubscribe(s *poolShard, args []WsArg) {
+	if len(args) == 0 || s.closed.Load() || s.pub.WsConn == nil {
+		return
+	}
+	_ = s.pub.WsConn.Unsubscribe(args...)
+}
+
+func (p *OKExV5WsPublicPool) rebalanceAsync() {
+	select {
+	case <-p.done:
+		return
+	default:
+	}
+	if !p.rebalancing.CompareAndSwap(false, true) {
+		return
+	}
+	defer p.rebalancing.Store(false)
+	p.Rebalance()
+}
+
+// Close 关闭全部连接
+func (p *OKExV5WsPublicPool) Close() {
+	p.closeOnce.Do(func() { close(p.done) })
+	p.shardLock.RLock()
+	defer p.shardLock.RUnlock()
+	for _, s := range p.shards {
+		s.closed.Store(true)
+		if s.pub.WsConn != nil {
+			s.pub.WsConn.CloseWs()
+		}
+	}
+}
+
+// pick 选出订阅最少且未满的可用连接，except 为需要排除的连接
+func (p *OKExV5WsPublicPool) pick(except int) int {
+	best, least := -1, p.maxPerConn
+	for i := 0; i < p.size; i++ {
+		s := p.shard(i)
+		if i == except || s.closed.Load() {
+			continue
+		}
+		if n := int(s.subs.Load()); n < least {
+			best, least = i, n
+		}
+	}
+	return best
+}
+
+func (p *OKExV5WsPublicPool) assign(k string, a WsArg, idx int) {
+	p.owner[k] = idx
+	p.args[k] = a
+	p.shard(idx).subs.Add(1)
+}
+
+func (p *OKExV5WsPublicPool) release(k string, idx int) {
+	delete(p.owner, k)
+	delete(p.args, k)
+	p.shard(idx).subs.Add(-1)
+}
+
+// subscribeOn 调用方持有 mu；在指定连接上按批订阅，未能建连或服务端拒绝的频道从池中移除
+func (p *OKExV5WsPublicPool) subscribeOn(idx int, args []WsArg) error {
+	dropped, _, err := p.send(p.shard(idx), args)
+	for _, a := range dropped {
+		p.release(a.key(), idx)
+	}
+	return err
+}
+
+// send 在连接上按批订阅并等待确认，不修改池状态；
+// dropped 为未能建连或被服务端拒绝的频道，unconfirmed 为超时等未确认但仍在该连接重放集合中的频道
+func (p *OKExV5WsPublicPool) send(s *poolShard, args []WsArg) (dropped, unconfirmed []WsArg, err error) {
+	if err = s.connect(); err != nil {
+		return args, nil, err
+	}
+	for i := 0; i < len(args); i += replayBatchSize {
+		batch := args[i:min(i+replayBatchSize, len(args))]
+		e := s.pub.WsConn.SubscribeWait(map[string]any{"op": "subscribe", "args": batch}, p.ackTimeout)
+		if e == nil {
+			continue
+		}
+		if _, ok := e.(*APIError); ok {
+			dropped = append(dropped, batch...)
+		} else {
+			unconfirmed = append(unconfirmed, batch...)
+		}
+		if err == nil {
+			err = e
+		}
+	}
+	return
+}
@@ -0,0 +1,328 @@
+package okx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeOKX 模拟 OKX 公共频道：回 pong、确认订阅、定时推送，frozen 的连接不再回任何消息，
+// muted 的连接照常记录订阅但不确认；instId 为 rejectInstId 的订阅返回 error
+type fakeOKX struct {
+	*httptest.Server
+	mu     sync.Mutex
+	conns  []*fakeConn
+	frozen map[int]bool
+	muted  map[int]bool
+	subs   []fakeSub // 按到达顺序记录的订阅
+}
+
+const rejectInstId = "BAD-USDT"
+
+type fakeConn struct {
+	id int
+	mu sync.Mutex
+	c  *websocket.Conn
+}
+
+type fakeSub struct {
+	conn int
+	op   string
+	arg  WsArg
+}
+
+func newFakeOKX(t *testing.T) *fakeOKX {
+	f := &fakeOKX{frozen: make(map[int]bool), muted: make(map[int]bool)}
+	upgrader := websocket.Upgrader{}
+	done := make(chan struct{})
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		f.mu.Lock()
+		conn := &fakeConn{id: len(f.conns), c: c}
+		f.conns = append(f.conns, conn)
+		f.mu.Unlock()
+
+		for {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			if f.isFrozen(conn.id) {
+				continue
+			}
+			if string(data) == "ping" {
+				conn.write([]byte("pong"))
+				continue
+			}
+			var req subRequest
+			if json.Unmarshal(data, &req) != nil {
+				continue
+			}
+			f.mu.Lock()
+			for _, a := range req.Args {
+				f.subs = append(f.subs, fakeSub{conn.id, req.Op, a})
+			}
+			muted := f.muted[conn.id]
+			f.mu.Unlock()
+			if muted {
+				continue
+			}
+			for _, a := range req.Args {
+				ack := map[string]any{"event": req.Op, "arg": a, "id": req.Id}
+				if a.InstId == rejectInstId {
+					ack = map[string]any{"event": "error", "code": "60018", "msg": "doesn't exist", "id": req.Id}
+				}
+				bs, _ := json.Marshal(ack)
+				conn.write(bs)
+			}
+		}
+	}))
+	go func() {
+		t := time.NewTicker(20 * time.Millisecond)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+			}
+			f.mu.Lock()
+			conns := append([]*fakeConn(nil), f.conns...)
+			f.mu.Unlock()
+			for _, c := range conns {
+				if !f.isFrozen(c.id) {
+					c.write([]byte(`{"arg":{"channel":"tickers","instId":"BTC-USDT"},"data":[]}`))
+				}
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		close(done)
+		f.Close()
+	})
+	return f
+}
+
+func (c *fakeConn) write(bs []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_ = c.c.WriteMessage(websocket.TextMessage, bs)
+}
+
+func (f *fakeOKX) url() string { return "ws" + strings.TrimPrefix(f.URL, "http") }
+
+func (f *fakeOKX) isFrozen(id int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.frozen[id]
+}
+
+func (f *fakeOKX) freeze(id int) {
+	f.mu.Lock()
+	f.frozen[id] = true
+	f.mu.Unlock()
+}
+
+func (f *fakeOKX) mute(id int) {
+	f.mu.Lock()
+	f.muted[id] = true
+	f.mu.Unlock()
+}
+
+// kick 服务端断开连接
+func (f *fakeOKX) kick(id int) {
+	f.mu.Lock()
+	c := f.conns[id]
+	f.mu.Unlock()
+	c.c.Close()
+}
+
+// connOf 最近一次订阅 instId 的连接
+func (f *fakeOKX) connOf(instId string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := -1
+	for _, s := range f.subs {
+		if s.arg.InstId == instId && s.op == "subscribe" {
+			id = s.conn
+		}
+	}
+	return id
+}
+
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestOKExV5WsPublicPool_staleShardMigrates(t *testing.T) {
+	server := newFakeOKX(t)
+	pool := NewOKExV5WsPublicPool(&APIConfig{Endpoint: server.url()}, 2, 0, nil, nil)
+	pool.staleTimeout = 300 * time.Millisecond
+	defer pool.Close()
+
+	args := []WsArg{
+		{Channel: ChannelTickers, InstId: "BTC-USDT"},
+		{Channel: ChannelTickers, InstId: "ETH-USDT"},
+		{Channel: ChannelTickers, InstId: "SOL-USDT"},
+		{Channel: ChannelTickers, InstId: "XRP-USDT"},
+	}
+	if err := pool.Subscribe(args...); err != nil {
+		t.Fatal(err)
+	}
+
+	frozen := server.connOf("BTC-USDT")
+	var moved []string
+	for _, a := range args {
+		if server.connOf(a.InstId) == frozen {
+			moved = append(moved, a.InstId)
+		}
+	}
+	if len(moved) != 2 {
+		t.Fatalf("channels on shard = %v, want 2", moved)
+	}
+	server.freeze(frozen)
+
+	ok := waitFor(t, 5*time.Second, func() bool {
+		for _, instId := range moved {
+			if id := server.connOf(instId); id == frozen || server.isFrozen(id) {
+				return false
+			}
+		}
+		return true
+	})
+	if !ok {
+		t.Fatalf("subscriptions %v not moved off frozen connection %d", moved, frozen)
+	}
+
+	if got := pool.Subscriptions(); len(got) != len(args) {
+		t.Errorf("Subscriptions() = %v, want %d channels", got, len(args))
+	}
+	total := 0
+	for _, h := range pool.Health() {
+		if h.Closed {
+			t.Errorf("shard %d still closed after rebalance", h.Shard)
+		}
+		total += h.Subscriptions
+	}
+	if total != len(args) {
+		t.Errorf("subscriptions across shards = %d, want %d", total, len(args))
+	}
+}
+
+// opsOn 连接 id 上 instId 收到的 subscribe/unsubscribe 序列
+func (f *fakeOKX) opsOn(id int, instId string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var ops []string
+	for _, s := range f.subs {
+		if s.conn == id && s.arg.InstId == instId {
+			ops = append(ops, s.op)
+		}
+	}
+	return ops
+}
+
+func TestOKExV5WsPublicPool_Rebalance(t *testing.T) {
+	tests := []struct {
+		name       string
+		muteTarget bool // 目标连接不确认订阅
+		wantLoads  []int
+		wantFrom   []string // 原连接上被迁移频道的 op 序列
+		wantTo     []string // 目标连接上被迁移频道的 op 序列
+	}{
+		{
+			name:      "ack",
+			wantLoads: []int{1, 1},
+			wantFrom:  []string{"subscribe", "unsubscribe"},
+			wantTo:    []string{"subscribe"},
+		},
+		{
+			name:       "timeout rolls back",
+			muteTarget: true,
+			wantLoads:  []int{2, 0},
+			wantFrom:   []string{"subscribe"},
+			wantTo:     []string{"subscribe", "unsubscribe"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeOKX(t)
+			pool := NewOKExV5WsPublicPool(&APIConfig{Endpoint: server.url()}, 2, 0, nil, nil)
+			pool.ackTimeout = 200 * time.Millisecond
+			defer pool.Close()
+
+			args := []WsArg{
+				{Channel: ChannelTickers, InstId: "BTC-USDT"},
+				{Channel: ChannelTickers, InstId: "ETH-USDT"},
+				{Channel: ChannelTickers, InstId: "SOL-USDT"},
+				{Channel: ChannelTickers, InstId: "XRP-USDT"},
+			}
+			if err := pool.Subscribe(args...); err != nil {
+				t.Fatal(err)
+			}
+			// 只留下 shard 0 上的频道，制造负载不均
+			var keep, drop []WsArg
+			for _, a := range args {
+				if pool.owner[a.key()] == 0 {
+					keep = append(keep, a)
+				} else {
+					drop = append(drop, a)
+				}
+			}
+			if err := pool.Unsubscribe(drop...); err != nil {
+				t.Fatal(err)
+			}
+			from, to := server.connOf(keep[0].InstId), server.connOf(drop[0].InstId)
+			if tt.muteTarget {
+				server.mute(to)
+			}
+
+			pool.Rebalance()
+
+			var loads []int
+			for _, h := range pool.Health() {
+				loads = append(loads, h.Subscriptions)
+			}
+			if !reflect.DeepEqual(loads, tt.wantLoads) {
+				t.Errorf("loads = %v, want %v", loads, tt.wantLoads)
+			}
+			if got := pool.Subscriptions(); len(got) != len(keep) {
+				t.Errorf("Subscriptions() = %v, want %d channels", got, len(keep))
+			}
+			var moved string
+			for _, a := range keep {
+				if len(server.opsOn(to, a.InstId)) > 0 {
+					moved = a.InstId
+				}
+			}
+			if moved == "" {
+				t.Fatal("no channel migrated to the target connection")
+			}
+			if !waitFor(t, time.Second, func() bool { return reflect.DeepEqual(server.opsOn(to, moved), tt.wantTo) }) {
+				t.Errorf("target ops = %v, want %v", server.opsOn(to, moved), tt.wantTo)
+			}
+			if !waitFor(t, time.Second, func() bool { return reflect.DeepEqual(server.opsOn(from, moved), tt.wantFrom) }) {
+				t.Errorf("source ops = %v, want %v", server.opsOn(from, moved), tt.wantFrom)
+			}
+		})
+	}
+}
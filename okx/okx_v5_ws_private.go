@@ -0,0 +1,272 @@
+package okx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	OKExV5WsPrivateEndpoint     = "wss://ws.okx.com:8443/ws/v5/private"
+	OKExV5WsPrivateDemoEndpoint = "wss://wspap.okx.com:8443/ws/v5/private?brokerId=9999" // 模拟盘
+
+	loginTimeout = 10 * time.Second
+)
+
+var ErrLoginTimeout = errors.New("okx: private ws login timeout")
+
+// 私有频道
+const (
+	ChannelAccount            = "account"
+	ChannelPositions          = "positions"
+	ChannelOrders             = "orders"
+	ChannelBalanceAndPosition = "balance_and_position"
+)
+
+// OKExV5PrivateHandlers 私有频道回调，未设置回调的消息交给 Raw
+type OKExV5PrivateHandlers struct {
+	Account            func(arg WsArg, data []AccountBalance)
+	Positions          func(arg WsArg, data []Position)
+	Orders             func(arg WsArg, data []Order)
+	BalanceAndPosition func(arg WsArg, data []BalanceAndPosition)
+	Raw                func([]byte) error
+}
+
+type OKExV5WsPrivate struct {
+	cfg *APIConfig
+	*WsBuilder
+	once    *sync.Once
+	WsConn  *WsConn
+	hand    OKExV5PrivateHandlers
+	logged  atomic.Bool
+	loginCh chan error
+	connErr error
+
+	orderTimeout atomic.Int64 // WS 交易请求超时（time.Duration），可与 Request 并发读写
+}
+
+func NewOKExV5WsPrivate(cfg *APIConfig, hand OKExV5PrivateHandlers, connected func(err error)) *OKExV5WsPrivate {
+	if cfg == nil {
+		cfg = &APIConfig{Endpoint: OKExV5WsPrivateEndpoint}
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = OKExV5WsPrivateEndpoint
+	}
+	if cfg.HttpClient == nil {
+		cfg.HttpClient = http.DefaultClient
+	}
+
+	pri := &OKExV5WsPrivate{
+		cfg:     cfg,
+		once:    new(sync.Once),
+		hand:    hand,
+		loginCh: make(chan error, 1),
+	}
+
+	pri.WsBuilder = NewWsBuilder().
+		WsUrl(cfg.Endpoint).
+		ReconnectInterval(time.Second).
+		AutoReconnect().
+		Heartbeat(func() []byte { return []byte("ping") }, 15*time.Second).
+		ConnectedHandleFunc(func(err error) {
+			if err == nil {
+				pri.logged.Store(false) // 新连接需要重新登录
+				pri.drainLogin()
+			}
+			if connected != nil {
+				connected(err)
+			}
+		}).
+		ConnectSuccessAfterSendMessage(pri.loginMessage). // 首次连接和每次重连都会重新签名登录
+		ReplayReadyFunc(pri.waitLogin).                   // 重连登录成功后才重放私有频道，登录失败时退避重登，仍失败则重新拨号
+		DecompressFunc(FlateDecompress).
+		ProtoHandleFunc(pri.handle)
+
+	return pri
+}
+
+// ConnectWs 建连并等待首次登录结果，重连后的登录由 WsConn 自动完成
+func (p *OKExV5WsPrivate) ConnectWs() error {
+	p.once.Do(func() {
+		p.WsConn = p.WsBuilder.Build()
+		if p.WsConn == nil {
+			p.connErr = errors.New("okx: private ws dial failed")
+			return
+		}
+		p.connErr = p.waitLogin()
+	})
+	return p.connErr
+}
+
+// waitLogin 等待本次连接的登录结果
+func (p *OKExV5WsPrivate) waitLogin() error {
+	select {
+	case err := <-p.loginCh:
+		return err
+	case <-time.After(loginTimeout):
+		return ErrLoginTimeout
+	}
+}
+
+// drainLogin 丢弃上一个连接遗留的登录结果
+func (p *OKExV5WsPrivate) drainLogin() {
+	select {
+	case <-p.loginCh:
+	default:
+	}
+}
+
+// IsLogin 当前连接是否已登录
+func (p *OKExV5WsPrivate) IsLogin() bool { return p.logged.Load() }
+
+func (p *OKExV5WsPrivate) loginMessage() []byte {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	bs, _ := json.Marshal(map[string]any{
+		"op": "login",
+		"args": []map[string]string{{
+			"apiKey":     p.cfg.ApiKey,
+			"passphrase": p.cfg.ApiPassphrase,
+			"timestamp":  ts,
+			"sign":       sign(p.cfg.ApiSecretKey, ts+http.MethodGet+"/users/self/verify"),
+		}},
+	})
+	return bs
+}
+
+func (p *OKExV5WsPrivate) notifyLogin(err error) {
+	select {
+	case p.loginCh <- err:
+	default:
+	}
+}
+
+func (p *OKExV5WsPrivate) handle(bs []byte) error {
+	if string(bs) == "pong" { // 心跳
+		return nil
+	}
+
+	var msg WsMessage
+	if err := json.Unmarshal(bs, &msg); err != nil {
+		zap.S().Warnf("[okx][private] decode err: %s, raw: %s", err, string(bs))
+		return err
+	}
+
+	switch msg.Event {
+	case "login":
+		p.logged.Store(true)
+		p.notifyLogin(nil)
+		return nil
+	case "error":
+		err := &APIError{Code: msg.Code, Msg: msg.Msg}
+		if !p.logged.Load() { // 登录前收到的错误即为登录失败
+			p.notifyLogin(err)
+		}
+		zap.S().Errorf("[okx][private] %s", err)
+		return p.raw(bs)
+	case "":
+	default: // subscribe / unsubscribe / channel-conn-count
+		return p.raw(bs)
+	}
+
+	if msg.Arg == nil || len(msg.Data) == 0 {
+		return p.raw(bs)
+	}
+
+	arg := *msg.Arg
+	switch arg.Channel {
+	case ChannelAccount:
+		if p.hand.Account != nil {
+			var data []AccountBalance
+			if err := json.Unmarshal(msg.Data, &data); err != nil {
+				return err
+			}
+			p.hand.Account(arg, data)
+			return nil
+		}
+	case ChannelPositions:
+		if p.hand.Positions != nil {
+			var data []Position
+			if err := json.Unmarshal(msg.Data, &data); err != nil {
+				return err
+			}
+			p.hand.Positions(arg, data)
+			return nil
+		}
+	case ChannelOrders:
+		if p.hand.Orders != nil {
+			var data []Order
+			if err := json.Unmarshal(msg.Data, &data); err != nil {
+				return err
+			}
+			p.hand.Orders(arg, data)
+			return nil
+		}
+	case ChannelBalanceAndPosition:
+		if p.hand.BalanceAndPosition != nil {
+			var data []BalanceAndPosition
+			if err := json.Unmarshal(msg.Data, &data); err != nil {
+				return err
+			}
+			p.hand.BalanceAndPosition(arg, data)
+			return nil
+		}
+	}
+	return p.raw(bs)
+}
+
+func (p *OKExV5WsPrivate) raw(bs []byte) error {
+	if p.hand.Raw != nil {
+		return p.hand.Raw(bs)
+	}
+	return nil
+}
+
+// Subscribe 等待服务端确认，成功的订阅由 WsConn 记录并在重连登录后自动重放
+func (p *OKExV5WsPrivate) Subscribe(args ...WsArg) error {
+	if err := p.ConnectWs(); err != nil {
+		return err
+	}
+	return p.WsConn.SubscribeWait(map[string]any{"op": "subscribe", "args": args}, subscribeTimeout)
+}
+
+func (p *OKExV5WsPrivate) Unsubscribe(args ...WsArg) error {
+	if err := p.ConnectWs(); err != nil {
+		return err
+	}
+	return p.WsConn.UnsubscribeWait(subscribeTimeout, args...)
+}
+
+// SubscribeAccount ccy 为空表示全部币种
+func (p *OKExV5WsPrivate) SubscribeAccount(ccy string) error {
+	return p.Subscribe(WsArg{Channel: ChannelAccount, Ccy: ccy})
+}
+
+// SubscribePositions instType: MARGIN/SWAP/FUTURES/OPTION/ANY
+func (p *OKExV5WsPrivate) SubscribePositions(instType, instFamily, instId string) error {
+	return p.Subscribe(WsArg{Channel: ChannelPositions, InstType: instType, InstFamily: instFamily, InstId: instId})
+}
+
+// SubscribeOrders instType: SPOT/MARGIN/SWAP/FUTURES/OPTION/ANY
+func (p *OKExV5WsPrivate) SubscribeOrders(instType, instFamily, instId string) error {
+	return p.Subscribe(WsArg{Channel: ChannelOrders, InstType: instType, InstFamily: instFamily, InstId: instId})
+}
+
+func (p *OKExV5WsPrivate) SubscribeBalanceAndPosition() error {
+	return p.Subscribe(WsArg{Channel: ChannelBalanceAndPosition})
+}
+
+// sign OKX v5 签名：Base64(HmacSHA256(prehash, secret))
+func sign(secret, prehash string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(prehash))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,177 @@
+package okx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeOKXPrivate 模拟 OKX 私有频道：fail(conn, n) 为真时第 n 次登录（从 1 起）返回 error，
+// 登录成功后确认订阅并按连接记录；其余 op 交给 trade，由其调用 reply 应答（可延迟或不应答）
+type fakeOKXPrivate struct {
+	*httptest.Server
+	mu     sync.Mutex
+	wmu    sync.Mutex // 串行化各连接的写，trade 可在其他协程中 reply
+	conns  []*websocket.Conn
+	logins map[int]int
+	subs   map[int][]string // 连接 -> 订阅的 channel
+	fail   func(conn, n int) bool
+	trade  func(req subRequest, reply func(resp map[string]any))
+}
+
+func newFakeOKXPrivate(t *testing.T, fail func(conn, n int) bool) *fakeOKXPrivate {
+	f := &fakeOKXPrivate{logins: make(map[int]int), subs: make(map[int][]string), fail: fail}
+	upgrader := websocket.Upgrader{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		f.mu.Lock()
+		id := len(f.conns)
+		f.conns = append(f.conns, c)
+		f.mu.Unlock()
+
+		logged := false
+		for {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(data) == "ping" {
+				f.write(c, []byte("pong"))
+				continue
+			}
+			var req subRequest
+			if json.Unmarshal(data, &req) != nil {
+				continue
+			}
+			var ack map[string]any
+			f.mu.Lock()
+			switch req.Op {
+			case "login":
+				f.logins[id]++
+				if f.fail(id, f.logins[id]) {
+					ack = map[string]any{"event": "error", "code": "60009", "msg": "Login failed."}
+				} else {
+					logged = true
+					ack = map[string]any{"event": "login", "code": "0"}
+				}
+			case "subscribe":
+				if !logged {
+					ack = map[string]any{"event": "error", "code": "60011", "msg": "Please log in", "id": req.Id}
+					break
+				}
+				for _, a := range req.Args {
+					f.subs[id] = append(f.subs[id], a.Channel)
+				}
+				ack = map[string]any{"event": "subscribe", "arg": req.Args[0], "id": req.Id}
+			default:
+				if f.trade != nil {
+					f.trade(req, func(resp map[string]any) {
+						resp["id"], resp["op"] = req.Id, req.Op
+						bs, _ := json.Marshal(resp)
+						f.write(c, bs)
+					})
+				}
+			}
+			f.mu.Unlock()
+			if ack != nil {
+				bs, _ := json.Marshal(ack)
+				f.write(c, bs)
+			}
+		}
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeOKXPrivate) onTrade(trade func(req subRequest, reply func(resp map[string]any))) {
+	f.mu.Lock()
+	f.trade = trade
+	f.mu.Unlock()
+}
+
+func (f *fakeOKXPrivate) write(c *websocket.Conn, bs []byte) {
+	f.wmu.Lock()
+	defer f.wmu.Unlock()
+	_ = c.WriteMessage(websocket.TextMessage, bs)
+}
+
+func (f *fakeOKXPrivate) kick(id int) {
+	f.mu.Lock()
+	c := f.conns[id]
+	f.mu.Unlock()
+	c.Close()
+}
+
+func (f *fakeOKXPrivate) client() *OKExV5WsPrivate {
+	return NewOKExV5WsPrivate(&APIConfig{
+		Endpoint:      "ws" + strings.TrimPrefix(f.URL, "http"),
+		ApiKey:        "key",
+		ApiSecretKey:  "secret",
+		ApiPassphrase: "pass",
+	}, OKExV5PrivateHandlers{}, nil)
+}
+
+func (f *fakeOKXPrivate) state(id int) (logins int, subs []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.logins[id], append([]string(nil), f.subs[id]...)
+}
+
+func TestOKExV5WsPrivate_reloginAfterReconnect(t *testing.T) {
+	tests := []struct {
+		name       string
+		fail       func(conn, n int) bool
+		wantConn   int // 重放订阅所在连接
+		wantLogins int // 该连接上的登录次数
+	}{
+		{
+			name:       "relogin with backoff",
+			fail:       func(conn, n int) bool { return conn == 1 && n <= 2 },
+			wantConn:   1,
+			wantLogins: 3,
+		},
+		{
+			name:       "redial after retries exhausted",
+			fail:       func(conn, n int) bool { return conn == 1 },
+			wantConn:   2,
+			wantLogins: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeOKXPrivate(t, tt.fail)
+			pri := server.client()
+			pri.WsBuilder.ReconnectInterval(10 * time.Millisecond)
+			if err := pri.SubscribeOrders("SPOT", "", ""); err != nil {
+				t.Fatal(err)
+			}
+			defer pri.WsConn.CloseWs()
+
+			server.kick(0)
+			ok := waitFor(t, 5*time.Second, func() bool {
+				_, subs := server.state(tt.wantConn)
+				return len(subs) == 1 && subs[0] == ChannelOrders
+			})
+			logins, subs := server.state(tt.wantConn)
+			if !ok {
+				t.Fatalf("conn %d subscriptions = %v, want [%s]", tt.wantConn, subs, ChannelOrders)
+			}
+			if logins != tt.wantLogins {
+				t.Errorf("conn %d logins = %d, want %d", tt.wantConn, logins, tt.wantLogins)
+			}
+			if !pri.IsLogin() {
+				t.Error("IsLogin() = false after relogin")
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ type APIConfig struct {
 	ApiSecretKey  string
 	ApiPassphrase string //for okex.com v3 api
 	ClientId      string //for bitstamp.net , huobi.pro
+	Simulated     bool   //模拟盘，REST 请求附加 x-simulated-trading: 1
 
 	Lever float64 //杠杆倍数 , for future
 }
@@ -25,6 +26,7 @@ type OKExV5WsPublic struct {
 	once   *sync.Once
 	WsConn *WsConn
 	hand   func([]byte) error
+	router *OKExV5Router // 非空时 Subscribe 等待服务端确认
 }
 
 func NewOKExV5WsPublic(cfg *APIConfig, hand func([]byte) error, connected func(err error)) *OKExV5WsPublic {
@@ -70,5 +72,21 @@ func (p *OKExV5WsPublic) handle(bs []byte) error {
 
 func (p *OKExV5WsPublic) Subscribe(req map[string]any) error {
 	p.ConnectWs()
-	return p.WsConn.Subscribe(req)
+	if p.router == nil {
+		return p.WsConn.Subscribe(req)
+	}
+	return p.WsConn.SubscribeWait(req, subscribeTimeout)
+}
+
+func (p *OKExV5WsPublic) Unsubscribe(args ...WsArg) error {
+	p.ConnectWs()
+	if p.router == nil {
+		return p.WsConn.Unsubscribe(args...)
+	}
+	return p.WsConn.UnsubscribeWait(subscribeTimeout, args...)
+}
+
+// SubscribeArgs 以 WsArg 形式订阅一个或多个频道
+func (p *OKExV5WsPublic) SubscribeArgs(args ...WsArg) error {
+	return p.Subscribe(map[string]any{"op": "subscribe", "args": args})
 }
@@ -0,0 +1,84 @@
+package okx
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const defaultOrderTimeout = 3 * time.Second
+
+// 私有 WS 交易类 op
+const (
+	OpOrder             = "order"
+	OpBatchOrders       = "batch-orders"
+	OpCancelOrder       = "cancel-order"
+	OpBatchCancelOrders = "batch-cancel-orders"
+	OpAmendOrder        = "amend-order"
+	OpBatchAmendOrders  = "batch-amend-orders"
+)
+
+// SetOrderTimeout 设置 WS 交易请求等待响应的超时，默认 3s，可在请求进行中调用
+func (p *OKExV5WsPrivate) SetOrderTimeout(d time.Duration) {
+	p.orderTimeout.Store(int64(d))
+}
+
+// PlaceOrder 通过私有 WS 下单，ClOrdId 可使用 GenerateOrderClientId 生成
+func (p *OKExV5WsPrivate) PlaceOrder(req PlaceOrderReq) (OrderResult, error) {
+	return p.orderOne(OpOrder, req)
+}
+
+func (p *OKExV5WsPrivate) CancelOrder(req CancelOrderReq) (OrderResult, error) {
+	return p.orderOne(OpCancelOrder, req)
+}
+
+func (p *OKExV5WsPrivate) AmendOrder(req AmendOrderReq) (OrderResult, error) {
+	return p.orderOne(OpAmendOrder, req)
+}
+
+// BatchOrders 最多 20 笔；部分失败时同时返回全部结果与 *APIError
+func (p *OKExV5WsPrivate) BatchOrders(reqs []PlaceOrderReq) ([]OrderResult, error) {
+	return p.orderBatch(OpBatchOrders, reqs)
+}
+
+func (p *OKExV5WsPrivate) BatchCancelOrders(reqs []CancelOrderReq) ([]OrderResult, error) {
+	return p.orderBatch(OpBatchCancelOrders, reqs)
+}
+
+func (p *OKExV5WsPrivate) BatchAmendOrders(reqs []AmendOrderReq) ([]OrderResult, error) {
+	return p.orderBatch(OpBatchAmendOrders, reqs)
+}
+
+func (p *OKExV5WsPrivate) orderOne(op string, req any) (result OrderResult, err error) {
+	ret, err := p.orderBatch(op, []any{req})
+	if len(ret) > 0 {
+		result = ret[0]
+		if err == nil {
+			err = result.Err()
+		}
+	}
+	return
+}
+
+func (p *OKExV5WsPrivate) orderBatch(op string, args any) (result []OrderResult, err error) {
+	if err = p.ConnectWs(); err != nil {
+		return
+	}
+	timeout := time.Duration(p.orderTimeout.Load())
+	if timeout <= 0 {
+		timeout = defaultOrderTimeout
+	}
+
+	msg, err := p.WsConn.Request(op, args, timeout)
+	if err != nil {
+		return
+	}
+	if len(msg.Data) > 0 {
+		if err = json.Unmarshal(msg.Data, &result); err != nil {
+			return
+		}
+	}
+	if msg.Code != "0" {
+		err = orderError(msg.Code, msg.Msg, result)
+	}
+	return
+}
@@ -0,0 +1,155 @@
+package okx
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// orderAck 逐笔结果：instId 为 rejectInstId 的订单返回 51008，其余以 instId 作为 ordId；
+// 全部失败 code=1，部分失败 code=2
+func orderAck(req subRequest) map[string]any {
+	var data []map[string]string
+	rejected := 0
+	for _, a := range req.Args {
+		if a.InstId == rejectInstId {
+			data = append(data, map[string]string{"ordId": "", "sCode": "51008", "sMsg": "Order failed. Insufficient balance"})
+			rejected++
+			continue
+		}
+		data = append(data, map[string]string{"ordId": a.InstId, "sCode": "0", "sMsg": ""})
+	}
+	code := "0"
+	switch {
+	case rejected == len(data):
+		code = "1"
+	case rejected > 0:
+		code = "2"
+	}
+	return map[string]any{"code": code, "msg": "", "data": data}
+}
+
+func TestOKExV5WsPrivate_orderIdCorrelation(t *testing.T) {
+	server := newFakeOKXPrivate(t, func(conn, n int) bool { return false })
+	// 先到的 BTC 订单最后应答，响应顺序与请求顺序相反
+	btc := make(chan func(), 1)
+	server.onTrade(func(req subRequest, reply func(map[string]any)) {
+		if req.Args[0].InstId == "BTC-USDT" {
+			btc <- func() { reply(orderAck(req)) }
+			return
+		}
+		reply(orderAck(req))
+	})
+	pri := server.client()
+	if err := pri.ConnectWs(); err != nil {
+		t.Fatal(err)
+	}
+	defer pri.WsConn.CloseWs()
+
+	var wg sync.WaitGroup
+	results := make(map[string]string)
+	var mu sync.Mutex
+	order := func(instId string) {
+		defer wg.Done()
+		ret, err := pri.PlaceOrder(PlaceOrderReq{InstId: instId, TdMode: "cash", Side: "buy", OrdType: "market", Sz: "1"})
+		if err != nil {
+			t.Errorf("PlaceOrder(%s) error = %v", instId, err)
+		}
+		mu.Lock()
+		results[instId] = ret.OrdId
+		mu.Unlock()
+	}
+	wg.Add(1)
+	go order("BTC-USDT")
+	var reply func()
+	select {
+	case reply = <-btc:
+	case <-time.After(time.Second):
+		t.Fatal("BTC-USDT order not received")
+	}
+	wg.Add(2)
+	go order("ETH-USDT")
+	go order("SOL-USDT")
+	pri.SetOrderTimeout(2 * time.Second) // 与进行中的请求并发设置
+	if !waitFor(t, time.Second, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(results) == 2
+	}) {
+		t.Fatal("ETH-USDT/SOL-USDT orders not answered before BTC-USDT")
+	}
+	reply()
+	wg.Wait()
+
+	want := map[string]string{"BTC-USDT": "BTC-USDT", "ETH-USDT": "ETH-USDT", "SOL-USDT": "SOL-USDT"}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("ordIds = %v, want %v", results, want)
+	}
+}
+
+func TestOKExV5WsPrivate_orderErrors(t *testing.T) {
+	server := newFakeOKXPrivate(t, func(conn, n int) bool { return false })
+	server.onTrade(func(req subRequest, reply func(map[string]any)) {
+		if req.Args[0].InstId == "SLOW-USDT" {
+			return
+		}
+		reply(orderAck(req))
+	})
+	pri := server.client()
+	pri.SetOrderTimeout(100 * time.Millisecond)
+	if err := pri.ConnectWs(); err != nil {
+		t.Fatal(err)
+	}
+	defer pri.WsConn.CloseWs()
+
+	insufficient := &APIError{Code: "1", SCode: "51008", SMsg: "Order failed. Insufficient balance"}
+	tests := []struct {
+		name    string
+		instIds []string
+		wantIds []string
+		wantErr error
+	}{
+		{name: "ok", instIds: []string{"BTC-USDT"}, wantIds: []string{"BTC-USDT"}},
+		{name: "sCode", instIds: []string{rejectInstId}, wantIds: []string{""}, wantErr: insufficient},
+		{
+			name:    "batch partial",
+			instIds: []string{"BTC-USDT", rejectInstId},
+			wantIds: []string{"BTC-USDT", ""},
+			wantErr: &APIError{Code: "2", SCode: "51008", SMsg: "Order failed. Insufficient balance"},
+		},
+		{name: "timeout", instIds: []string{"SLOW-USDT"}, wantErr: ErrRequestTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reqs []PlaceOrderReq
+			for _, id := range tt.instIds {
+				reqs = append(reqs, PlaceOrderReq{InstId: id, TdMode: "cash", Side: "buy", OrdType: "market", Sz: "1"})
+			}
+			var (
+				ids []string
+				err error
+			)
+			if len(reqs) == 1 {
+				var ret OrderResult
+				ret, err = pri.PlaceOrder(reqs[0])
+				if !errors.Is(err, ErrRequestTimeout) {
+					ids = []string{ret.OrdId}
+				}
+			} else {
+				var ret []OrderResult
+				ret, err = pri.BatchOrders(reqs)
+				for _, r := range ret {
+					ids = append(ids, r.OrdId)
+				}
+			}
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("error = %#v, want %#v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIds) {
+				t.Errorf("ordIds = %v, want %v", ids, tt.wantIds)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package okx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	subscribeTimeout = 5 * time.Second
+	replayBatchSize  = 50 // 重连后每条订阅报文携带的频道数
+)
+
+var (
+	ErrRequestTimeout = errors.New("okx: ws request timeout")
+	ErrWsClosed       = errors.New("okx: websocket closed")
+
+	idTag = []byte(`"id":`)
+)
+
+type subRequest struct {
+	Id   string  `json:"id,omitempty"`
+	Op   string  `json:"op"`
+	Args []WsArg `json:"args"`
+}
+
+func (a WsArg) key() string {
+	return a.Channel + "|" + a.InstType + "|" + a.InstFamily + "|" + a.InstId + "|" + a.Ccy + "|" + a.Uid
+}
+
+// SubscribeWait 发送订阅并阻塞到收到确认、错误或超时；
+// 频道发送时即进入重放集合，只有服务端明确返回 error 才会移除
+func (ws *WsConn) SubscribeWait(subEvent interface{}, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = subscribeTimeout
+	}
+	return ws.subscribe(subEvent, timeout)
+}
+
+// Unsubscribe 退订并从重放集合中移除
+func (ws *WsConn) Unsubscribe(args ...WsArg) error {
+	return ws.unsubscribe(args, 0)
+}
+
+// UnsubscribeWait 退订并等待确认
+func (ws *WsConn) UnsubscribeWait(timeout time.Duration, args ...WsArg) error {
+	if timeout <= 0 {
+		timeout = subscribeTimeout
+	}
+	return ws.unsubscribe(args, timeout)
+}
+
+// Subscriptions 当前重放集合中的频道
+func (ws *WsConn) Subscriptions() []WsArg {
+	ws.subsLock.Lock()
+	defer ws.subsLock.Unlock()
+	args := make([]WsArg, 0, len(ws.subs))
+	for _, a := range ws.subs {
+		args = append(args, a)
+	}
+	return args
+}
+
+func (ws *WsConn) subscribe(subEvent interface{}, wait time.Duration) error {
+	data, err := json.Marshal(subEvent)
+	if err != nil {
+		zap.S().Errorf("[ws][%s] json encode error , %s", ws.WsUrl, err)
+		return err
+	}
+
+	var req subRequest
+	if json.Unmarshal(data, &req) != nil || req.Op != "subscribe" || len(req.Args) == 0 {
+		ws.subscribeRaw(data)
+		return nil
+	}
+
+	// 确认中的频道等待同一个确认，已在重放集合中的频道不再重复发送
+	w := &subWait{done: make(chan struct{})}
+	ws.subsLock.Lock()
+	fresh := make([]WsArg, 0, len(req.Args))
+	var inflight []*subWait
+	for _, a := range req.Args {
+		k := a.key()
+		if p, ok := ws.pending[k]; ok {
+			inflight = append(inflight, p)
+			continue
+		}
+		if _, ok := ws.subs[k]; ok {
+			continue
+		}
+		ws.subs[k] = a
+		ws.pending[k] = w
+		fresh = append(fresh, a)
+	}
+	ws.subsLock.Unlock()
+
+	if len(fresh) > 0 {
+		req.Args = fresh
+		timeout := wait
+		if timeout <= 0 {
+			timeout = subscribeTimeout
+		}
+		go ws.confirm(req, w, timeout)
+		inflight = append(inflight, w)
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for _, p := range inflight {
+		select {
+		case <-p.done:
+			if p.err != nil {
+				return p.err
+			}
+		case <-timer.C:
+			return ErrRequestTimeout
+		}
+	}
+	return nil
+}
+
+// subWait 一次订阅请求的确认结果，done 关闭后 err 可读
+type subWait struct {
+	done chan struct{}
+	err  error
+}
+
+// confirm 等待订阅确认；服务端返回 error 时频道移出重放集合，
+// 超时或连接断开时保留，由重连后的重放补发
+func (ws *WsConn) confirm(req subRequest, w *subWait, wait time.Duration) {
+	w.err = ws.request(&req, wait)
+	var apiErr *APIError
+	rejected := errors.As(w.err, &apiErr)
+	ws.subsLock.Lock()
+	for _, a := range req.Args {
+		k := a.key()
+		if ws.pending[k] != w { // 确认前已退订
+			continue
+		}
+		delete(ws.pending, k)
+		if rejected {
+			delete(ws.subs, k)
+		}
+	}
+	ws.subsLock.Unlock()
+	if w.err != nil {
+		zap.S().Errorf("[ws][%s] subscribe %d channels err: %s", ws.WsUrl, len(req.Args), w.err)
+	}
+	close(w.done)
+}
+
+func (ws *WsConn) subscribeRaw(data []byte) {
+	ws.subsLock.Lock()
+	dup := false
+	for _, sub := range ws.rawSubs {
+		if bytes.Equal(sub, data) {
+			dup = true
+			break
+		}
+	}
+	if !dup {
+		ws.rawSubs = append(ws.rawSubs, data)
+	}
+	ws.subsLock.Unlock()
+	ws.SendMessage(data)
+}
+
+func (ws *WsConn) unsubscribe(args []WsArg, wait time.Duration) error {
+	if len(args) == 0 {
+		return nil
+	}
+	ws.forget(args)
+	req := subRequest{Op: "unsubscribe", Args: args}
+	if wait <= 0 {
+		return ws.SendJsonMessage(req)
+	}
+	return ws.request(&req, wait)
+}
+
+func (ws *WsConn) forget(args []WsArg) {
+	ws.subsLock.Lock()
+	for _, a := range args {
+		delete(ws.subs, a.key())
+		delete(ws.pending, a.key())
+	}
+	ws.subsLock.Unlock()
+}
+
+// request 以 id 关联请求与服务端的 subscribe/unsubscribe/error 事件
+func (ws *WsConn) request(req *subRequest, wait time.Duration) error {
+	if req.Id == "" {
+		req.Id = ws.nextId()
+	}
+	msg, err := ws.roundTrip(req.Id, req, wait)
+	if err != nil {
+		return err
+	}
+	if msg.Event == "error" {
+		return &APIError{Code: msg.Code, Msg: msg.Msg}
+	}
+	return nil
+}
+
+// Request 发送交易类 op（order / batch-orders / cancel-order ...）并等待同 id 的响应
+func (ws *WsConn) Request(op string, args any, timeout time.Duration) (WsMessage, error) {
+	id := ws.nextId()
+	return ws.roundTrip(id, map[string]any{"id": id, "op": op, "args": args}, timeout)
+}
+
+func (ws *WsConn) nextId() string {
+	return strconv.FormatInt(ws.ackSeq.Add(1), 10)
+}
+
+// roundTrip 发送携带 id 的请求并等待同 id 的响应
+func (ws *WsConn) roundTrip(id string, req any, wait time.Duration) (WsMessage, error) {
+	ch := make(chan WsMessage, 1)
+	ws.subsLock.Lock()
+	ws.acks[id] = ch
+	ws.subsLock.Unlock()
+	defer func() {
+		ws.subsLock.Lock()
+		delete(ws.acks, id)
+		ws.subsLock.Unlock()
+	}()
+
+	if err := ws.SendJsonMessage(req); err != nil {
+		return WsMessage{}, err
+	}
+	select {
+	case msg := <-ch:
+		return msg, nil
+	case <-time.After(wait):
+		return WsMessage{}, ErrRequestTimeout
+	case <-ws.close:
+		return WsMessage{}, ErrWsClosed
+	}
+}
+
+// dispatch 先处理请求响应，再交给业务协议处理函数
+func (ws *WsConn) dispatch(msg []byte) {
+	if bytes.Contains(msg, idTag) {
+		ws.resolveAck(msg)
+	}
+	ws.ProtoHandleFunc(msg)
+}
+
+func (ws *WsConn) resolveAck(msg []byte) {
+	var ev WsMessage
+	if json.Unmarshal(msg, &ev) != nil || ev.Id == "" {
+		return
+	}
+	ws.subsLock.Lock()
+	ch, ok := ws.acks[ev.Id]
+	delete(ws.acks, ev.Id)
+	ws.subsLock.Unlock()
+	if ok {
+		ch <- ev
+	}
+}
+
+// replaySubscriptions 重连后按批重放全部订阅，包括仍在等待确认的频道
+func (ws *WsConn) replaySubscriptions() {
+	ws.subsLock.Lock()
+	args := make([]WsArg, 0, len(ws.subs))
+	for _, a := range ws.subs {
+		args = append(args, a)
+	}
+	raws := append([][]byte(nil), ws.rawSubs...)
+	ws.subsLock.Unlock()
+
+	for i := 0; i < len(args); i += replayBatchSize {
+		end := min(i+replayBatchSize, len(args))
+		zap.S().Infof("[ws][%s] re subscribe %d channels", ws.WsUrl, end-i)
+		if err := ws.SendJsonMessage(subRequest{Op: "subscribe", Args: args[i:end]}); err != nil {
+			zap.S().Errorf("[ws][%s] re subscribe err: %s", ws.WsUrl, err)
+		}
+	}
+	for _, sub := range raws {
+		zap.S().Infof("[ws] re subscribe: %s", string(sub))
+		ws.SendMessage(sub)
+	}
+}
@@ -0,0 +1,69 @@
+package okx
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func instIds(args []WsArg) []string {
+	ids := make([]string, 0, len(args))
+	for _, a := range args {
+		ids = append(ids, a.InstId)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestWsConn_replayPendingAfterReconnect(t *testing.T) {
+	server := newFakeOKX(t)
+	server.mute(0) // 第一条连接收到订阅但不确认
+	pub := NewOKExV5WsPublic(&APIConfig{Endpoint: server.url()}, nil, nil)
+
+	// 不等待确认的订阅：重连时仍在等待确认
+	if err := pub.SubscribeArgs(WsArg{Channel: ChannelTickers, InstId: "BTC-USDT"}); err != nil {
+		t.Fatal(err)
+	}
+	defer pub.WsConn.CloseWs()
+	// 等待确认超时的订阅
+	req := map[string]any{"op": "subscribe", "args": []WsArg{{Channel: ChannelTickers, InstId: "ETH-USDT"}}}
+	if err := pub.WsConn.SubscribeWait(req, 100*time.Millisecond); !errors.Is(err, ErrRequestTimeout) {
+		t.Fatalf("SubscribeWait() error = %v, want %v", err, ErrRequestTimeout)
+	}
+	if got := instIds(pub.WsConn.Subscriptions()); len(got) != 2 {
+		t.Fatalf("Subscriptions() = %v, want both channels kept", got)
+	}
+
+	server.kick(0)
+	ok := waitFor(t, 5*time.Second, func() bool {
+		return server.connOf("BTC-USDT") == 1 && server.connOf("ETH-USDT") == 1
+	})
+	if !ok {
+		t.Fatalf("channels not replayed on new connection: BTC-USDT on %d, ETH-USDT on %d",
+			server.connOf("BTC-USDT"), server.connOf("ETH-USDT"))
+	}
+}
+
+func TestWsConn_subscribeRejected(t *testing.T) {
+	server := newFakeOKX(t)
+	pub := NewOKExV5WsPublic(&APIConfig{Endpoint: server.url()}, nil, nil)
+	if err := pub.SubscribeArgs(WsArg{Channel: ChannelTickers, InstId: "BTC-USDT"}); err != nil {
+		t.Fatal(err)
+	}
+	defer pub.WsConn.CloseWs()
+
+	req := map[string]any{"op": "subscribe", "args": []WsArg{{Channel: ChannelTickers, InstId: rejectInstId}}}
+	var apiErr *APIError
+	if err := pub.WsConn.SubscribeWait(req, time.Second); !errors.As(err, &apiErr) {
+		t.Fatalf("SubscribeWait() error = %v, want *APIError", err)
+	}
+	// 只有服务端明确拒绝的频道移出重放集合
+	ok := waitFor(t, time.Second, func() bool {
+		got := instIds(pub.WsConn.Subscriptions())
+		return len(got) == 1 && got[0] == "BTC-USDT"
+	})
+	if !ok {
+		t.Errorf("Subscriptions() = %v, want [BTC-USDT]", instIds(pub.WsConn.Subscriptions()))
+	}
+}
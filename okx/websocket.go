@@ -8,6 +8,7 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -26,6 +27,7 @@ type WsConfig struct {
 	ErrorHandleFunc                func(err error)
 	ConnectSuccessAfterSendMessage func() []byte //for reconnect
 	ConnectedHandleFunc            func(err error)
+	ReplayReadyFunc                func() error //重连后重放订阅前的等待，如登录结果
 	IsDump                         bool
 	DisableEnableCompression       bool
 	readDeadLineTime               time.Duration
@@ -50,7 +52,12 @@ type WsConn struct {
 	pingMessageBufferChan  chan []byte
 	pongMessageBufferChan  chan []byte
 	closeMessageBufferChan chan []byte
-	subs                   [][]byte
+	subs                   map[string]WsArg    // 已确认的订阅，按 channel+instId 去重，重连后重放
+	pending                map[string]*subWait // 已发送、等待确认的订阅
+	rawSubs                [][]byte            // 无法解析为 WsArg 的订阅报文，原样重放
+	subsLock               sync.Mutex
+	acks                   map[string]chan WsMessage // 等待响应的请求 id（订阅确认 / 交易类 op）
+	ackSeq                 atomic.Int64
 	close                  chan struct{}
 	reConnectLock          *sync.Mutex
 	writeLock              sync.Mutex // ★新增：全局写锁★
@@ -128,6 +135,11 @@ func (b *WsBuilder) ConnectedHandleFunc(msg func(err error)) *WsBuilder {
 	return b
 }
 
+func (b *WsBuilder) ReplayReadyFunc(f func() error) *WsBuilder {
+	b.wsConfig.ReplayReadyFunc = f
+	return b
+}
+
 func (b *WsBuilder) ConnectSuccessAfterSendMessage(msg func() []byte) *WsBuilder {
 	b.wsConfig.ConnectSuccessAfterSendMessage = msg
 	return b
@@ -146,6 +158,9 @@ func (ws *WsConn) NewWs() *WsConn {
 	ws.pongMessageBufferChan = make(chan []byte, 4)
 	ws.closeMessageBufferChan = make(chan []byte, 2)
 	ws.reConnectLock = new(sync.Mutex)
+	ws.subs = make(map[string]WsArg)
+	ws.pending = make(map[string]*subWait)
+	ws.acks = make(map[string]chan WsMessage)
 
 	/* ---- 2. 读超时 ---- */
 	if ws.HeartbeatIntervalTime == 0 {
@@ -252,6 +267,11 @@ func (ws *WsConn) reconnect() {
 	_ = ws.c.Close()
 	backoff := time.Second
 	for {
+		select {
+		case <-ws.close: // 重连期间被 CloseWs 关闭
+			return
+		default:
+		}
 		if err := ws.connect(); err != nil {
 			zap.S().Errorf("[ws][%s] reconnect fail: %s", ws.WsUrl, err)
 			time.Sleep(backoff)
@@ -273,14 +293,58 @@ func (ws *WsConn) reconnect() {
 		msg := ws.ConnectSuccessAfterSendMessage()
 		ws.SendMessage(msg)
 		zap.S().Errorf("[ws] [%s] execute the connect success after send message=%s", ws.WsUrl, string(msg))
+	}
+
+	// reconnect 运行在读协程中，等待登录等响应必须放到独立协程，否则响应无人读取
+	go ws.resume(ws.c)
+}
+
+// replayReadyRetry 重连后 ReplayReadyFunc 失败时重发 ConnectSuccessAfterSendMessage 的次数
+const replayReadyRetry = 3
+
+// resume 重连后等待 ReplayReadyFunc（如登录结果）再重放订阅。
+// 失败时按 reconnectInterval 起步的退避重发 ConnectSuccessAfterSendMessage（如重新登录），
+// 仍失败则交给 ErrorHandleFunc 并断开连接 c，由读协程重新拨号
+func (ws *WsConn) resume(c *websocket.Conn) {
+	switch {
+	case ws.ReplayReadyFunc != nil:
+		if err := ws.waitReady(); err != nil {
+			zap.S().Errorf("[ws][%s] not ready to re subscribe, redial: %s", ws.WsUrl, err)
+			if ws.ErrorHandleFunc != nil {
+				ws.ErrorHandleFunc(err)
+			}
+			_ = c.Close()
+			return
+		}
+	case ws.ConnectSuccessAfterSendMessage != nil:
 		time.Sleep(time.Second) //wait response
 	}
+	select {
+	case <-ws.close:
+		return
+	default:
+	}
+	ws.replaySubscriptions()
+}
 
-	for _, sub := range ws.subs {
-		zap.S().Infof("[ws] re subscribe: %s", string(sub))
-		ws.SendMessage(sub)
+func (ws *WsConn) waitReady() error {
+	err := ws.ReplayReadyFunc()
+	if ws.ConnectSuccessAfterSendMessage == nil {
+		return err
 	}
-	// }
+	backoff := ws.reconnectInterval
+	for i := 1; err != nil && i <= replayReadyRetry; i++ {
+		zap.S().Warnf("[ws][%s] not ready: %s, resend connect message %d/%d in %v", ws.WsUrl, err, i, replayReadyRetry, backoff)
+		select {
+		case <-ws.close:
+			return err
+		case <-time.After(backoff):
+		}
+		backoff <<= 1
+		ws.SendMessage(ws.ConnectSuccessAfterSendMessage())
+		err = ws.ReplayReadyFunc()
+	}
+	return err
 }
 
 func (ws *WsConn) writeRequest() {
@@ -327,15 +391,9 @@ func (ws *WsConn) safeWrite(mt int, data []byte) {
 	}
 }
 
+// Subscribe 发送订阅，不等待服务端确认；确认后频道才记录到重放集合
 func (ws *WsConn) Subscribe(subEvent interface{}) error {
-	data, err := json.Marshal(subEvent)
-	if err != nil {
-		zap.S().Errorf("[ws][%s] json encode error , %s", ws.WsUrl, err)
-		return err
-	}
-	ws.writeBufferChan <- data
-	ws.subs = append(ws.subs, data)
-	return nil
+	return ws.subscribe(subEvent, 0)
 }
 
 func (ws *WsConn) SendMessage(msg []byte) {
@@ -409,16 +467,16 @@ func (ws *WsConn) receiveMessage() {
 			ws.c.SetReadDeadline(time.Now().Add(ws.readDeadLineTime))
 			switch t {
 			case websocket.TextMessage:
-				ws.ProtoHandleFunc(msg)
+				ws.dispatch(msg)
 			case websocket.BinaryMessage:
 				if ws.DecompressFunc == nil {
-					ws.ProtoHandleFunc(msg)
+					ws.dispatch(msg)
 				} else {
 					msg2, err := ws.DecompressFunc(msg)
 					if err != nil {
 						zap.S().Errorf("[ws][%s] decompress error %s", ws.WsUrl, err.Error())
 					} else {
-						ws.ProtoHandleFunc(msg2)
+						ws.dispatch(msg2)
 					}
 				}
 				//	case websocket.CloseMessage: